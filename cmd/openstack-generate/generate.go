@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/terraform-providers/terraform-provider-openstack/openstack"
+)
+
+// object is an existing OpenStack object which is generated as a Terraform
+// resource.
+type object struct {
+	resourceType string
+
+	// kind is a short name of the resource type. It names objects which
+	// have no name of their own.
+	kind string
+
+	// label is the name of the object in OpenStack, name its Terraform
+	// resource name.
+	label string
+	name  string
+
+	// id is the ID which other objects refer to the object with, importID
+	// the ID which the Importer of the resource expects.
+	id       string
+	importID string
+
+	schema map[string]*schema.Schema
+	data   *schema.ResourceData
+}
+
+// address returns the Terraform address of the resource.
+func (o *object) address() string {
+	return o.resourceType + "." + o.name
+}
+
+// lister lists the objects of one resource type in a project.
+type lister struct {
+	resourceType string
+	kind         string
+	list         func(ctx context.Context, g *generator) ([]*object, error)
+}
+
+// generator enumerates a project with the clients of a configured provider
+// and imports every object with the Importer and Read of its resource, the
+// way terraform import does.
+type generator struct {
+	provider  *schema.Provider
+	config    *openstack.Config
+	projectID string
+
+	// warnings receives the objects and services which are skipped.
+	warnings io.Writer
+}
+
+func newGenerator(ctx context.Context, provider *schema.Provider, warnings io.Writer) (*generator, error) {
+	config := provider.Meta().(*openstack.Config)
+
+	identityClient, err := config.IdentityV3Client(ctx, config.Region)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating OpenStack identity client: %s", err)
+	}
+	_, projectID, err := openstack.GetTokenInfo(identityClient)
+	if err != nil {
+		return nil, fmt.Errorf("Error determining the project of the token: %s", err)
+	}
+
+	return &generator{
+		provider:  provider,
+		config:    config,
+		projectID: projectID,
+		warnings:  warnings,
+	}, nil
+}
+
+// generate imports the objects of the given resource types, or of all
+// supported types if none are given.
+func (g *generator) generate(ctx context.Context, resourceTypes []string) ([]*object, error) {
+	selected := make(map[string]bool)
+	for _, t := range resourceTypes {
+		if !supportedResourceType(t) {
+			return nil, fmt.Errorf("Unsupported resource type %s", t)
+		}
+		selected[t] = true
+	}
+
+	var objects []*object
+	for _, l := range listers {
+		if len(selected) > 0 && !selected[l.resourceType] {
+			continue
+		}
+
+		listed, err := l.list(ctx, g)
+		if err != nil {
+			if unsupported(err) {
+				fmt.Fprintf(g.warnings, "Skipping %s: %s\n", l.resourceType, err)
+				continue
+			}
+			return nil, fmt.Errorf("Error listing %s: %s", l.resourceType, err)
+		}
+
+		for _, obj := range listed {
+			obj.resourceType = l.resourceType
+			obj.kind = l.kind
+		}
+		sort.SliceStable(listed, func(i, j int) bool {
+			return listed[i].label < listed[j].label
+		})
+		objects = append(objects, listed...)
+	}
+
+	nameObjects(objects)
+
+	var imported []*object
+	for _, obj := range objects {
+		ok, err := g.importObject(ctx, obj)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			fmt.Fprintf(g.warnings, "Skipping %s: %s no longer exists\n", obj.address(), obj.importID)
+			continue
+		}
+		imported = append(imported, obj)
+	}
+
+	return imported, nil
+}
+
+// importObject imports an object and reads it. It returns false if the
+// object has vanished in the meantime.
+func (g *generator) importObject(ctx context.Context, obj *object) (bool, error) {
+	r := g.provider.ResourcesMap[obj.resourceType]
+
+	d := r.Data(nil)
+	d.SetId(obj.importID)
+
+	log.Printf("[DEBUG] Importing %s %s", obj.address(), obj.importID)
+
+	states, err := r.Importer.StateContext(ctx, d, g.provider.Meta())
+	if err != nil {
+		return false, fmt.Errorf("Error importing %s %s: %s", obj.address(), obj.importID, err)
+	}
+	if len(states) == 0 {
+		return false, nil
+	}
+
+	d = states[0]
+	if diags := r.ReadContext(ctx, d, g.provider.Meta()); diags.HasError() {
+		var summaries []string
+		for _, d := range diags {
+			summaries = append(summaries, d.Summary)
+		}
+		return false, fmt.Errorf("Error reading %s %s: %s", obj.address(), obj.importID, strings.Join(summaries, "; "))
+	}
+	if d.Id() == "" {
+		return false, nil
+	}
+
+	// Objects of the project of the credentials are created in it anyway,
+	// so the project is only generated for objects of other projects.
+	for _, k := range []string{"tenant_id", "project_id"} {
+		if _, ok := r.Schema[k]; ok && d.Get(k) == g.projectID {
+			if err := d.Set(k, ""); err != nil {
+				return false, fmt.Errorf("Error clearing %s of %s: %s", k, obj.address(), err)
+			}
+		}
+	}
+	obj.schema = r.Schema
+	obj.data = d
+
+	return true, nil
+}
+
+// unsupported returns true if err means that the cloud offers no service or
+// extension for a resource type.
+func unsupported(err error) bool {
+	switch err.(type) {
+	case *gophercloud.ErrEndpointNotFound, gophercloud.ErrDefault404:
+		return true
+	}
+
+	return false
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// resourceName turns the name of an object into a valid Terraform resource
+// name.
+func resourceName(label string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(label), "_")
+	name = strings.Trim(name, "_")
+	if name != "" && (name[0] == '-' || (name[0] >= '0' && name[0] <= '9')) {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// nameObjects assigns unique resource names to objects. Objects without a
+// name are named after their kind and ID.
+func nameObjects(objects []*object) {
+	used := make(map[string]bool)
+	for _, obj := range objects {
+		name := resourceName(obj.label)
+		if name == "" {
+			id := obj.id
+			if len(id) > 8 {
+				id = id[:8]
+			}
+			name = resourceName(obj.kind + "_" + id)
+		}
+
+		unique := name
+		for i := 2; used[obj.resourceType+"."+unique]; i++ {
+			unique = fmt.Sprintf("%s_%d", name, i)
+		}
+		used[obj.resourceType+"."+unique] = true
+		obj.name = unique
+	}
+}
+
+func supportedResourceType(resourceType string) bool {
+	for _, l := range listers {
+		if l.resourceType == resourceType {
+			return true
+		}
+	}
+
+	return false
+}
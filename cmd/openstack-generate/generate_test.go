@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/listeners"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/monitors"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/pools"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+	"github.com/terraform-providers/terraform-provider-openstack/openstack"
+)
+
+func testFakeGenerator(t *testing.T, srv *fakeopenstack.Server) *generator {
+	provider, err := configureProvider(context.Background(), map[string]interface{}{
+		"auth_url":    srv.AuthURL(),
+		"user_name":   srv.Username,
+		"password":    srv.Password,
+		"tenant_name": srv.ProjectName,
+		"region":      srv.Region,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := newGenerator(context.Background(), provider, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestGenerate(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	for _, kind := range []fakeopenstack.Kind{
+		fakeopenstack.LBLoadBalancers,
+		fakeopenstack.LBListeners,
+		fakeopenstack.LBPools,
+		fakeopenstack.LBMembers,
+		fakeopenstack.LBMonitors,
+	} {
+		srv.SetLifecycle(kind, fakeopenstack.Lifecycle{})
+	}
+
+	g := testFakeGenerator(t, srv)
+	config := g.provider.Meta().(*openstack.Config)
+
+	ctx := context.Background()
+	networkingClient, err := config.NetworkingV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	computeClient, err := config.ComputeV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var externalNetworkID string
+	for _, network := range srv.Objects(fakeopenstack.NetworkNetworks) {
+		if network["name"] == fakeopenstack.ExternalNetworkName {
+			externalNetworkID = network["id"].(string)
+		}
+	}
+
+	network, err := networks.Create(networkingClient, networks.CreateOpts{Name: "private net"}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subnet, err := subnets.Create(networkingClient, subnets.CreateOpts{
+		Name:      "private-subnet",
+		NetworkID: network.ID,
+		CIDR:      "192.168.10.0/24",
+		IPVersion: 4,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	router, err := routers.Create(networkingClient, routers.CreateOpts{
+		Name:        "router",
+		GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetworkID},
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = routers.AddInterface(networkingClient, router.ID, routers.AddInterfaceOpts{SubnetID: subnet.ID}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := groups.Create(networkingClient, groups.CreateOpts{Name: "web"}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = rules.Create(networkingClient, rules.CreateOpts{
+		SecGroupID:     group.ID,
+		Direction:      rules.DirIngress,
+		EtherType:      rules.EtherType4,
+		Protocol:       rules.ProtocolTCP,
+		PortRangeMin:   80,
+		PortRangeMax:   80,
+		RemoteIPPrefix: "0.0.0.0/0",
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := ports.Create(networkingClient, ports.CreateOpts{
+		Name:           "vip",
+		NetworkID:      network.ID,
+		SecurityGroups: &[]string{group.ID},
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = floatingips.Create(networkingClient, floatingips.CreateOpts{
+		FloatingNetworkID: externalNetworkID,
+		PortID:            port.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image := srv.Objects(fakeopenstack.ImageImages)[0]
+	server, err := servers.Create(computeClient, servers.CreateOpts{
+		Name:      "web-1",
+		ImageRef:  image["id"].(string),
+		FlavorRef: "2",
+		Networks:  []servers.Network{{UUID: network.ID}},
+		Metadata:  map[string]string{"role": "${web}"},
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.SetStatus(fakeopenstack.ComputeServers, server.ID, "ACTIVE"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = volumes.Create(blockStorageClient, volumes.CreateOpts{Name: "data", Size: 1}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lb, err := loadbalancers.Create(networkingClient, loadbalancers.CreateOpts{
+		Name:        "lb",
+		VipSubnetID: subnet.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := listeners.Create(networkingClient, listeners.CreateOpts{
+		Name:           "http",
+		LoadbalancerID: lb.ID,
+		Protocol:       listeners.ProtocolHTTP,
+		ProtocolPort:   80,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := pools.Create(networkingClient, pools.CreateOpts{
+		Name:       "backend",
+		ListenerID: listener.ID,
+		LBMethod:   pools.LBMethodRoundRobin,
+		Protocol:   pools.ProtocolHTTP,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	member, err := pools.CreateMember(networkingClient, pool.ID, pools.CreateMemberOpts{
+		Address:      "192.168.10.100",
+		ProtocolPort: 8080,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitor, err := monitors.Create(networkingClient, monitors.CreateOpts{
+		Name:          "check",
+		PoolID:        pool.ID,
+		Type:          monitors.TypeHTTP,
+		URLPath:       "/",
+		ExpectedCodes: "200",
+		Delay:         5,
+		Timeout:       3,
+		MaxRetries:    3,
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects, err := g.generate(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := writeHCL(&out, objects); err != nil {
+		t.Fatal(err)
+	}
+	hclText := out.String()
+
+	file, diags := hclsyntax.ParseConfig(out.Bytes(), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("Generated configuration is invalid: %s\n%s", diags.Error(), hclText)
+	}
+	content, diags := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("Generated configuration has unexpected contents: %s\n%s", diags.Error(), hclText)
+	}
+
+	var addresses []string
+	for _, block := range content.Blocks {
+		addresses = append(addresses, block.Labels[0]+"."+block.Labels[1])
+	}
+	expected := []string{
+		"openstack_networking_network_v2.private_net",
+		"openstack_networking_network_v2.public",
+		"openstack_networking_subnet_v2.private-subnet",
+		"openstack_networking_subnet_v2.public-subnet",
+		"openstack_networking_router_v2.router",
+		"openstack_networking_router_interface_v2.router_interface_",
+		"openstack_networking_secgroup_v2.web",
+		"openstack_networking_secgroup_rule_v2.secgroup_rule_",
+		"openstack_networking_port_v2.vip",
+		"openstack_compute_instance_v2.web-1",
+		"openstack_blockstorage_volume_v3.data",
+		"openstack_networking_floatingip_v2.floatingip_",
+		"openstack_lb_loadbalancer_v2.lb",
+		"openstack_lb_listener_v2.http",
+		"openstack_lb_pool_v2.backend",
+		"openstack_lb_member_v2.member_",
+		"openstack_lb_monitor_v2.check",
+	}
+	if len(addresses) != len(expected) {
+		t.Fatalf("Expected resources %v, got %v\n%s", expected, addresses, hclText)
+	}
+	for i, address := range addresses {
+		if !strings.HasPrefix(address, expected[i]) {
+			t.Fatalf("Expected resource %d to be %s, got %s\n%s", i, expected[i], address, hclText)
+		}
+	}
+
+	// Alignment of the equals signs depends on the neighbouring arguments.
+	unaligned := regexp.MustCompile(`(\S) +=`).ReplaceAllString(hclText, "$1 =")
+	for _, s := range []string{
+		// Import commands use the IDs the Importers expect.
+		"# terraform import openstack_lb_member_v2.member_" + member.ID[:8] + " " + pool.ID + "/" + member.ID + "\n",
+		"# terraform import openstack_lb_monitor_v2.check " + monitor.ID + "/" + pool.ID + "\n",
+		// References replace the IDs of generated objects.
+		"  network_id = openstack_networking_network_v2.private_net.id\n",
+		"  router_id = openstack_networking_router_v2.router.id\n",
+		"  security_group_id = openstack_networking_secgroup_v2.web.id\n",
+		"  port_id = openstack_networking_port_v2.vip.id\n",
+		"  pool_id = openstack_lb_pool_v2.backend.id\n",
+		"    uuid = openstack_networking_network_v2.private_net.id\n",
+		// Templates are escaped.
+		"    \"role\" = \"$${web}\"\n",
+	} {
+		if !strings.Contains(unaligned, s) {
+			t.Errorf("Expected generated configuration to contain %q:\n%s", s, hclText)
+		}
+	}
+
+	for _, s := range []string{
+		"openstack_networking_secgroup_v2.default",
+		"region",
+		// The project of the credentials is implied.
+		"tenant_id",
+		// Blocks with only zero values are left out.
+		"persistence",
+	} {
+		if strings.Contains(hclText, s) {
+			t.Errorf("Expected generated configuration not to contain %q:\n%s", s, hclText)
+		}
+	}
+
+	var script bytes.Buffer
+	if err := writeImportScript(&script, objects); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(script.String(), "terraform import 'openstack_networking_port_v2.vip' '"+port.ID+"'\n") {
+		t.Errorf("Unexpected import script:\n%s", script.String())
+	}
+}
+
+func TestGenerateUnsupportedResourceType(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	g := testFakeGenerator(t, srv)
+	if _, err := g.generate(context.Background(), []string{"openstack_compute_keypair_v2"}); err == nil {
+		t.Fatal("Expected an error for an unsupported resource type")
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	for label, expected := range map[string]string{
+		"web":             "web",
+		"Web Server (1)":  "web_server_1",
+		"10.0.0.1":        "_10_0_0_1",
+		"-dash":           "_-dash",
+		"":                "",
+		"floatingip_1.2.": "floatingip_1_2",
+	} {
+		if actual := resourceName(label); actual != expected {
+			t.Errorf("Expected resource name %q for %q, got %q", expected, label, actual)
+		}
+	}
+}
+
+func TestNameObjects(t *testing.T) {
+	objects := []*object{
+		{resourceType: "openstack_networking_network_v2", kind: "network", label: "net", id: "1"},
+		{resourceType: "openstack_networking_network_v2", kind: "network", label: "net", id: "2"},
+		{resourceType: "openstack_networking_subnet_v2", kind: "subnet", label: "net", id: "3"},
+		{resourceType: "openstack_networking_floatingip_v2", kind: "floatingip", id: "0123456789"},
+	}
+	nameObjects(objects)
+
+	for i, expected := range []string{"net", "net_2", "net", "floatingip_01234567"} {
+		if objects[i].name != expected {
+			t.Errorf("Expected object %d to be named %s, got %s", i, expected, objects[i].name)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for s, expected := range map[string]string{
+		"plain":        `"plain"`,
+		`say "hi"\`:    `"say \"hi\"\\"`,
+		"a\nb":         `"a\nb"`,
+		"${var} %{if}": `"$${var} %%{if}"`,
+		"$5 and 100%":  `"$5 and 100%"`,
+	} {
+		if actual := quote(s); actual != expected {
+			t.Errorf("Expected %s for %q, got %s", expected, s, actual)
+		}
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	withDefault := &schema.Schema{Type: schema.TypeBool, Optional: true, Default: true}
+	if !defaultValue(withDefault, true) || defaultValue(withDefault, false) {
+		t.Error("Expected only true to be the default value")
+	}
+
+	withoutDefault := &schema.Schema{Type: schema.TypeString, Optional: true}
+	if !defaultValue(withoutDefault, "") || defaultValue(withoutDefault, "foo") {
+		t.Error("Expected only the empty string to be the default value")
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// skippedAttributes are not generated for a resource type. The region is
+// that of the provider. A router interface is its port, which is only known
+// once the interface exists.
+var skippedAttributes = map[string][]string{
+	"*": {"region"},
+	"openstack_networking_router_interface_v2": {"port_id"},
+}
+
+// hclWriter renders imported objects as resource blocks.
+type hclWriter struct {
+	buf bytes.Buffer
+
+	// refs maps the IDs of the generated objects to references to them.
+	refs map[string]string
+}
+
+func newHCLWriter(objects []*object) *hclWriter {
+	h := &hclWriter{refs: make(map[string]string)}
+	for _, obj := range objects {
+		h.refs[obj.id] = obj.address() + ".id"
+	}
+
+	return h
+}
+
+// writeHCL writes a resource block for each object, preceded by the command
+// which imports it.
+func writeHCL(w io.Writer, objects []*object) error {
+	h := newHCLWriter(objects)
+	for i, obj := range objects {
+		if i > 0 {
+			h.buf.WriteString("\n")
+		}
+		fmt.Fprintf(&h.buf, "# terraform import %s %s\n", obj.address(), obj.importID)
+		fmt.Fprintf(&h.buf, "resource %q %q {\n", obj.resourceType, obj.name)
+		h.resourceBody(obj)
+		h.buf.WriteString("}\n")
+	}
+
+	_, err := h.buf.WriteTo(w)
+	return err
+}
+
+// writeImportScript writes a shell script which imports the objects.
+func writeImportScript(w io.Writer, objects []*object) error {
+	var buf bytes.Buffer
+	buf.WriteString("#!/bin/sh\nset -e\n\n")
+	for _, obj := range objects {
+		fmt.Fprintf(&buf, "terraform import %s %s\n", shellQuote(obj.address()), shellQuote(obj.importID))
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func (h *hclWriter) resourceBody(obj *object) {
+	skip := make(map[string]bool)
+	for _, k := range append(skippedAttributes["*"], skippedAttributes[obj.resourceType]...) {
+		skip[k] = true
+	}
+
+	// Only the attributes Read has set are generated, the others are
+	// unknown rather than zero.
+	values := make(map[string]interface{})
+	for k := range obj.data.State().Attributes {
+		k = strings.SplitN(k, ".", 2)[0]
+		if _, ok := values[k]; !ok && k != "id" {
+			values[k] = obj.data.Get(k)
+		}
+	}
+
+	h.body(1, obj.schema, values, skip)
+}
+
+type hclAttribute struct {
+	name  string
+	value string
+}
+
+// body writes the arguments of a block. Arguments which cannot be
+// configured, are deprecated or sensitive, have their default value or
+// conflict with an argument written before are left out.
+func (h *hclWriter) body(indent int, schemaMap map[string]*schema.Schema, values map[string]interface{}, skip map[string]bool) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var attributes []hclAttribute
+	var blocks []string
+	written := make(map[string]bool)
+	for _, k := range keys {
+		s, ok := schemaMap[k]
+		if !ok || skip[k] || !(s.Required || s.Optional) || s.Deprecated != "" || s.Sensitive {
+			continue
+		}
+
+		v := normalizeValue(values[k])
+		if !s.Required && defaultValue(s, v) {
+			continue
+		}
+		if conflicting(s, written) {
+			continue
+		}
+		written[k] = true
+
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			for _, e := range v.([]interface{}) {
+				m, _ := e.(map[string]interface{})
+				if emptyBlock(elem.Schema, m) {
+					continue
+				}
+				blocks = append(blocks, h.block(indent, k, elem.Schema, m))
+			}
+			continue
+		}
+
+		attributes = append(attributes, hclAttribute{k, h.value(indent, v)})
+	}
+
+	h.attributes(indent, attributes)
+	for i, block := range blocks {
+		if i > 0 || len(attributes) > 0 {
+			h.buf.WriteString("\n")
+		}
+		h.buf.WriteString(block)
+	}
+}
+
+// attributes writes attributes with their equals signs aligned the way
+// terraform fmt does.
+func (h *hclWriter) attributes(indent int, attributes []hclAttribute) {
+	prefix := strings.Repeat("  ", indent)
+	for start := 0; start < len(attributes); {
+		end, width := start, 0
+		for ; end < len(attributes); end++ {
+			if len(attributes[end].name) > width {
+				width = len(attributes[end].name)
+			}
+			if strings.Contains(attributes[end].value, "\n") {
+				end++
+				break
+			}
+		}
+
+		for _, a := range attributes[start:end] {
+			fmt.Fprintf(&h.buf, "%s%-*s = %s\n", prefix, width, a.name, a.value)
+		}
+		start = end
+	}
+}
+
+func (h *hclWriter) block(indent int, name string, schemaMap map[string]*schema.Schema, values map[string]interface{}) string {
+	prefix := strings.Repeat("  ", indent)
+
+	nested := &hclWriter{refs: h.refs}
+	fmt.Fprintf(&nested.buf, "%s%s {\n", prefix, name)
+	nested.body(indent+1, schemaMap, values, nil)
+	fmt.Fprintf(&nested.buf, "%s}\n", prefix)
+
+	return nested.buf.String()
+}
+
+// value renders a value as an HCL expression. Strings holding the ID of a
+// generated object are rendered as references to it.
+func (h *hclWriter) value(indent int, v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if ref, ok := h.refs[v]; ok {
+			return ref
+		}
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		elems := make([]string, 0, len(v))
+		for _, e := range v {
+			elems = append(elems, h.value(indent, normalizeValue(e)))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		attributes := make([]hclAttribute, 0, len(keys))
+		for _, k := range keys {
+			attributes = append(attributes, hclAttribute{quote(k), h.value(indent+1, v[k])})
+		}
+
+		nested := &hclWriter{refs: h.refs}
+		nested.buf.WriteString("{\n")
+		nested.attributes(indent+1, attributes)
+		nested.buf.WriteString(strings.Repeat("  ", indent) + "}")
+		return nested.buf.String()
+	}
+
+	return quote(fmt.Sprint(v))
+}
+
+// quote renders s as an HCL string literal. Template sequences are escaped,
+// so that s is taken literally.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func normalizeValue(v interface{}) interface{} {
+	if set, ok := v.(*schema.Set); ok {
+		return set.List()
+	}
+
+	return v
+}
+
+// defaultValue returns true if v is the default value of an argument, or
+// its zero value if it has no default.
+func defaultValue(s *schema.Schema, v interface{}) bool {
+	if s.Default != nil {
+		return reflect.DeepEqual(v, s.Default)
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return reflect.ValueOf(v).IsZero()
+}
+
+// emptyBlock returns true if all arguments of a block have their default
+// values, as blocks Read sets for absent settings do.
+func emptyBlock(schemaMap map[string]*schema.Schema, values map[string]interface{}) bool {
+	for k, v := range values {
+		if s, ok := schemaMap[k]; ok && !defaultValue(s, normalizeValue(v)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// conflicting returns true if an argument conflicting with s has been
+// written.
+func conflicting(s *schema.Schema, written map[string]bool) bool {
+	for _, c := range append(s.ConflictsWith, s.ExactlyOneOf...) {
+		parts := strings.Split(c, ".")
+		if written[parts[len(parts)-1]] {
+			return true
+		}
+	}
+
+	return false
+}
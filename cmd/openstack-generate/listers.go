@@ -0,0 +1,471 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/listeners"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/monitors"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/pools"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+// listers are in the order in which the resources are generated.
+var listers = []lister{
+	{"openstack_networking_network_v2", "network", listNetworks},
+	{"openstack_networking_subnet_v2", "subnet", listSubnets},
+	{"openstack_networking_router_v2", "router", listRouters},
+	{"openstack_networking_router_interface_v2", "router_interface", listRouterInterfaces},
+	{"openstack_networking_secgroup_v2", "secgroup", listSecGroups},
+	{"openstack_networking_secgroup_rule_v2", "secgroup_rule", listSecGroupRules},
+	{"openstack_networking_port_v2", "port", listPorts},
+	{"openstack_compute_instance_v2", "instance", listInstances},
+	{"openstack_blockstorage_volume_v3", "volume", listVolumes},
+	{"openstack_networking_floatingip_v2", "floatingip", listFloatingIPs},
+	{"openstack_lb_loadbalancer_v2", "loadbalancer", listLoadBalancers},
+	{"openstack_lb_listener_v2", "listener", listListeners},
+	{"openstack_lb_pool_v2", "pool", listPools},
+	{"openstack_lb_member_v2", "member", listMembers},
+	{"openstack_lb_monitor_v2", "monitor", listMonitors},
+}
+
+func (g *generator) networkingClient(ctx context.Context) (*gophercloud.ServiceClient, error) {
+	return g.config.NetworkingV2Client(ctx, g.config.Region)
+}
+
+// lbClient returns the client of the load balancing service the provider is
+// configured to use.
+func (g *generator) lbClient(ctx context.Context) (*gophercloud.ServiceClient, error) {
+	if g.config.UseOctavia {
+		return g.config.LoadBalancerV2Client(ctx, g.config.Region)
+	}
+
+	return g.config.NetworkingV2Client(ctx, g.config.Region)
+}
+
+// newObject returns an object whose import ID is its ID.
+func newObject(label, id string) *object {
+	return &object{label: label, id: id, importID: id}
+}
+
+func listNetworks(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := networks.List(client, networks.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allNetworks, err := networks.ExtractNetworks(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, n := range allNetworks {
+		objects = append(objects, newObject(n.Name, n.ID))
+	}
+
+	return objects, nil
+}
+
+func listSubnets(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := subnets.List(client, subnets.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allSubnets, err := subnets.ExtractSubnets(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, s := range allSubnets {
+		objects = append(objects, newObject(s.Name, s.ID))
+	}
+
+	return objects, nil
+}
+
+func listRouters(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := routers.List(client, routers.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allRouters, err := routers.ExtractRouters(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, r := range allRouters {
+		objects = append(objects, newObject(r.Name, r.ID))
+	}
+
+	return objects, nil
+}
+
+func listProjectPorts(ctx context.Context, g *generator) ([]ports.Port, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := ports.List(client, ports.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	return ports.ExtractPorts(allPages)
+}
+
+// listRouterInterfaces lists the interface ports of routers. An interface is
+// imported by the ID of its port.
+func listRouterInterfaces(ctx context.Context, g *generator) ([]*object, error) {
+	allPorts, err := listProjectPorts(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, p := range allPorts {
+		if !strings.HasPrefix(p.DeviceOwner, "network:router_interface") {
+			continue
+		}
+		objects = append(objects, newObject(p.Name, p.ID))
+	}
+
+	return objects, nil
+}
+
+// listSecGroups skips the default security group, which Neutron creates for
+// every project.
+func listSecGroups(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := groups.List(client, groups.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allGroups, err := groups.ExtractGroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, group := range allGroups {
+		if group.Name == "default" {
+			continue
+		}
+		objects = append(objects, newObject(group.Name, group.ID))
+	}
+
+	return objects, nil
+}
+
+// listSecGroupRules skips the rules of the default security group and the
+// egress rules Neutron adds to every new security group.
+func listSecGroupRules(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := groups.List(client, groups.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allGroups, err := groups.ExtractGroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+	groupNames := make(map[string]string)
+	for _, group := range allGroups {
+		groupNames[group.ID] = group.Name
+	}
+
+	allPages, err = rules.List(client, rules.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allRules, err := rules.ExtractRules(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, rule := range allRules {
+		groupName, ok := groupNames[rule.SecGroupID]
+		if !ok || groupName == "default" || defaultSecGroupRule(rule) {
+			continue
+		}
+		objects = append(objects, newObject("", rule.ID))
+	}
+
+	return objects, nil
+}
+
+func defaultSecGroupRule(rule rules.SecGroupRule) bool {
+	return rule.Direction == "egress" && rule.Protocol == "" &&
+		rule.PortRangeMin == 0 && rule.PortRangeMax == 0 &&
+		rule.RemoteIPPrefix == "" && rule.RemoteGroupID == "" &&
+		rule.Description == ""
+}
+
+// listPorts lists the ports created by users. Ports owned by a service,
+// such as DHCP ports, router interfaces and load balancer VIPs, are managed
+// by that service. So are the unnamed ports Nova creates for instances.
+func listPorts(ctx context.Context, g *generator) ([]*object, error) {
+	allPorts, err := listProjectPorts(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, p := range allPorts {
+		if p.DeviceOwner != "" && (!strings.HasPrefix(p.DeviceOwner, "compute:") || p.Name == "") {
+			continue
+		}
+		objects = append(objects, newObject(p.Name, p.ID))
+	}
+
+	return objects, nil
+}
+
+func listInstances(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.config.ComputeV2Client(ctx, g.config.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := servers.List(client, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allServers, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, s := range allServers {
+		objects = append(objects, newObject(s.Name, s.ID))
+	}
+
+	return objects, nil
+}
+
+func listVolumes(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.config.BlockStorageV3Client(ctx, g.config.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := volumes.List(client, volumes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allVolumes, err := volumes.ExtractVolumes(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, v := range allVolumes {
+		objects = append(objects, newObject(v.Name, v.ID))
+	}
+
+	return objects, nil
+}
+
+// listFloatingIPs names floating IPs after their addresses.
+func listFloatingIPs(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.networkingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := floatingips.List(client, floatingips.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allFloatingIPs, err := floatingips.ExtractFloatingIPs(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, fip := range allFloatingIPs {
+		label := ""
+		if fip.FloatingIP != "" {
+			label = "floatingip_" + fip.FloatingIP
+		}
+		objects = append(objects, newObject(label, fip.ID))
+	}
+
+	return objects, nil
+}
+
+func listLoadBalancers(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.lbClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := loadbalancers.List(client, loadbalancers.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allLoadBalancers, err := loadbalancers.ExtractLoadBalancers(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, lb := range allLoadBalancers {
+		objects = append(objects, newObject(lb.Name, lb.ID))
+	}
+
+	return objects, nil
+}
+
+func listListeners(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.lbClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := listeners.List(client, listeners.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allListeners, err := listeners.ExtractListeners(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, l := range allListeners {
+		objects = append(objects, newObject(l.Name, l.ID))
+	}
+
+	return objects, nil
+}
+
+func listProjectPools(ctx context.Context, g *generator) (*gophercloud.ServiceClient, []pools.Pool, error) {
+	client, err := g.lbClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allPages, err := pools.List(client, pools.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, nil, err
+	}
+	allPools, err := pools.ExtractPools(allPages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, allPools, nil
+}
+
+func listPools(ctx context.Context, g *generator) ([]*object, error) {
+	_, allPools, err := listProjectPools(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, p := range allPools {
+		objects = append(objects, newObject(p.Name, p.ID))
+	}
+
+	return objects, nil
+}
+
+// listMembers lists the members of all pools. Members are imported by
+// <pool id>/<member id>.
+func listMembers(ctx context.Context, g *generator) ([]*object, error) {
+	client, allPools, err := listProjectPools(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, p := range allPools {
+		allPages, err := pools.ListMembers(client, p.ID, pools.ListMembersOpts{}).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		allMembers, err := pools.ExtractMembers(allPages)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range allMembers {
+			objects = append(objects, &object{
+				label:    m.Name,
+				id:       m.ID,
+				importID: fmt.Sprintf("%s/%s", p.ID, m.ID),
+			})
+		}
+	}
+
+	return objects, nil
+}
+
+// listMonitors lists the health monitors. Monitors are imported by
+// <monitor id>/<pool id>.
+func listMonitors(ctx context.Context, g *generator) ([]*object, error) {
+	client, err := g.lbClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := monitors.List(client, monitors.ListOpts{ProjectID: g.projectID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allMonitors, err := monitors.ExtractMonitors(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*object
+	for _, m := range allMonitors {
+		obj := newObject(m.Name, m.ID)
+		if len(m.Pools) > 0 {
+			obj.importID = fmt.Sprintf("%s/%s", m.ID, m.Pools[0].ID)
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
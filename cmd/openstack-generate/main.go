@@ -0,0 +1,124 @@
+// Command openstack-generate writes Terraform configuration for the existing
+// objects of an OpenStack project, together with the terraform import
+// commands which adopt them.
+//
+// It is configured like the provider, by OS_* environment variables or a
+// clouds.yaml entry, and imports every object with the Importer and Read of
+// its resource. The generated configuration is a starting point: review it
+// and run terraform plan after importing.
+//
+// Usage:
+//
+//	openstack-generate [-cloud name] [-region name] [-resources types] [-out file] [-import-script file]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/terraform-providers/terraform-provider-openstack/openstack"
+)
+
+func main() {
+	cloud := flag.String("cloud", "", "entry in clouds.yaml to use, defaults to OS_CLOUD")
+	region := flag.String("region", "", "region to generate, defaults to OS_REGION_NAME")
+	resources := flag.String("resources", "", "comma-separated resource types to generate, defaults to all supported types")
+	out := flag.String("out", "-", "file to write the configuration to, - for standard output")
+	importScript := flag.String("import-script", "", "file to write a shell script with the import commands to")
+	list := flag.Bool("list", false, "list the supported resource types and exit")
+	flag.Parse()
+
+	if *list {
+		for _, l := range listers {
+			fmt.Println(l.resourceType)
+		}
+		return
+	}
+
+	raw := make(map[string]interface{})
+	if *cloud != "" {
+		raw["cloud"] = *cloud
+	}
+	if *region != "" {
+		raw["region"] = *region
+	}
+
+	var resourceTypes []string
+	for _, t := range strings.Split(*resources, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			resourceTypes = append(resourceTypes, t)
+		}
+	}
+
+	if err := run(context.Background(), raw, resourceTypes, *out, *importScript); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, raw map[string]interface{}, resourceTypes []string, out, importScript string) error {
+	provider, err := configureProvider(ctx, raw)
+	if err != nil {
+		return err
+	}
+
+	g, err := newGenerator(ctx, provider, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	objects, err := g.generate(ctx, resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(out, objects, writeHCL); err != nil {
+		return err
+	}
+	if importScript != "" {
+		return writeFile(importScript, objects, writeImportScript)
+	}
+
+	return nil
+}
+
+// configureProvider configures the provider with the arguments in raw. All
+// other arguments take their defaults from the environment, as they do in
+// Terraform.
+func configureProvider(ctx context.Context, raw map[string]interface{}) (*schema.Provider, error) {
+	provider := openstack.Provider()
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				return nil, fmt.Errorf("Error configuring the provider: %s", d.Summary)
+			}
+		}
+	}
+
+	return provider, nil
+}
+
+func writeFile(path string, objects []*object, write func(io.Writer, []*object) error) error {
+	if path == "-" {
+		return write(os.Stdout, objects)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, objects); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// writeConfig writes a resource block for each migrated resource, which
+// replaces the block of the deprecated resource in the configuration. The
+// arguments are those of the first instance. References replace the IDs of
+// the other migrated resources.
+func writeConfig(w io.Writer, provider *schema.Provider, resources []*resource) error {
+	refs := make(map[string]string)
+	for _, r := range resources {
+		if r.Each == "" && len(r.Instances) > 0 {
+			if id, ok := r.Instances[0].Attributes["id"].(string); ok && id != "" {
+				refs[id] = r.Type + "." + r.Name + ".id"
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, r := range resources {
+		if len(r.Instances) == 0 {
+			continue
+		}
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if r.Module != "" {
+			fmt.Fprintf(&buf, "# In %s\n", r.Module)
+		}
+		if r.Each != "" {
+			fmt.Fprintf(&buf, "# The resource uses %s, adapt the arguments to each instance.\n", map[string]string{"list": "count", "map": "for_each"}[r.Each])
+		}
+		fmt.Fprintf(&buf, "resource %q %q {\n", r.Type, r.Name)
+
+		schemaMap := provider.ResourcesMap[r.Type].Schema
+		attrs := r.Instances[0].Attributes
+		keys := make([]string, 0, len(attrs))
+		for k := range attrs {
+			if s, ok := schemaMap[k]; ok && (s.Required || s.Optional) && k != "region" && !zeroValue(attrs[k]) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		width := 0
+		for _, k := range keys {
+			if len(k) > width {
+				width = len(k)
+			}
+		}
+		for _, k := range keys {
+			v := configValue(attrs[k])
+			if ref, ok := refs[fmt.Sprint(attrs[k])]; ok && ref != r.Type+"."+r.Name+".id" {
+				v = ref
+			}
+			fmt.Fprintf(&buf, "  %-*s = %s\n", width, k, v)
+		}
+		buf.WriteString("}\n")
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// zeroValue returns true for values which are left out of the
+// configuration.
+func zeroValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case json.Number:
+		return v.String() == "0"
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func configValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return quote(v)
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, e := range v {
+			values[i] = configValue(e)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			values[i] = quote(k) + " = " + configValue(v[k])
+		}
+		return "{ " + strings.Join(values, ", ") + " }"
+	}
+
+	return fmt.Sprint(v)
+}
+
+// quote returns a quoted HCL string, escaping template sequences.
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	q := strings.Replace(string(b), "${", "$${", -1)
+
+	return strings.Replace(q, "%{", "%%{", -1)
+}
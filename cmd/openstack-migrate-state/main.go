@@ -0,0 +1,178 @@
+// Command openstack-migrate-state moves the instances of deprecated
+// resources in a Terraform state to their successors, without recreating
+// their objects:
+//
+//	openstack_blockstorage_volume_v1 -> openstack_blockstorage_volume_v3
+//	openstack_compute_floatingip_v2  -> openstack_networking_floatingip_v2
+//	openstack_compute_secgroup_v2    -> openstack_networking_secgroup_v2 and
+//	                                    openstack_networking_secgroup_rule_v2
+//
+// The LBaaS v1 resources cannot be migrated, their objects do not exist in
+// the LBaaS v2 API. They are reported and left in the state.
+//
+// The command rewrites a local state file, keeping a backup, or prints the
+// changes as a diff with -dry-run. Remote states are migrated with
+// terraform state pull and push. The configuration of the migrated
+// resources is written to -config-out and replaces the blocks of the
+// deprecated resources, so that the next terraform plan shows no changes.
+//
+// Usage:
+//
+//	openstack-migrate-state [-state file] [-out file] [-dry-run] [-config-out file] [-resources types]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/terraform-providers/terraform-provider-openstack/openstack"
+)
+
+func main() {
+	statePath := flag.String("state", "terraform.tfstate", "state file to migrate, - for standard input")
+	out := flag.String("out", "", "file to write the migrated state to, defaults to the state file after backing it up to <state>.backup")
+	dryRun := flag.Bool("dry-run", false, "print the changes of the state as a diff without writing it")
+	configOut := flag.String("config-out", "", "file to write the configuration of the migrated resources to, - for standard output")
+	resources := flag.String("resources", "", "comma-separated deprecated resource types to migrate, defaults to all")
+	flag.Parse()
+
+	var resourceTypes []string
+	for _, t := range strings.Split(*resources, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			resourceTypes = append(resourceTypes, t)
+		}
+	}
+
+	opts := options{
+		statePath:     *statePath,
+		out:           *out,
+		dryRun:        *dryRun,
+		configOut:     *configOut,
+		resourceTypes: resourceTypes,
+	}
+	if err := run(opts, os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	statePath     string
+	out           string
+	dryRun        bool
+	configOut     string
+	resourceTypes []string
+}
+
+func run(opts options, stdin io.Reader, stdout, stderr io.Writer) error {
+	m, err := newMigrator(openstack.Provider(), opts.resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	var original []byte
+	if opts.statePath == "-" {
+		original, err = ioutil.ReadAll(stdin)
+	} else {
+		original, err = ioutil.ReadFile(opts.statePath)
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading the state: %s", err)
+	}
+
+	s, err := readState(bytes.NewReader(original))
+	if err != nil {
+		return err
+	}
+	before, err := s.marshal()
+	if err != nil {
+		return err
+	}
+
+	changed, err := m.migrate(s)
+	if err != nil {
+		return err
+	}
+	for _, line := range m.report {
+		fmt.Fprintln(stderr, line)
+	}
+	if !changed {
+		fmt.Fprintln(stderr, "No resources to migrate.")
+		return nil
+	}
+
+	after, err := s.marshal()
+	if err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(before)),
+			B:        difflib.SplitLines(string(after)),
+			FromFile: opts.statePath,
+			ToFile:   opts.statePath + " (migrated)",
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, diff)
+		return err
+	}
+
+	if err := writeOutput(opts, original, after, stdout); err != nil {
+		return err
+	}
+
+	if opts.configOut == "" {
+		fmt.Fprintln(stderr, "Replace the deprecated resources in the configuration before the next plan, see -config-out.")
+		return nil
+	}
+	if opts.configOut == "-" {
+		return writeConfig(stdout, m.provider, m.created)
+	}
+
+	f, err := os.Create(opts.configOut)
+	if err != nil {
+		return err
+	}
+	if err := writeConfig(f, m.provider, m.created); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeOutput writes the migrated state. The state file itself is only
+// replaced after writing a backup of it.
+func writeOutput(opts options, original, migrated []byte, stdout io.Writer) error {
+	out := opts.out
+	if out == "" {
+		out = opts.statePath
+	}
+	if out == "-" {
+		_, err := stdout.Write(migrated)
+		return err
+	}
+
+	if out == opts.statePath {
+		if err := ioutil.WriteFile(opts.statePath+".backup", original, 0600); err != nil {
+			return fmt.Errorf("Error writing the backup of the state: %s", err)
+		}
+	}
+
+	if err := ioutil.WriteFile(out, migrated, 0600); err != nil {
+		return fmt.Errorf("Error writing the state: %s", err)
+	}
+
+	return nil
+}
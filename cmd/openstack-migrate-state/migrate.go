@@ -0,0 +1,329 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// migration converts the instances of a deprecated resource type into
+// instances of its successors.
+type migration struct {
+	to      string
+	migrate func(m *migrator, r *resource, to string) ([]*resource, error)
+}
+
+// migrations are keyed by the deprecated resource type. The objects of the
+// deprecated and the new resources are the same, only the API managing them
+// differs, so the IDs are kept.
+var migrations = map[string]migration{
+	"openstack_blockstorage_volume_v1": {"openstack_blockstorage_volume_v3", migrateSimple},
+	"openstack_compute_floatingip_v2":  {"openstack_networking_floatingip_v2", migrateSimple},
+	"openstack_compute_secgroup_v2":    {"openstack_networking_secgroup_v2", migrateSecGroup},
+}
+
+// unsupportedMigrations are deprecated resource types whose objects cannot
+// be managed by another resource, keyed by type.
+var unsupportedMigrations = map[string]string{
+	"openstack_lb_member_v1":  lbV1Unsupported,
+	"openstack_lb_monitor_v1": lbV1Unsupported,
+	"openstack_lb_pool_v1":    lbV1Unsupported,
+	"openstack_lb_vip_v1":     lbV1Unsupported,
+}
+
+const lbV1Unsupported = "LBaaS v1 objects do not exist in the LBaaS v2 and Octavia APIs, " +
+	"recreate them with the openstack_lb_*_v2 resources"
+
+// migrator rewrites the instances of deprecated resources in a state.
+type migrator struct {
+	provider *schema.Provider
+
+	// types are the deprecated resource types to migrate.
+	types map[string]bool
+
+	// moved maps the addresses of the migrated resources to the addresses
+	// of their successors.
+	moved map[string]string
+
+	// created are the new resources, in the order of the state.
+	created []*resource
+
+	// report describes the migration of each resource.
+	report []string
+}
+
+func newMigrator(provider *schema.Provider, resourceTypes []string) (*migrator, error) {
+	m := &migrator{
+		provider: provider,
+		types:    make(map[string]bool),
+		moved:    make(map[string]string),
+	}
+
+	if len(resourceTypes) == 0 {
+		for t := range migrations {
+			resourceTypes = append(resourceTypes, t)
+		}
+		for t := range unsupportedMigrations {
+			resourceTypes = append(resourceTypes, t)
+		}
+	}
+	for _, t := range resourceTypes {
+		_, ok := migrations[t]
+		_, unsupported := unsupportedMigrations[t]
+		if !ok && !unsupported {
+			return nil, fmt.Errorf("Resource type %s is not a deprecated resource type", t)
+		}
+		m.types[t] = true
+	}
+
+	return m, nil
+}
+
+// migrate rewrites the instances of the deprecated resources of the state.
+// It returns true if the state changed.
+func (m *migrator) migrate(s *state) (bool, error) {
+	var resources []*resource
+	for _, r := range s.Resources {
+		if r.Mode != "managed" || !m.types[r.Type] {
+			resources = append(resources, r)
+			continue
+		}
+
+		if reason, ok := unsupportedMigrations[r.Type]; ok {
+			m.reportf("! %s is not migrated: %s", r.address(), reason)
+			resources = append(resources, r)
+			continue
+		}
+
+		for _, inst := range r.Instances {
+			if inst.AttributesFlat != nil {
+				return false, fmt.Errorf("%s has not been upgraded to the current state format, run terraform refresh first", r.instanceAddress(inst))
+			}
+		}
+
+		mig := migrations[r.Type]
+		migrated, err := mig.migrate(m, r, mig.to)
+		if err != nil {
+			return false, fmt.Errorf("Error migrating %s: %s", r.address(), err)
+		}
+
+		m.moved[r.address()] = migrated[0].address()
+		m.reportf("~ %s -> %s", r.address(), migrated[0].address())
+		for _, n := range migrated[1:] {
+			m.reportf("  + %s", n.address())
+		}
+
+		resources = append(resources, migrated...)
+		m.created = append(m.created, migrated...)
+	}
+
+	if len(m.moved) == 0 {
+		return false, nil
+	}
+
+	// Resources may only be named once per module.
+	names := make(map[string]bool)
+	for _, r := range resources {
+		if names[r.address()] {
+			return false, fmt.Errorf("The state already contains a resource %s, rename it first", r.address())
+		}
+		names[r.address()] = true
+	}
+
+	for _, r := range resources {
+		for _, inst := range r.Instances {
+			for i, dep := range inst.Dependencies {
+				if to, ok := m.moved[dep]; ok {
+					inst.Dependencies[i] = to
+				}
+			}
+		}
+	}
+
+	s.Resources = resources
+	s.Serial++
+
+	return true, nil
+}
+
+func (m *migrator) reportf(format string, args ...interface{}) {
+	m.report = append(m.report, fmt.Sprintf(format, args...))
+}
+
+// newResource returns a resource of another type in the module of r.
+func newResource(r *resource, resourceType, name string) *resource {
+	return &resource{
+		Module:   r.Module,
+		Mode:     r.Mode,
+		Type:     resourceType,
+		Name:     name,
+		Each:     r.Each,
+		Provider: r.Provider,
+	}
+}
+
+// convert returns an instance of resourceType with the given attributes,
+// replacing inst. The attributes missing from the schema of resourceType
+// are dropped and those not given are null, so that Terraform reads them
+// with the next refresh.
+func (m *migrator) convert(resourceType string, inst *instance, attributes map[string]interface{}) *instance {
+	r := m.provider.ResourcesMap[resourceType]
+
+	attrs := map[string]interface{}{"id": attributes["id"]}
+	for k := range r.Schema {
+		attrs[k] = attributes[k]
+	}
+	if r.Timeouts != nil {
+		attrs["timeouts"] = nil
+	}
+
+	return &instance{
+		IndexKey:            inst.IndexKey,
+		Status:              inst.Status,
+		Deposed:             inst.Deposed,
+		SchemaVersion:       r.SchemaVersion,
+		Attributes:          attrs,
+		Dependencies:        inst.Dependencies,
+		CreateBeforeDestroy: inst.CreateBeforeDestroy,
+	}
+}
+
+// migrateSimple converts each instance of r into an instance of the new
+// resource type with the same name and attributes. The instance_id of the
+// floating IPs is dropped, the networking resource reads the associated
+// port into port_id instead.
+func migrateSimple(m *migrator, r *resource, to string) ([]*resource, error) {
+	n := newResource(r, to, r.Name)
+	for _, inst := range r.Instances {
+		n.Instances = append(n.Instances, m.convert(to, inst, inst.Attributes))
+	}
+
+	return []*resource{n}, nil
+}
+
+// migrateSecGroup converts each security group into an
+// openstack_networking_secgroup_v2 and each of its rules into an
+// openstack_networking_secgroup_rule_v2. The rules are named after the
+// group, e.g. web_rule_0, in the order of protocol, ports and remote.
+func migrateSecGroup(m *migrator, r *resource, to string) ([]*resource, error) {
+	group := newResource(r, to, r.Name)
+	var rules []*resource
+
+	for _, inst := range r.Instances {
+		group.Instances = append(group.Instances, m.convert(group.Type, inst, inst.Attributes))
+
+		groupID, _ := inst.Attributes["id"].(string)
+		rawRules, _ := inst.Attributes["rule"].([]interface{})
+		ruleAttributes := make([]map[string]interface{}, 0, len(rawRules))
+		for _, rawRule := range rawRules {
+			rule, ok := rawRule.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Invalid rule in %s: %v", r.instanceAddress(inst), rawRule)
+			}
+			attrs, err := secGroupRuleAttributes(groupID, inst.Attributes["region"], rule)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid rule in %s: %s", r.instanceAddress(inst), err)
+			}
+			ruleAttributes = append(ruleAttributes, attrs)
+		}
+		sort.SliceStable(ruleAttributes, func(i, j int) bool {
+			return secGroupRuleKey(ruleAttributes[i]) < secGroupRuleKey(ruleAttributes[j])
+		})
+
+		for i, attrs := range ruleAttributes {
+			if i == len(rules) {
+				rules = append(rules, newResource(r, "openstack_networking_secgroup_rule_v2", fmt.Sprintf("%s_rule_%d", r.Name, i)))
+			}
+			ruleInst := m.convert(rules[i].Type, inst, attrs)
+			ruleInst.Dependencies = []string{group.address()}
+			rules[i].Instances = append(rules[i].Instances, ruleInst)
+		}
+	}
+
+	return append([]*resource{group}, rules...), nil
+}
+
+// secGroupRuleAttributes converts a Nova security group rule into the
+// attributes of an openstack_networking_secgroup_rule_v2. Nova rules are
+// Neutron ingress rules with the same ID. Nova uses -1 for any ICMP type or
+// code, Neutron leaves them empty.
+func secGroupRuleAttributes(groupID string, region interface{}, rule map[string]interface{}) (map[string]interface{}, error) {
+	id, _ := rule["id"].(string)
+	if id == "" {
+		return nil, fmt.Errorf("the rule has no ID")
+	}
+
+	protocol, _ := rule["ip_protocol"].(string)
+	protocol = strings.ToLower(protocol)
+	fromPort, err := stateInt(rule["from_port"])
+	if err != nil {
+		return nil, err
+	}
+	toPort, err := stateInt(rule["to_port"])
+	if err != nil {
+		return nil, err
+	}
+	if protocol == "icmp" {
+		if fromPort < 0 {
+			fromPort = 0
+		}
+		if toPort < 0 {
+			toPort = 0
+		}
+	}
+
+	cidr, _ := rule["cidr"].(string)
+	ethertype := "IPv4"
+	if strings.Contains(cidr, ":") {
+		ethertype = "IPv6"
+	}
+
+	remoteGroupID, _ := rule["from_group_id"].(string)
+	if self, _ := rule["self"].(bool); self {
+		remoteGroupID = groupID
+	}
+
+	return map[string]interface{}{
+		"id":                id,
+		"region":            region,
+		"description":       "",
+		"direction":         "ingress",
+		"ethertype":         ethertype,
+		"protocol":          protocol,
+		"port_range_min":    json.Number(strconv.Itoa(fromPort)),
+		"port_range_max":    json.Number(strconv.Itoa(toPort)),
+		"remote_ip_prefix":  cidr,
+		"remote_group_id":   remoteGroupID,
+		"security_group_id": groupID,
+	}, nil
+}
+
+func secGroupRuleKey(attrs map[string]interface{}) string {
+	min, _ := stateInt(attrs["port_range_min"])
+	max, _ := stateInt(attrs["port_range_max"])
+
+	return fmt.Sprintf("%s/%05d/%05d/%s/%s/%s", attrs["protocol"], min, max,
+		attrs["remote_ip_prefix"], attrs["remote_group_id"], attrs["id"])
+}
+
+// stateInt returns an integer attribute of a state.
+func stateInt(v interface{}) (int, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case json.Number:
+		i, err := strconv.Atoi(v.String())
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %s", v)
+		}
+		return i, nil
+	case int:
+		return v, nil
+	}
+
+	return 0, fmt.Errorf("invalid number %v", v)
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/openstack"
+)
+
+const testState = `{
+  "version": 4,
+  "terraform_version": "0.13.0",
+  "serial": 7,
+  "lineage": "2d6c5ee7-5b4e-4ea5-8d9d-6a4d2a2f3c11",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "openstack_blockstorage_volume_v1",
+      "name": "data",
+      "provider": "provider[\"registry.terraform.io/terraform-provider-openstack/openstack\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "vol-1",
+            "region": "RegionOne",
+            "size": 10,
+            "name": "data",
+            "description": "",
+            "availability_zone": "nova",
+            "metadata": {"role": "db"},
+            "snapshot_id": "",
+            "source_vol_id": "",
+            "image_id": "",
+            "volume_type": "ssd",
+            "attachment": []
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_compute_secgroup_v2",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/terraform-provider-openstack/openstack\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "sg-1",
+            "region": "RegionOne",
+            "name": "web",
+            "description": "Web servers",
+            "rule": [
+              {"id": "rule-2", "from_port": 80, "to_port": 80, "ip_protocol": "tcp", "cidr": "0.0.0.0/0", "from_group_id": "", "self": false},
+              {"id": "rule-1", "from_port": -1, "to_port": -1, "ip_protocol": "icmp", "cidr": "", "from_group_id": "", "self": true}
+            ]
+          },
+          "private": "bnVsbA=="
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_compute_floatingip_v2",
+      "name": "web",
+      "each": "list",
+      "provider": "provider[\"registry.terraform.io/terraform-provider-openstack/openstack\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "schema_version": 0,
+          "attributes": {
+            "id": "fip-1",
+            "region": "RegionOne",
+            "pool": "public",
+            "address": "172.24.4.10",
+            "fixed_ip": "",
+            "instance_id": ""
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_lb_pool_v1",
+      "name": "pool",
+      "provider": "provider[\"registry.terraform.io/terraform-provider-openstack/openstack\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "pool-1"
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_compute_instance_v2",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/terraform-provider-openstack/openstack\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {
+            "id": "server-1"
+          },
+          "dependencies": [
+            "openstack_compute_secgroup_v2.web"
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+
+func testMigrate(t *testing.T, resourceTypes []string) (*state, *migrator) {
+	s, err := readState(strings.NewReader(testState))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := newMigrator(openstack.Provider(), resourceTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := m.migrate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, changed)
+
+	return s, m
+}
+
+func TestMigrate(t *testing.T) {
+	s, m := testMigrate(t, nil)
+
+	var addresses []string
+	for _, r := range s.Resources {
+		addresses = append(addresses, r.address())
+	}
+	assert.Equal(t, []string{
+		"openstack_blockstorage_volume_v3.data",
+		"openstack_networking_secgroup_v2.web",
+		"openstack_networking_secgroup_rule_v2.web_rule_0",
+		"openstack_networking_secgroup_rule_v2.web_rule_1",
+		"openstack_networking_floatingip_v2.web",
+		"openstack_lb_pool_v1.pool",
+		"openstack_compute_instance_v2.web",
+	}, addresses)
+	assert.Equal(t, int64(8), s.Serial)
+
+	volume := s.Resources[0].Instances[0]
+	assert.Equal(t, "vol-1", volume.Attributes["id"])
+	assert.Equal(t, json.Number("10"), volume.Attributes["size"])
+	assert.Equal(t, "ssd", volume.Attributes["volume_type"])
+	assert.Contains(t, volume.Attributes, "multiattach")
+	assert.Nil(t, volume.Attributes["multiattach"])
+
+	group := s.Resources[1].Instances[0]
+	assert.Equal(t, "sg-1", group.Attributes["id"])
+	assert.Equal(t, "Web servers", group.Attributes["description"])
+	assert.NotContains(t, group.Attributes, "rule")
+	assert.Empty(t, group.Private)
+
+	// The ICMP rule sorts first, -1 means any type and code.
+	icmp := s.Resources[2].Instances[0]
+	assert.Equal(t, "rule-1", icmp.Attributes["id"])
+	assert.Equal(t, "icmp", icmp.Attributes["protocol"])
+	assert.Equal(t, json.Number("0"), icmp.Attributes["port_range_min"])
+	assert.Equal(t, "sg-1", icmp.Attributes["remote_group_id"])
+	assert.Equal(t, "sg-1", icmp.Attributes["security_group_id"])
+	assert.Equal(t, []string{"openstack_networking_secgroup_v2.web"}, icmp.Dependencies)
+
+	http := s.Resources[3].Instances[0]
+	assert.Equal(t, "rule-2", http.Attributes["id"])
+	assert.Equal(t, "ingress", http.Attributes["direction"])
+	assert.Equal(t, "IPv4", http.Attributes["ethertype"])
+	assert.Equal(t, json.Number("80"), http.Attributes["port_range_max"])
+	assert.Equal(t, "0.0.0.0/0", http.Attributes["remote_ip_prefix"])
+
+	fip := s.Resources[4].Instances[0]
+	assert.Equal(t, json.Number("0"), fip.IndexKey)
+	assert.Equal(t, "172.24.4.10", fip.Attributes["address"])
+	assert.NotContains(t, fip.Attributes, "instance_id")
+
+	assert.Equal(t, []string{"openstack_networking_secgroup_v2.web"}, s.Resources[6].Instances[0].Dependencies)
+
+	assert.Equal(t, []string{
+		"~ openstack_blockstorage_volume_v1.data -> openstack_blockstorage_volume_v3.data",
+		"~ openstack_compute_secgroup_v2.web -> openstack_networking_secgroup_v2.web",
+		"  + openstack_networking_secgroup_rule_v2.web_rule_0",
+		"  + openstack_networking_secgroup_rule_v2.web_rule_1",
+		"~ openstack_compute_floatingip_v2.web -> openstack_networking_floatingip_v2.web",
+		"! openstack_lb_pool_v1.pool is not migrated: " + lbV1Unsupported,
+	}, m.report)
+}
+
+func TestMigrateResourceTypes(t *testing.T) {
+	s, _ := testMigrate(t, []string{"openstack_blockstorage_volume_v1"})
+
+	assert.Equal(t, "openstack_blockstorage_volume_v3", s.Resources[0].Type)
+	assert.Equal(t, "openstack_compute_secgroup_v2", s.Resources[1].Type)
+
+	_, err := newMigrator(openstack.Provider(), []string{"openstack_networking_port_v2"})
+	assert.EqualError(t, err, "Resource type openstack_networking_port_v2 is not a deprecated resource type")
+}
+
+func TestMigrateNameConflict(t *testing.T) {
+	s, err := readState(strings.NewReader(testState))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Resources = append(s.Resources, &resource{
+		Mode: "managed",
+		Type: "openstack_networking_secgroup_rule_v2",
+		Name: "web_rule_1",
+	})
+
+	m, _ := newMigrator(openstack.Provider(), nil)
+	_, err = m.migrate(s)
+	assert.EqualError(t, err, "The state already contains a resource openstack_networking_secgroup_rule_v2.web_rule_1, rename it first")
+}
+
+func TestWriteConfig(t *testing.T) {
+	_, m := testMigrate(t, nil)
+
+	var buf bytes.Buffer
+	if err := writeConfig(&buf, m.provider, m.created); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `resource "openstack_blockstorage_volume_v3" "data" {
+  availability_zone = "nova"
+  metadata          = { "role" = "db" }
+  name              = "data"
+  size              = 10
+  volume_type       = "ssd"
+}
+
+resource "openstack_networking_secgroup_v2" "web" {
+  description = "Web servers"
+  name        = "web"
+}
+
+resource "openstack_networking_secgroup_rule_v2" "web_rule_0" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  protocol          = "icmp"
+  remote_group_id   = openstack_networking_secgroup_v2.web.id
+  security_group_id = openstack_networking_secgroup_v2.web.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "web_rule_1" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 80
+  port_range_min    = 80
+  protocol          = "tcp"
+  remote_ip_prefix  = "0.0.0.0/0"
+  security_group_id = openstack_networking_secgroup_v2.web.id
+}
+
+# The resource uses count, adapt the arguments to each instance.
+resource "openstack_networking_floatingip_v2" "web" {
+  address = "172.24.4.10"
+  pool    = "public"
+}
+`, buf.String())
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "terraform.tfstate")
+	if err := ioutil.WriteFile(path, []byte(testState), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A dry run prints the diff and leaves the state alone.
+	var stdout, stderr bytes.Buffer
+	err = run(options{statePath: path, dryRun: true}, nil, &stdout, &stderr)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), `-      "type": "openstack_compute_secgroup_v2",`)
+	assert.Contains(t, stdout.String(), `+      "type": "openstack_networking_secgroup_v2",`)
+	assert.Contains(t, stdout.String(), `-  "serial": 7,`)
+	assert.Contains(t, stderr.String(), "~ openstack_compute_secgroup_v2.web -> openstack_networking_secgroup_v2.web")
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, testState, string(content))
+
+	configPath := filepath.Join(dir, "migrated.tf")
+	err = run(options{statePath: path, configOut: configPath}, nil, &stdout, &stderr)
+	assert.NoError(t, err)
+
+	backup, _ := ioutil.ReadFile(path + ".backup")
+	assert.Equal(t, testState, string(backup))
+	migrated, err := readState(strings.NewReader(readFile(t, path)))
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(8), migrated.Serial)
+		assert.Equal(t, "openstack_blockstorage_volume_v3", migrated.Resources[0].Type)
+	}
+	assert.Contains(t, readFile(t, configPath), `resource "openstack_networking_secgroup_v2" "web" {`)
+
+	// Nothing is left to migrate.
+	stderr.Reset()
+	err = run(options{statePath: path}, nil, &stdout, &stderr)
+	assert.NoError(t, err)
+	assert.Contains(t, stderr.String(), "No resources to migrate.")
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
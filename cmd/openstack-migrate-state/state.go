@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// state is a Terraform state file of format version 4, written by Terraform
+// 0.12 and later. Outputs and check results are kept as they are.
+type state struct {
+	Version          int             `json:"version"`
+	TerraformVersion string          `json:"terraform_version"`
+	Serial           int64           `json:"serial"`
+	Lineage          string          `json:"lineage"`
+	Outputs          json.RawMessage `json:"outputs"`
+	Resources        []*resource     `json:"resources"`
+	CheckResults     json.RawMessage `json:"check_results,omitempty"`
+}
+
+type resource struct {
+	Module    string      `json:"module,omitempty"`
+	Mode      string      `json:"mode"`
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Each      string      `json:"each,omitempty"`
+	Provider  string      `json:"provider"`
+	Instances []*instance `json:"instances"`
+}
+
+type instance struct {
+	IndexKey            interface{}            `json:"index_key,omitempty"`
+	Status              string                 `json:"status,omitempty"`
+	Deposed             string                 `json:"deposed,omitempty"`
+	SchemaVersion       int                    `json:"schema_version"`
+	Attributes          map[string]interface{} `json:"attributes,omitempty"`
+	AttributesFlat      map[string]string      `json:"attributes_flat,omitempty"`
+	SensitiveAttributes json.RawMessage        `json:"sensitive_attributes,omitempty"`
+	Private             string                 `json:"private,omitempty"`
+	Dependencies        []string               `json:"dependencies,omitempty"`
+	CreateBeforeDestroy bool                   `json:"create_before_destroy,omitempty"`
+}
+
+// address returns the address of the resource, e.g.
+// module.network.openstack_networking_secgroup_v2.web.
+func (r *resource) address() string {
+	address := r.Type + "." + r.Name
+	if r.Mode == "data" {
+		address = "data." + address
+	}
+	if r.Module != "" {
+		address = r.Module + "." + address
+	}
+
+	return address
+}
+
+// instanceAddress returns the address of an instance of the resource.
+func (r *resource) instanceAddress(inst *instance) string {
+	switch key := inst.IndexKey.(type) {
+	case string:
+		return fmt.Sprintf("%s[%q]", r.address(), key)
+	case json.Number:
+		return fmt.Sprintf("%s[%s]", r.address(), key)
+	}
+
+	return r.address()
+}
+
+func readState(r io.Reader) (*state, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var s state
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("Error parsing the state: %s", err)
+	}
+	if s.Version != 4 {
+		return nil, fmt.Errorf("State format version %d is not supported, run terraform refresh with Terraform 0.12 or later first", s.Version)
+	}
+
+	return &s, nil
+}
+
+// marshal encodes the state the way Terraform writes it.
+func (s *state) marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
@@ -5,8 +5,10 @@ go 1.14
 require (
 	github.com/gophercloud/gophercloud v0.12.1-0.20200821143728-362eb785d617
 	github.com/gophercloud/utils v0.0.0-20200508015959-b0167b94122c
+	github.com/hashicorp/hcl/v2 v2.3.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.0.1
 	github.com/mitchellh/go-homedir v1.1.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.4.0
 	gopkg.in/yaml.v2 v2.3.0
 )
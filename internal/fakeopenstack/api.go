@@ -0,0 +1,162 @@
+package fakeopenstack
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// collectionAPI exposes a collection as a REST resource whose bodies are
+// wrapped in singular/plural envelopes, the way Nova, Neutron, Cinder and
+// Octavia do.
+type collectionAPI struct {
+	service  string
+	kind     Kind
+	prefix   string
+	singular string
+	plural   string
+
+	// detail additionally serves the list at prefix + "/detail".
+	detail bool
+
+	// parentVar and parentField scope a nested collection, e.g. the
+	// members of a pool, to the object named by a path variable.
+	parentVar   string
+	parentField string
+
+	// createStatus defaults to 201 and deleteStatus to 204.
+	createStatus int
+	deleteStatus int
+
+	// create validates a new object and fills in its defaults. update
+	// validates changed fields and delete is called before an object is
+	// deleted. Each returns a non-zero status and a message to reject the
+	// request.
+	create func(s *Server, r *request, data map[string]interface{}) (int, string)
+	update func(s *Server, r *request, obj *object, fields map[string]interface{}) (int, string)
+	delete func(s *Server, r *request, obj *object) (int, string)
+}
+
+func (s *Server) serve(api *collectionAPI) {
+	if api.createStatus == 0 {
+		api.createStatus = http.StatusCreated
+	}
+	if api.deleteStatus == 0 {
+		api.deleteStatus = http.StatusNoContent
+	}
+
+	if api.detail {
+		s.handle(api.service, "GET", api.prefix+"/detail", api.listHandler)
+	}
+	s.handle(api.service, "GET", api.prefix, api.listHandler)
+	s.handle(api.service, "POST", api.prefix, api.createHandler)
+	s.handle(api.service, "GET", api.prefix+"/{id}", api.getHandler)
+	s.handle(api.service, "PUT", api.prefix+"/{id}", api.updateHandler)
+	s.handle(api.service, "DELETE", api.prefix+"/{id}", api.deleteHandler)
+}
+
+// lookup returns the object named by the "id" path variable.
+func (api *collectionAPI) lookup(s *Server, r *request) (*object, bool) {
+	obj, ok := s.coll(api.kind).get(r.vars["id"])
+	if !ok {
+		return nil, false
+	}
+
+	if api.parentVar != "" && obj.str(api.parentField) != r.vars[api.parentVar] {
+		return nil, false
+	}
+
+	return obj, true
+}
+
+func (api *collectionAPI) notFound(r *request) (int, interface{}) {
+	return http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", api.singular, r.vars["id"])
+}
+
+func (api *collectionAPI) listHandler(s *Server, r *request) (int, interface{}) {
+	var objects []map[string]interface{}
+	for _, obj := range s.coll(api.kind).list() {
+		if api.parentVar != "" && obj.str(api.parentField) != r.vars[api.parentVar] {
+			continue
+		}
+		objects = append(objects, s.render(api.kind, obj))
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		api.plural: filter(objects, r.URL.Query()),
+	}
+}
+
+func (api *collectionAPI) getHandler(s *Server, r *request) (int, interface{}) {
+	obj, ok := api.lookup(s, r)
+	if !ok {
+		return api.notFound(r)
+	}
+
+	data := s.render(api.kind, obj)
+	s.coll(api.kind).observe(obj)
+
+	return http.StatusOK, map[string]interface{}{api.singular: data}
+}
+
+func (api *collectionAPI) createHandler(s *Server, r *request) (int, interface{}) {
+	data, err := r.object(api.singular)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	data["id"] = newID()
+	setDefault(data, "created_at", now())
+	setDefault(data, "updated_at", now())
+	if api.parentVar != "" {
+		data[api.parentField] = r.vars[api.parentVar]
+	}
+
+	if api.create != nil {
+		if status, msg := api.create(s, r, data); status != 0 {
+			return status, msg
+		}
+	}
+
+	obj := s.coll(api.kind).create(data)
+
+	return api.createStatus, map[string]interface{}{api.singular: s.render(api.kind, obj)}
+}
+
+func (api *collectionAPI) updateHandler(s *Server, r *request) (int, interface{}) {
+	obj, ok := api.lookup(s, r)
+	if !ok {
+		return api.notFound(r)
+	}
+
+	fields, err := r.object(api.singular)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	if api.update != nil {
+		if status, msg := api.update(s, r, obj, fields); status != 0 {
+			return status, msg
+		}
+	}
+
+	s.coll(api.kind).update(obj, fields)
+
+	return http.StatusOK, map[string]interface{}{api.singular: s.render(api.kind, obj)}
+}
+
+func (api *collectionAPI) deleteHandler(s *Server, r *request) (int, interface{}) {
+	obj, ok := api.lookup(s, r)
+	if !ok {
+		return api.notFound(r)
+	}
+
+	if api.delete != nil {
+		if status, msg := api.delete(s, r, obj); status != 0 {
+			return status, msg
+		}
+	}
+
+	s.coll(api.kind).delete(obj)
+
+	return api.deleteStatus, nil
+}
@@ -0,0 +1,298 @@
+package fakeopenstack
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cinderTimeFormat is the timestamp format used by Cinder.
+const cinderTimeFormat = "2006-01-02T15:04:05.000000"
+
+func init() {
+	renderers[VolumeVolumes] = func(s *Server, obj *object, data map[string]interface{}) {
+		for _, key := range []string{"created_at", "updated_at"} {
+			if v, ok := data[key].(string); ok {
+				if t, err := time.Parse(time.RFC3339, v); err == nil {
+					data[key] = t.UTC().Format(cinderTimeFormat)
+				}
+			}
+		}
+
+		attachments := []interface{}{}
+		for _, serverID := range volumeServers(obj) {
+			attachments = append(attachments, map[string]interface{}{
+				"id":            obj.id(),
+				"attachment_id": obj.meta["attachment:"+serverID],
+				"volume_id":     obj.id(),
+				"server_id":     serverID,
+				"device":        obj.meta["device"],
+				"host_name":     "fake-host",
+				"attached_at":   data["updated_at"],
+			})
+		}
+		data["attachments"] = attachments
+	}
+}
+
+func volumeVersions(s *Server, r *request) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"versions": []interface{}{
+			map[string]interface{}{
+				"id":          "v3.0",
+				"status":      "CURRENT",
+				"min_version": "3.0",
+				"version":     s.VolumeMicroversion,
+				"links": []interface{}{
+					map[string]interface{}{"rel": "self", "href": s.URL + "/volume/v3/"},
+				},
+			},
+		},
+	}
+}
+
+func (s *Server) registerBlockStorage() {
+	s.handle("volume", "GET", "/volume/v3", volumeVersions).public = true
+
+	for _, version := range []string{"v2", "v3"} {
+		prefix := "/volume/" + version + "/" + s.ProjectID
+
+		s.serve(&collectionAPI{
+			service:      "volume",
+			kind:         VolumeVolumes,
+			prefix:       prefix + "/volumes",
+			singular:     "volume",
+			plural:       "volumes",
+			detail:       true,
+			createStatus: http.StatusAccepted,
+			deleteStatus: http.StatusAccepted,
+			create:       volumeCreate,
+			update:       volumeUpdate,
+			delete:       volumeDelete,
+		})
+		s.handle("volume", "POST", prefix+"/volumes/{id}/action", volumeAction)
+
+		s.serve(&collectionAPI{
+			service:  "volume",
+			kind:     VolumeTypes,
+			prefix:   prefix + "/types",
+			singular: "volume_type",
+			plural:   "volume_types",
+			create:   volumeTypeCreate,
+		})
+	}
+}
+
+func volumeNotFound(r *request) (int, interface{}) {
+	return http.StatusNotFound, fmt.Sprintf("Volume %s could not be found.", r.vars["id"])
+}
+
+func volumeDefaults(s *Server, data map[string]interface{}) {
+	setDefault(data, "name", "")
+	setDefault(data, "description", "")
+	setDefault(data, "status", "available")
+	setDefault(data, "availability_zone", "nova")
+	setDefault(data, "volume_type", "lvmdriver-1")
+	setDefault(data, "metadata", map[string]interface{}{})
+	setDefault(data, "multiattach", false)
+	setDefault(data, "encrypted", false)
+	setDefault(data, "replication_status", "disabled")
+	setDefault(data, "snapshot_id", nil)
+	setDefault(data, "source_volid", nil)
+	data["user_id"] = s.UserID
+	data["os-vol-tenant-attr:tenant_id"] = s.ProjectID
+
+	data["bootable"] = "false"
+	if imageID, ok := data["imageRef"].(string); ok && imageID != "" {
+		data["bootable"] = "true"
+		data["volume_image_metadata"] = map[string]interface{}{"image_id": imageID}
+	}
+	delete(data, "imageRef")
+}
+
+func volumeCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	size, _ := data["size"].(float64)
+	if size < 1 && data["snapshot_id"] == nil && data["source_volid"] == nil {
+		return http.StatusBadRequest, "Invalid input received: size must be a positive integer"
+	}
+
+	if imageID, ok := data["imageRef"].(string); ok && imageID != "" {
+		if _, ok := s.coll(ImageImages).get(imageID); !ok {
+			return http.StatusBadRequest, fmt.Sprintf("Invalid image identifier or unable to access requested image: %s", imageID)
+		}
+	}
+
+	if name, ok := data["volume_type"].(string); ok && name != "" {
+		if len(s.coll(VolumeTypes).find(map[string]string{"name": name})) == 0 &&
+			len(s.coll(VolumeTypes).find(map[string]string{"id": name})) == 0 {
+			return http.StatusNotFound, fmt.Sprintf("Volume type with name %s could not be found.", name)
+		}
+	}
+
+	volumeDefaults(s, data)
+
+	return 0, ""
+}
+
+func volumeUpdate(s *Server, r *request, obj *object, fields map[string]interface{}) (int, string) {
+	for k := range fields {
+		switch k {
+		case "name", "description", "metadata":
+		default:
+			return http.StatusBadRequest, fmt.Sprintf("Invalid input for field/attribute %s.", k)
+		}
+	}
+
+	return 0, ""
+}
+
+func volumeDelete(s *Server, r *request, obj *object) (int, string) {
+	if len(volumeServers(obj)) > 0 {
+		return http.StatusBadRequest, "Invalid volume: Volume status must be available or error, and must not be attached."
+	}
+
+	return 0, ""
+}
+
+// volumeServers returns the IDs of the servers a volume is attached to.
+func volumeServers(volume *object) []string {
+	return strings.Fields(volume.meta["servers"])
+}
+
+// attachedVolumes returns the volumes attached to a server.
+func (s *Server) attachedVolumes(serverID string) []*object {
+	var volumes []*object
+	for _, volume := range s.coll(VolumeVolumes).list() {
+		if _, ok := volume.meta["attachment:"+serverID]; ok {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	return volumes
+}
+
+func (s *Server) attachVolume(volume *object, serverID, device string) {
+	if _, ok := volume.meta["attachment:"+serverID]; !ok {
+		volume.meta["attachment:"+serverID] = newID()
+		volume.meta["servers"] += serverID + " "
+	}
+	volume.meta["device"] = device
+
+	s.coll(VolumeVolumes).transition(volume, "in-use")
+}
+
+func (s *Server) detachVolume(volume *object, serverID string) {
+	delete(volume.meta, "attachment:"+serverID)
+	delete(volume.meta, "delete_on_termination")
+
+	var servers string
+	for _, id := range volumeServers(volume) {
+		if id != serverID {
+			servers += id + " "
+		}
+	}
+	volume.meta["servers"] = servers
+
+	if servers == "" {
+		delete(volume.meta, "device")
+		s.coll(VolumeVolumes).transition(volume, "available")
+	}
+}
+
+func volumeAction(s *Server, r *request) (int, interface{}) {
+	volumes := s.coll(VolumeVolumes)
+	obj, ok := volumes.get(r.vars["id"])
+	if !ok {
+		return volumeNotFound(r)
+	}
+
+	body, err := r.object("")
+	if err != nil || len(body) != 1 {
+		return http.StatusBadRequest, "Malformed request body"
+	}
+
+	var action string
+	var args map[string]interface{}
+	for k, v := range body {
+		action = k
+		args, _ = v.(map[string]interface{})
+	}
+
+	status := obj.str("status")
+
+	switch action {
+	case "os-extend":
+		size, _ := args["new_size"].(float64)
+		current, _ := obj.data["size"].(float64)
+		if size <= current {
+			return http.StatusBadRequest, "Invalid input received: New size for extend must be greater than current size."
+		}
+		if status != "available" && status != "in-use" {
+			return http.StatusBadRequest, fmt.Sprintf("Invalid volume: Volume %s status must be available or in-use", obj.id())
+		}
+		obj.data["size"] = size
+		volumes.transition(obj, "extending", status)
+	case "os-reserve":
+		volumes.transition(obj, "attaching")
+	case "os-unreserve":
+		volumes.transition(obj, "available")
+	case "os-attach":
+		serverID, _ := args["instance_uuid"].(string)
+		device, _ := args["mountpoint"].(string)
+		s.attachVolume(obj, serverID, device)
+	case "os-begin_detaching":
+		volumes.transition(obj, "detaching")
+	case "os-detach":
+		for _, serverID := range volumeServers(obj) {
+			if id, _ := args["attachment_id"].(string); id == "" || obj.meta["attachment:"+serverID] == id {
+				s.detachVolume(obj, serverID)
+			}
+		}
+		if len(volumeServers(obj)) == 0 {
+			volumes.transition(obj, "available")
+		}
+	case "os-initialize_connection":
+		return http.StatusOK, map[string]interface{}{
+			"connection_info": map[string]interface{}{
+				"driver_volume_type": "iscsi",
+				"data": map[string]interface{}{
+					"target_iqn":    "iqn.2010-10.org.openstack:volume-" + obj.id(),
+					"target_portal": "127.0.0.1:3260",
+					"target_lun":    0,
+					"volume_id":     obj.id(),
+				},
+			},
+		}
+	case "os-terminate_connection", "os-update_readonly_flag", "os-set_image_metadata":
+	case "os-set_bootable":
+		obj.data["bootable"] = fmt.Sprint(args["bootable"] == true)
+	case "os-retype":
+		obj.data["volume_type"] = args["new_type"]
+		volumes.transition(obj, "retyping", status)
+	case "os-reset_status":
+		volumes.transition(obj, fmt.Sprint(args["status"]))
+	case "os-force_delete":
+		volumes.delete(obj)
+	default:
+		return http.StatusBadRequest, fmt.Sprintf("Unsupported volume action %s", action)
+	}
+
+	return http.StatusAccepted, nil
+}
+
+func volumeTypeCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	name, _ := data["name"].(string)
+	if len(s.coll(VolumeTypes).find(map[string]string{"name": name})) > 0 {
+		return http.StatusConflict, fmt.Sprintf("Volume Type %s already exists.", name)
+	}
+
+	setDefault(data, "description", nil)
+	setDefault(data, "extra_specs", map[string]interface{}{})
+	setDefault(data, "os-volume-type-access:is_public", true)
+	setDefault(data, "is_public", data["os-volume-type-access:is_public"])
+	delete(data, "created_at")
+	delete(data, "updated_at")
+
+	return 0, ""
+}
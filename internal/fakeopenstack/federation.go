@@ -0,0 +1,190 @@
+package fakeopenstack
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Defaults of the federated identity provider used by New.
+const (
+	DefaultIdentityProvider = "idp"
+	DefaultClientID         = "terraform"
+	DefaultClientSecret     = "client-secret"
+)
+
+const (
+	soapNS = "http://schemas.xmlsoap.org/soap/envelope/"
+	paosNS = "urn:liberty:paos:2003-08"
+	ecpNS  = "urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"
+)
+
+// registerFederation registers an identity provider which supports the
+// OpenID Connect password and client credentials grants and SAML2 ECP, and
+// the Keystone federation endpoints trusting it.
+//
+// The identity provider authenticates the default user and the OpenID
+// Connect client DefaultClientID. Keystone accepts the access token in
+// AccessToken and SAML assertions issued by the identity provider.
+func (s *Server) registerFederation() {
+	s.handle("idp", "GET", "/idp/.well-known/openid-configuration", idpDiscovery).public = true
+	s.handle("idp", "POST", "/idp/token", idpToken).public = true
+	s.handle("idp", "POST", "/idp/ecp", idpECP).public = true
+
+	auth := "/identity/v3/OS-FEDERATION/identity_providers/{idp}/protocols/{protocol}/auth"
+	s.handle("identity", "POST", auth, identityFederatedAuth).public = true
+	s.handle("identity", "GET", auth, identityFederatedAuth).public = true
+	s.handle("identity", "POST", "/identity/Shibboleth.sso/SAML2/ECP", identityAssertionConsumer).public = true
+}
+
+// DiscoveryEndpoint returns the OpenID Connect discovery endpoint of the
+// identity provider.
+func (s *Server) DiscoveryEndpoint() string {
+	return s.URL + "/idp/.well-known/openid-configuration"
+}
+
+// IdentityProviderURL returns the SAML2 ECP endpoint of the identity
+// provider.
+func (s *Server) IdentityProviderURL() string {
+	return s.URL + "/idp/ecp"
+}
+
+func idpDiscovery(s *Server, r *request) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"issuer":         s.URL + "/idp",
+		"token_endpoint": s.URL + "/idp/token",
+	}
+}
+
+func idpToken(s *Server, r *request) (int, interface{}) {
+	form, err := url.ParseQuery(string(r.raw))
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	if id, secret, ok := r.BasicAuth(); !ok || id != DefaultClientID || secret != DefaultClientSecret {
+		return http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client"}
+	}
+
+	switch form.Get("grant_type") {
+	case "password":
+		if form.Get("username") != s.Username || form.Get("password") != s.Password {
+			return http.StatusBadRequest, map[string]interface{}{"error": "invalid_grant"}
+		}
+	case "client_credentials":
+	default:
+		return http.StatusBadRequest, map[string]interface{}{"error": "unsupported_grant_type"}
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"access_token": s.AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+	}
+}
+
+// idpECP answers an ECP authentication request with a SAML response which
+// carries SAMLAssertion.
+func idpECP(s *Server, r *request) (int, interface{}) {
+	if user, password, ok := r.BasicAuth(); !ok || user != s.Username || password != s.Password {
+		return http.StatusUnauthorized, []byte("Unauthorized")
+	}
+
+	var envelope struct {
+		Body struct {
+			AuthnRequest struct {
+				AssertionConsumerServiceURL string `xml:",attr"`
+			}
+		}
+	}
+	if err := xml.Unmarshal(r.raw, &envelope); err != nil {
+		return http.StatusBadRequest, []byte(err.Error())
+	}
+
+	r.header.Set("Content-Type", "text/xml")
+
+	return http.StatusOK, []byte(fmt.Sprintf(`<soap11:Envelope xmlns:soap11="%s">`+
+		`<soap11:Header>`+
+		`<ecp:Response xmlns:ecp="%s" soap11:actor="http://schemas.xmlsoap.org/soap/actor/next" soap11:mustUnderstand="1" AssertionConsumerServiceURL="%s"/>`+
+		`</soap11:Header>`+
+		`<soap11:Body>`+
+		`<saml2p:Response xmlns:saml2p="urn:oasis:names:tc:SAML:2.0:protocol">`+
+		`<saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion">%s</saml2:Assertion>`+
+		`</saml2p:Response>`+
+		`</soap11:Body>`+
+		`</soap11:Envelope>`, soapNS, ecpNS, envelope.Body.AuthnRequest.AssertionConsumerServiceURL, s.SAMLAssertion))
+}
+
+// identityFederatedAuth issues an unscoped token for a valid OpenID Connect
+// access token or SAML session, or starts an ECP authentication.
+func identityFederatedAuth(s *Server, r *request) (int, interface{}) {
+	if r.vars["idp"] != DefaultIdentityProvider {
+		return http.StatusNotFound, "Could not find Identity Provider: " + r.vars["idp"]
+	}
+
+	switch {
+	case r.Header.Get("Authorization") == "Bearer "+s.AccessToken:
+	case s.validSession(r):
+	case strings.Contains(r.Header.Get("Accept"), "application/vnd.paos+xml"):
+		r.header.Set("Content-Type", "application/vnd.paos+xml")
+		return ecpAuthnRequest(s)
+	default:
+		return http.StatusUnauthorized, "The request you have made requires authentication."
+	}
+
+	token := strings.Replace(newID(), "-", "", -1)
+	expires := time.Now().Add(s.TokenTTL)
+	s.tokens[token] = expires
+
+	r.header.Set("X-Subject-Token", token)
+
+	body := s.tokenBody([]string{r.vars["protocol"]}, nil, expires)
+	t := body["token"].(map[string]interface{})
+	delete(t, "project")
+	delete(t, "catalog")
+
+	return http.StatusCreated, body
+}
+
+func ecpAuthnRequest(s *Server) (int, interface{}) {
+	return http.StatusOK, []byte(fmt.Sprintf(`<S:Envelope xmlns:S="%s">`+
+		`<S:Header>`+
+		`<paos:Request xmlns:paos="%s" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1" responseConsumerURL="%s" service="%s"/>`+
+		`<ecp:RelayState xmlns:ecp="%s" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1">ss:mem:%s</ecp:RelayState>`+
+		`</S:Header>`+
+		`<S:Body>`+
+		`<samlp:AuthnRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" AssertionConsumerServiceURL="%s" ID="_%s"/>`+
+		`</S:Body>`+
+		`</S:Envelope>`, soapNS, paosNS, s.assertionConsumerURL(), ecpNS, ecpNS, s.relayState, s.assertionConsumerURL(), newID()))
+}
+
+func (s *Server) assertionConsumerURL() string {
+	return s.URL + "/identity/Shibboleth.sso/SAML2/ECP"
+}
+
+// identityAssertionConsumer starts a SAML session for a response carrying
+// the relay state and SAMLAssertion, and redirects to the federated
+// authentication endpoint.
+func identityAssertionConsumer(s *Server, r *request) (int, interface{}) {
+	if !bytes.Contains(r.raw, []byte("ss:mem:"+s.relayState)) || !bytes.Contains(r.raw, []byte(s.SAMLAssertion)) {
+		return http.StatusUnauthorized, []byte("Unauthorized")
+	}
+
+	session := newID()
+	s.sessions[session] = true
+
+	r.header.Add("Set-Cookie", (&http.Cookie{Name: "_shibsession", Value: session, Path: "/"}).String())
+	r.header.Set("Location", s.URL+"/identity/v3/OS-FEDERATION/identity_providers/"+DefaultIdentityProvider+"/protocols/saml2/auth")
+
+	return http.StatusFound, nil
+}
+
+// validSession returns whether the request belongs to a SAML session.
+func (s *Server) validSession(r *request) bool {
+	c, err := r.Cookie("_shibsession")
+	return err == nil && s.sessions[c.Value]
+}
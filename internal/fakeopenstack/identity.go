@@ -0,0 +1,253 @@
+package fakeopenstack
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type catalogEntry struct {
+	serviceType string
+	name        string
+	url         string
+}
+
+func (s *Server) catalog() []catalogEntry {
+	return []catalogEntry{
+		{"identity", "keystone", s.URL + "/identity/v3"},
+		{"compute", "nova", s.URL + "/compute/v2.1"},
+		{"network", "neutron", s.URL + "/network"},
+		{"volumev2", "cinderv2", s.URL + "/volume/v2/" + s.ProjectID},
+		{"volumev3", "cinderv3", s.URL + "/volume/v3/" + s.ProjectID},
+		{"image", "glance", s.URL + "/image"},
+		{"load-balancer", "octavia", s.URL + "/load-balancer"},
+	}
+}
+
+func (s *Server) registerIdentity() {
+	s.handle("identity", "GET", "/identity", identityVersions).public = true
+	s.handle("identity", "GET", "/identity/v3", identityVersion).public = true
+	s.handle("identity", "POST", "/identity/v3/auth/tokens", identityCreateToken).public = true
+	s.handle("identity", "GET", "/identity/v3/auth/tokens", identityGetToken)
+}
+
+func (s *Server) validToken(token string) bool {
+	expires, ok := s.tokens[token]
+	return ok && time.Now().Before(expires)
+}
+
+// validPasscode returns whether passcode is the TOTP passcode of TOTPSecret
+// for the current or the previous 30 seconds.
+func (s *Server) validPasscode(passcode string) bool {
+	if s.TOTPSecret == "" {
+		return false
+	}
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(s.TOTPSecret, "="))
+	if err != nil {
+		return false
+	}
+
+	now := time.Now().Unix() / 30
+	for _, counter := range []int64{now, now - 1} {
+		var msg [8]byte
+		binary.BigEndian.PutUint64(msg[:], uint64(counter))
+		mac := hmac.New(sha1.New, key)
+		mac.Write(msg[:])
+		sum := mac.Sum(nil)
+		offset := sum[len(sum)-1] & 0x0f
+		code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+		if fmt.Sprintf("%06d", code%1000000) == passcode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func identityVersions(s *Server, r *request) (int, interface{}) {
+	return http.StatusMultipleChoices, map[string]interface{}{
+		"versions": map[string]interface{}{
+			"values": []interface{}{identityVersionDoc(s)},
+		},
+	}
+}
+
+func identityVersion(s *Server, r *request) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"version": identityVersionDoc(s),
+	}
+}
+
+func identityVersionDoc(s *Server) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      "v3.14",
+		"status":  "stable",
+		"updated": "2020-04-07T00:00:00Z",
+		"links": []interface{}{
+			map[string]interface{}{"rel": "self", "href": s.URL + "/identity/v3/"},
+		},
+	}
+}
+
+// authRequest is the subset of a Keystone v3 token request the fake
+// understands.
+type authRequest struct {
+	Auth struct {
+		Identity struct {
+			Methods  []string `json:"methods"`
+			Password struct {
+				User authUser `json:"user"`
+			} `json:"password"`
+			TOTP struct {
+				User authUser `json:"user"`
+			} `json:"totp"`
+			Token struct {
+				ID string `json:"id"`
+			} `json:"token"`
+			ApplicationCredential struct {
+				ID     string   `json:"id"`
+				Name   string   `json:"name"`
+				Secret string   `json:"secret"`
+				User   authUser `json:"user"`
+			} `json:"application_credential"`
+		} `json:"identity"`
+		Scope interface{} `json:"scope"`
+	} `json:"auth"`
+}
+
+type authUser struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Passcode string `json:"passcode"`
+}
+
+func identityCreateToken(s *Server, r *request) (int, interface{}) {
+	var req authRequest
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	identity := req.Auth.Identity
+	if len(identity.Methods) == 0 {
+		return http.StatusBadRequest, "Expecting to find methods in identity."
+	}
+
+	var totp bool
+	for _, method := range identity.Methods {
+		totp = totp || method == "totp"
+	}
+
+	for _, method := range identity.Methods {
+		switch method {
+		case "password":
+			user := identity.Password.User
+			if (user.Name != s.Username && user.ID != s.UserID) || user.Password != s.Password {
+				return http.StatusUnauthorized, "The request you have made requires authentication."
+			}
+			if s.TOTPSecret != "" && !totp {
+				return http.StatusUnauthorized, "Insufficient auth methods received for user " + s.UserID + "."
+			}
+		case "totp":
+			user := identity.TOTP.User
+			if (user.Name != s.Username && user.ID != s.UserID) || !s.validPasscode(user.Passcode) {
+				return http.StatusUnauthorized, "The request you have made requires authentication."
+			}
+		case "token":
+			if !s.validToken(identity.Token.ID) {
+				return http.StatusNotFound, "Could not find token: " + identity.Token.ID
+			}
+		case "application_credential":
+			// Any application credential is accepted as long as its
+			// secret matches the user's password.
+			if identity.ApplicationCredential.Secret != s.Password {
+				return http.StatusUnauthorized, "The request you have made requires authentication."
+			}
+		default:
+			return http.StatusUnauthorized, "Unsupported authentication method: " + method
+		}
+	}
+
+	token := strings.Replace(newID(), "-", "", -1)
+	expires := time.Now().Add(s.TokenTTL)
+	s.tokens[token] = expires
+
+	r.header.Set("X-Subject-Token", token)
+
+	return http.StatusCreated, s.tokenBody(identity.Methods, req.Auth.Scope, expires)
+}
+
+func identityGetToken(s *Server, r *request) (int, interface{}) {
+	token := r.Header.Get("X-Subject-Token")
+	expires, ok := s.tokens[token]
+	if !ok || !time.Now().Before(expires) {
+		return http.StatusNotFound, "Could not find token: " + token
+	}
+
+	r.header.Set("X-Subject-Token", token)
+
+	return http.StatusOK, s.tokenBody([]string{"token"}, nil, expires)
+}
+
+func (s *Server) tokenBody(methods []string, scope interface{}, expires time.Time) map[string]interface{} {
+	domain := map[string]interface{}{
+		"id":   DefaultDomainID,
+		"name": DefaultDomainName,
+	}
+
+	token := map[string]interface{}{
+		"methods":    methods,
+		"expires_at": expires.UTC().Format(time.RFC3339),
+		"issued_at":  now(),
+		"user": map[string]interface{}{
+			"id":     s.UserID,
+			"name":   s.Username,
+			"domain": domain,
+		},
+		"roles": []interface{}{
+			map[string]interface{}{"id": "admin", "name": "admin"},
+			map[string]interface{}{"id": "member", "name": "member"},
+		},
+	}
+
+	if sc, ok := scope.(map[string]interface{}); ok && sc["system"] != nil {
+		token["system"] = map[string]interface{}{"all": true}
+	} else if ok && sc["domain"] != nil {
+		token["domain"] = domain
+	} else {
+		token["project"] = map[string]interface{}{
+			"id":     s.ProjectID,
+			"name":   s.ProjectName,
+			"domain": domain,
+		}
+	}
+
+	var catalog []interface{}
+	for _, e := range s.catalog() {
+		var endpoints []interface{}
+		for _, iface := range []string{"public", "internal", "admin"} {
+			endpoints = append(endpoints, map[string]interface{}{
+				"id":        newID(),
+				"interface": iface,
+				"region":    s.Region,
+				"region_id": s.Region,
+				"url":       e.url,
+			})
+		}
+		catalog = append(catalog, map[string]interface{}{
+			"id":        newID(),
+			"type":      e.serviceType,
+			"name":      e.name,
+			"endpoints": endpoints,
+		})
+	}
+	token["catalog"] = catalog
+
+	return map[string]interface{}{"token": token}
+}
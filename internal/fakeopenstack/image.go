@@ -0,0 +1,258 @@
+package fakeopenstack
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) registerImage() {
+	prefix := "/image/v2"
+
+	s.handle("image", "GET", "/image", imageVersions).public = true
+	s.handle("image", "GET", prefix+"/images", imagesList)
+	s.handle("image", "POST", prefix+"/images", imageCreate)
+	s.handle("image", "GET", prefix+"/images/{id}", imageGet)
+	s.handle("image", "PATCH", prefix+"/images/{id}", imageUpdate)
+	s.handle("image", "DELETE", prefix+"/images/{id}", imageDelete)
+	s.handle("image", "PUT", prefix+"/images/{id}/file", imageUpload)
+	s.handle("image", "GET", prefix+"/images/{id}/file", imageDownload)
+	s.handle("image", "PUT", prefix+"/images/{id}/tags/{tag}", imageTagAdd)
+	s.handle("image", "DELETE", prefix+"/images/{id}/tags/{tag}", imageTagDelete)
+	s.handle("image", "GET", prefix+"/info/import", imageImportInfo)
+}
+
+// imageReadOnly are the image attributes clients may not change.
+var imageReadOnly = map[string]bool{
+	"id": true, "status": true, "created_at": true, "updated_at": true,
+	"checksum": true, "size": true, "virtual_size": true, "file": true,
+	"schema": true, "self": true,
+}
+
+func imageNotFound(r *request) (int, interface{}) {
+	return http.StatusNotFound, fmt.Sprintf("No image found with ID %s", r.vars["id"])
+}
+
+func imageVersions(s *Server, r *request) (int, interface{}) {
+	return http.StatusMultipleChoices, map[string]interface{}{
+		"versions": []interface{}{
+			map[string]interface{}{
+				"id":     "v2.9",
+				"status": "CURRENT",
+				"links": []interface{}{
+					map[string]interface{}{"rel": "self", "href": s.URL + "/image/v2/"},
+				},
+			},
+		},
+	}
+}
+
+func imageDefaults(s *Server, data map[string]interface{}) {
+	id := data["id"].(string)
+	setDefault(data, "name", nil)
+	setDefault(data, "status", "queued")
+	setDefault(data, "visibility", "shared")
+	setDefault(data, "protected", false)
+	setDefault(data, "container_format", nil)
+	setDefault(data, "disk_format", nil)
+	setDefault(data, "min_disk", 0)
+	setDefault(data, "min_ram", 0)
+	setDefault(data, "owner", s.ProjectID)
+	setDefault(data, "checksum", nil)
+	setDefault(data, "size", nil)
+	setDefault(data, "virtual_size", nil)
+	data["tags"] = stringList(data["tags"])
+	data["file"] = "/v2/images/" + id + "/file"
+	data["self"] = "/v2/images/" + id
+	data["schema"] = "/v2/schemas/image"
+}
+
+func imagesList(s *Server, r *request) (int, interface{}) {
+	images := []map[string]interface{}{}
+	for _, image := range s.coll(ImageImages).list() {
+		images = append(images, copyMap(image.data))
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"images": filter(images, r.URL.Query()),
+		"first":  "/v2/images",
+		"schema": "/v2/schemas/images",
+	}
+}
+
+func imageCreate(s *Server, r *request) (int, interface{}) {
+	data, err := r.object("")
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	for k := range data {
+		if imageReadOnly[k] && k != "id" {
+			return http.StatusForbidden, fmt.Sprintf("Attribute '%s' is read-only.", k)
+		}
+	}
+
+	images := s.coll(ImageImages)
+	if id, ok := data["id"].(string); ok {
+		if _, exists := images.get(id); exists {
+			return http.StatusConflict, fmt.Sprintf("Image with identifier %s already exists!", id)
+		}
+	} else {
+		data["id"] = newID()
+	}
+	data["created_at"] = now()
+	data["updated_at"] = now()
+	imageDefaults(s, data)
+
+	obj := images.put(data)
+
+	return http.StatusCreated, copyMap(obj.data)
+}
+
+func imageGet(s *Server, r *request) (int, interface{}) {
+	images := s.coll(ImageImages)
+	obj, ok := images.get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	data := copyMap(obj.data)
+	images.observe(obj)
+
+	return http.StatusOK, data
+}
+
+// imageUpdate applies a JSON patch to an image.
+func imageUpdate(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.coll(ImageImages).get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	var ops []struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+	if err := r.decode(&ops); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	for _, op := range ops {
+		key := strings.TrimPrefix(op.Path, "/")
+		if imageReadOnly[key] {
+			return http.StatusForbidden, fmt.Sprintf("Attribute '%s' is read-only.", key)
+		}
+
+		switch op.Op {
+		case "add", "replace":
+			if key == "tags" {
+				op.Value = stringList(op.Value)
+			}
+			obj.data[key] = op.Value
+		case "remove":
+			if _, ok := obj.data[key]; !ok {
+				return http.StatusConflict, fmt.Sprintf("Property %s does not exist.", key)
+			}
+			delete(obj.data, key)
+		default:
+			return http.StatusBadRequest, fmt.Sprintf("Invalid operation: `%s`", op.Op)
+		}
+	}
+	obj.data["updated_at"] = now()
+
+	return http.StatusOK, copyMap(obj.data)
+}
+
+func imageDelete(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.coll(ImageImages).get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	if obj.data["protected"] == true {
+		return http.StatusForbidden, fmt.Sprintf("Image %s is protected and cannot be deleted.", obj.id())
+	}
+
+	s.coll(ImageImages).remove(obj.id())
+
+	return http.StatusNoContent, nil
+}
+
+func imageUpload(s *Server, r *request) (int, interface{}) {
+	images := s.coll(ImageImages)
+	obj, ok := images.get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	if obj.str("status") != "queued" {
+		return http.StatusConflict, "Image status transition from " + obj.str("status") + " to saving is not allowed"
+	}
+
+	sum := md5.Sum(r.raw)
+	obj.meta["data"] = string(r.raw)
+	obj.data["checksum"] = hex.EncodeToString(sum[:])
+	obj.data["size"] = len(r.raw)
+	obj.data["virtual_size"] = len(r.raw)
+	obj.data["updated_at"] = now()
+	images.transition(obj, "saving", "active")
+
+	return http.StatusNoContent, nil
+}
+
+func imageDownload(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.coll(ImageImages).get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	data, ok := obj.meta["data"]
+	if !ok {
+		return http.StatusNoContent, nil
+	}
+	r.header.Set("Content-Type", "application/octet-stream")
+
+	return http.StatusOK, []byte(data)
+}
+
+func imageTagAdd(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.coll(ImageImages).get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	tags := append(stringList(obj.data["tags"]), r.vars["tag"])
+	obj.data["tags"] = stringList(tags)
+
+	return http.StatusNoContent, nil
+}
+
+func imageTagDelete(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.coll(ImageImages).get(r.vars["id"])
+	if !ok {
+		return imageNotFound(r)
+	}
+
+	tags := []interface{}{}
+	for _, tag := range stringList(obj.data["tags"]) {
+		if tag != r.vars["tag"] {
+			tags = append(tags, tag)
+		}
+	}
+	obj.data["tags"] = tags
+
+	return http.StatusNoContent, nil
+}
+
+func imageImportInfo(s *Server, r *request) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"import-methods": map[string]interface{}{
+			"description": "Import methods available.",
+			"type":        "array",
+			"value":       []interface{}{"glance-direct", "web-download"},
+		},
+	}
+}
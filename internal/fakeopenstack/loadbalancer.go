@@ -0,0 +1,424 @@
+package fakeopenstack
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// lbKinds are the collections whose objects report a provisioning_status.
+var lbKinds = []Kind{LBLoadBalancers, LBListeners, LBPools, LBMembers, LBMonitors}
+
+func init() {
+	renderers[LBLoadBalancers] = func(s *Server, obj *object, data map[string]interface{}) {
+		data["listeners"] = idList(s.coll(LBListeners).find(map[string]string{"loadbalancer_id": obj.id()}))
+		data["pools"] = idList(s.coll(LBPools).find(map[string]string{"loadbalancer_id": obj.id()}))
+	}
+
+	renderers[LBListeners] = func(s *Server, obj *object, data map[string]interface{}) {
+		data["loadbalancers"] = []interface{}{map[string]interface{}{"id": obj.str("loadbalancer_id")}}
+		data["pools"] = idList(s.coll(LBPools).find(map[string]string{"listener_id": obj.id()}))
+	}
+
+	renderers[LBPools] = func(s *Server, obj *object, data map[string]interface{}) {
+		data["loadbalancers"] = []interface{}{map[string]interface{}{"id": obj.str("loadbalancer_id")}}
+		data["listeners"] = []interface{}{}
+		if id := obj.str("listener_id"); id != "" {
+			data["listeners"] = []interface{}{map[string]interface{}{"id": id}}
+		}
+		data["members"] = idList(s.coll(LBMembers).find(map[string]string{"pool_id": obj.id()}))
+
+		data["healthmonitor_id"] = ""
+		for _, monitor := range s.coll(LBMonitors).find(map[string]string{"pool_id": obj.id()}) {
+			data["healthmonitor_id"] = monitor.id()
+		}
+	}
+
+	renderers[LBMonitors] = func(s *Server, obj *object, data map[string]interface{}) {
+		data["pools"] = []interface{}{map[string]interface{}{"id": obj.str("pool_id")}}
+	}
+}
+
+// registerLoadBalancer serves the load balancer API below prefix. It is
+// mounted both as Octavia and as the Neutron LBaaS v2 extension.
+func (s *Server) registerLoadBalancer(prefix, service string) {
+	s.serve(&collectionAPI{
+		service:  service,
+		kind:     LBLoadBalancers,
+		prefix:   prefix + "/loadbalancers",
+		singular: "loadbalancer",
+		plural:   "loadbalancers",
+		create:   loadBalancerCreate,
+		update:   lbUpdate,
+		delete:   loadBalancerDelete,
+	})
+	s.handle(service, "GET", prefix+"/loadbalancers/{id}/status", loadBalancerStatuses)
+
+	s.serve(&collectionAPI{
+		service:  service,
+		kind:     LBListeners,
+		prefix:   prefix + "/listeners",
+		singular: "listener",
+		plural:   "listeners",
+		create:   listenerCreate,
+		update:   lbUpdate,
+		delete:   listenerDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:  service,
+		kind:     LBPools,
+		prefix:   prefix + "/pools",
+		singular: "pool",
+		plural:   "pools",
+		create:   poolCreate,
+		update:   lbUpdate,
+		delete:   poolDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:     service,
+		kind:        LBMembers,
+		prefix:      prefix + "/pools/{pool_id}/members",
+		singular:    "member",
+		plural:      "members",
+		parentVar:   "pool_id",
+		parentField: "pool_id",
+		create:      memberCreate,
+		update:      lbUpdate,
+		delete:      lbDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:  service,
+		kind:     LBMonitors,
+		prefix:   prefix + "/healthmonitors",
+		singular: "healthmonitor",
+		plural:   "healthmonitors",
+		create:   monitorCreate,
+		update:   lbUpdate,
+		delete:   lbDelete,
+	})
+}
+
+func idList(objects []*object) []interface{} {
+	ids := []interface{}{}
+	for _, obj := range objects {
+		ids = append(ids, map[string]interface{}{"id": obj.id()})
+	}
+
+	return ids
+}
+
+func lbDefaults(s *Server, data map[string]interface{}) {
+	setDefault(data, "project_id", s.ProjectID)
+	setDefault(data, "tenant_id", data["project_id"])
+	setDefault(data, "name", "")
+	setDefault(data, "description", "")
+	setDefault(data, "admin_state_up", true)
+	setDefault(data, "provisioning_status", "ACTIVE")
+	setDefault(data, "operating_status", "ONLINE")
+	data["tags"] = stringList(data["tags"])
+}
+
+// lbImmutable rejects changes to a load balancer object while it or its load
+// balancer is in a PENDING_* state, like Octavia does.
+func (s *Server) lbImmutable(loadBalancerID string) (int, string) {
+	lb, ok := s.coll(LBLoadBalancers).get(loadBalancerID)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Load Balancer %s could not be found.", loadBalancerID)
+	}
+
+	switch status := lb.str("provisioning_status"); status {
+	case "ACTIVE", "ERROR":
+		return 0, ""
+	default:
+		return http.StatusConflict, fmt.Sprintf("Load Balancer %s is immutable and cannot be updated.", loadBalancerID)
+	}
+}
+
+func lbUpdate(s *Server, r *request, obj *object, fields map[string]interface{}) (int, string) {
+	if v, ok := fields["tags"]; ok {
+		fields["tags"] = stringList(v)
+	}
+
+	id := obj.str("loadbalancer_id")
+	if id == "" {
+		id = obj.id()
+	}
+
+	return s.lbImmutable(id)
+}
+
+func lbDelete(s *Server, r *request, obj *object) (int, string) {
+	return s.lbImmutable(obj.str("loadbalancer_id"))
+}
+
+func loadBalancerCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	portData := map[string]interface{}{
+		"name":         "octavia-lb-" + data["id"].(string),
+		"device_owner": "Octavia",
+		"device_id":    "lb-" + data["id"].(string),
+	}
+
+	switch {
+	case data["vip_port_id"] != nil:
+		port, ok := s.coll(NetworkPorts).get(fmt.Sprint(data["vip_port_id"]))
+		if !ok {
+			return http.StatusBadRequest, fmt.Sprintf("Port %s could not be found.", data["vip_port_id"])
+		}
+		data["vip_network_id"] = port.data["network_id"]
+		if ips := portFixedIPs(port); len(ips) > 0 {
+			data["vip_subnet_id"] = ips[0]["subnet_id"]
+			data["vip_address"] = ips[0]["ip_address"]
+		}
+	case data["vip_subnet_id"] != nil:
+		subnet, ok := s.coll(NetworkSubnets).get(fmt.Sprint(data["vip_subnet_id"]))
+		if !ok {
+			return http.StatusBadRequest, fmt.Sprintf("Subnet %s could not be found.", data["vip_subnet_id"])
+		}
+		portData["network_id"] = subnet.data["network_id"]
+		fixedIP := map[string]interface{}{"subnet_id": subnet.id()}
+		if data["vip_address"] != nil {
+			fixedIP["ip_address"] = data["vip_address"]
+		}
+		portData["fixed_ips"] = []interface{}{fixedIP}
+	case data["vip_network_id"] != nil:
+		portData["network_id"] = data["vip_network_id"]
+	default:
+		return http.StatusBadRequest, "Validation failure: VIP must contain one of: vip_port_id, vip_network_id, vip_subnet_id."
+	}
+
+	if data["vip_port_id"] == nil {
+		port, status, msg := s.createPort(portData)
+		if status != 0 {
+			return status, msg
+		}
+		port.meta["octavia"] = "true"
+
+		data["vip_port_id"] = port.id()
+		data["vip_network_id"] = port.data["network_id"]
+		if ips := portFixedIPs(port); len(ips) > 0 {
+			data["vip_subnet_id"] = ips[0]["subnet_id"]
+			data["vip_address"] = ips[0]["ip_address"]
+		}
+	}
+
+	lbDefaults(s, data)
+	setDefault(data, "provider", "amphora")
+	setDefault(data, "flavor_id", "")
+	setDefault(data, "availability_zone", "")
+	setDefault(data, "vip_qos_policy_id", "")
+
+	return 0, ""
+}
+
+func loadBalancerDelete(s *Server, r *request, obj *object) (int, string) {
+	if status, msg := s.lbImmutable(obj.id()); status != 0 {
+		return status, msg
+	}
+
+	listeners := s.coll(LBListeners).find(map[string]string{"loadbalancer_id": obj.id()})
+	pools := s.coll(LBPools).find(map[string]string{"loadbalancer_id": obj.id()})
+	if len(listeners)+len(pools) > 0 {
+		if r == nil || r.URL.Query().Get("cascade") != "true" {
+			return http.StatusBadRequest, fmt.Sprintf("Cannot delete Load Balancer %s - it has children", obj.id())
+		}
+
+		for _, pool := range pools {
+			s.deletePool(pool)
+		}
+		for _, listener := range listeners {
+			s.coll(LBListeners).remove(listener.id())
+		}
+	}
+
+	if port, ok := s.coll(NetworkPorts).get(obj.str("vip_port_id")); ok && port.meta["octavia"] == "true" {
+		s.coll(NetworkPorts).remove(port.id())
+	}
+
+	return 0, ""
+}
+
+func loadBalancerStatuses(s *Server, r *request) (int, interface{}) {
+	lb, ok := s.coll(LBLoadBalancers).get(r.vars["id"])
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Load Balancer %s could not be found.", r.vars["id"])
+	}
+
+	status := func(obj *object) map[string]interface{} {
+		return map[string]interface{}{
+			"id":                  obj.id(),
+			"name":                obj.data["name"],
+			"provisioning_status": obj.data["provisioning_status"],
+			"operating_status":    obj.data["operating_status"],
+		}
+	}
+
+	poolStatus := func(pool *object) map[string]interface{} {
+		p := status(pool)
+		members := []interface{}{}
+		for _, member := range s.coll(LBMembers).find(map[string]string{"pool_id": pool.id()}) {
+			members = append(members, status(member))
+		}
+		p["members"] = members
+		for _, monitor := range s.coll(LBMonitors).find(map[string]string{"pool_id": pool.id()}) {
+			p["healthmonitor"] = status(monitor)
+		}
+
+		return p
+	}
+
+	listeners := []interface{}{}
+	for _, listener := range s.coll(LBListeners).find(map[string]string{"loadbalancer_id": lb.id()}) {
+		l := status(listener)
+		pools := []interface{}{}
+		for _, pool := range s.coll(LBPools).find(map[string]string{"listener_id": listener.id()}) {
+			pools = append(pools, poolStatus(pool))
+		}
+		l["pools"] = pools
+		listeners = append(listeners, l)
+	}
+
+	tree := status(lb)
+	tree["listeners"] = listeners
+
+	return http.StatusOK, map[string]interface{}{
+		"statuses": map[string]interface{}{"loadbalancer": tree},
+	}
+}
+
+func listenerCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	lbID, _ := data["loadbalancer_id"].(string)
+	if status, msg := s.lbImmutable(lbID); status != 0 {
+		return status, msg
+	}
+
+	port := fmt.Sprint(data["protocol_port"])
+	if len(s.coll(LBListeners).find(map[string]string{"loadbalancer_id": lbID, "protocol_port": port})) > 0 {
+		return http.StatusConflict, fmt.Sprintf("Another Listener on this Load Balancer is already using protocol_port %s", port)
+	}
+
+	lbDefaults(s, data)
+	setDefault(data, "connection_limit", -1)
+	setDefault(data, "default_pool_id", nil)
+	setDefault(data, "default_tls_container_ref", nil)
+	setDefault(data, "sni_container_refs", []interface{}{})
+	setDefault(data, "insert_headers", map[string]interface{}{})
+	setDefault(data, "allowed_cidrs", nil)
+	setDefault(data, "timeout_client_data", 50000)
+	setDefault(data, "timeout_member_connect", 5000)
+	setDefault(data, "timeout_member_data", 50000)
+	setDefault(data, "timeout_tcp_inspect", 0)
+	setDefault(data, "l7policies", []interface{}{})
+
+	return 0, ""
+}
+
+func listenerDelete(s *Server, r *request, obj *object) (int, string) {
+	if status, msg := s.lbImmutable(obj.str("loadbalancer_id")); status != 0 {
+		return status, msg
+	}
+
+	for _, pool := range s.coll(LBPools).find(map[string]string{"listener_id": obj.id()}) {
+		pool.data["listener_id"] = ""
+	}
+
+	return 0, ""
+}
+
+func poolCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	if listenerID, ok := data["listener_id"].(string); ok && listenerID != "" {
+		listener, ok := s.coll(LBListeners).get(listenerID)
+		if !ok {
+			return http.StatusNotFound, fmt.Sprintf("Listener %s could not be found.", listenerID)
+		}
+		data["loadbalancer_id"] = listener.data["loadbalancer_id"]
+		if listener.data["default_pool_id"] == nil {
+			listener.data["default_pool_id"] = data["id"]
+		}
+	}
+
+	lbID, _ := data["loadbalancer_id"].(string)
+	if status, msg := s.lbImmutable(lbID); status != 0 {
+		return status, msg
+	}
+
+	lbDefaults(s, data)
+	setDefault(data, "listener_id", "")
+	setDefault(data, "session_persistence", nil)
+	setDefault(data, "tls_enabled", false)
+
+	return 0, ""
+}
+
+func poolDelete(s *Server, r *request, obj *object) (int, string) {
+	if status, msg := s.lbImmutable(obj.str("loadbalancer_id")); status != 0 {
+		return status, msg
+	}
+
+	s.deletePoolChildren(obj)
+
+	return 0, ""
+}
+
+// deletePool removes a pool together with its members and health monitor.
+func (s *Server) deletePool(pool *object) {
+	s.deletePoolChildren(pool)
+	s.coll(LBPools).remove(pool.id())
+}
+
+func (s *Server) deletePoolChildren(pool *object) {
+	for _, member := range s.coll(LBMembers).find(map[string]string{"pool_id": pool.id()}) {
+		s.coll(LBMembers).remove(member.id())
+	}
+	for _, monitor := range s.coll(LBMonitors).find(map[string]string{"pool_id": pool.id()}) {
+		s.coll(LBMonitors).remove(monitor.id())
+	}
+	for _, listener := range s.coll(LBListeners).find(map[string]string{"default_pool_id": pool.id()}) {
+		listener.data["default_pool_id"] = nil
+	}
+}
+
+func memberCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	pool, ok := s.coll(LBPools).get(fmt.Sprint(data["pool_id"]))
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Pool %s could not be found.", data["pool_id"])
+	}
+	data["loadbalancer_id"] = pool.data["loadbalancer_id"]
+
+	if status, msg := s.lbImmutable(pool.str("loadbalancer_id")); status != 0 {
+		return status, msg
+	}
+
+	lbDefaults(s, data)
+	setDefault(data, "weight", 1)
+	setDefault(data, "backup", false)
+	setDefault(data, "subnet_id", nil)
+	setDefault(data, "monitor_address", nil)
+	setDefault(data, "monitor_port", nil)
+
+	return 0, ""
+}
+
+func monitorCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	pool, ok := s.coll(LBPools).get(fmt.Sprint(data["pool_id"]))
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Pool %s could not be found.", data["pool_id"])
+	}
+	data["loadbalancer_id"] = pool.data["loadbalancer_id"]
+
+	if len(s.coll(LBMonitors).find(map[string]string{"pool_id": pool.id()})) > 0 {
+		return http.StatusConflict, fmt.Sprintf("Pool %s already has a health monitor", pool.id())
+	}
+
+	if status, msg := s.lbImmutable(pool.str("loadbalancer_id")); status != 0 {
+		return status, msg
+	}
+
+	lbDefaults(s, data)
+	setDefault(data, "http_method", "GET")
+	setDefault(data, "url_path", "/")
+	setDefault(data, "expected_codes", "200")
+
+	return 0, ""
+}
@@ -0,0 +1,1019 @@
+package fakeopenstack
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// networkTagKinds maps the resource types accepted by the Neutron tags API
+// to their collections.
+var networkTagKinds = map[string]Kind{
+	"networks":        NetworkNetworks,
+	"subnets":         NetworkSubnets,
+	"ports":           NetworkPorts,
+	"routers":         NetworkRouters,
+	"security-groups": NetworkSecGroups,
+	"floatingips":     NetworkFloatIPs,
+}
+
+// networkExtensions are the Neutron extensions the fake claims to support.
+var networkExtensions = []string{
+	"address-scope", "agent", "allowed-address-pairs", "binding",
+	"dns-integration", "external-net", "extra_dhcp_opt", "extraroute",
+	"port-security", "qos", "router", "security-group", "standard-attr-tag",
+	"subnet_allocation", "trunk",
+}
+
+func init() {
+	renderers[NetworkNetworks] = func(s *Server, obj *object, data map[string]interface{}) {
+		subnets := []interface{}{}
+		for _, subnet := range s.coll(NetworkSubnets).find(map[string]string{"network_id": obj.id()}) {
+			subnets = append(subnets, subnet.id())
+		}
+		data["subnets"] = subnets
+	}
+
+	renderers[NetworkSecGroups] = func(s *Server, obj *object, data map[string]interface{}) {
+		rules := []interface{}{}
+		for _, rule := range s.coll(NetworkSecRules).find(map[string]string{"security_group_id": obj.id()}) {
+			rules = append(rules, copyMap(rule.data))
+		}
+		data["security_group_rules"] = rules
+	}
+}
+
+func (s *Server) registerNetwork() {
+	prefix := "/network/v2.0"
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkNetworks,
+		prefix:   prefix + "/networks",
+		singular: "network",
+		plural:   "networks",
+		create:   networkCreate,
+		delete:   networkDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkSubnets,
+		prefix:   prefix + "/subnets",
+		singular: "subnet",
+		plural:   "subnets",
+		create:   subnetCreate,
+		delete:   subnetDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkPorts,
+		prefix:   prefix + "/ports",
+		singular: "port",
+		plural:   "ports",
+		create:   portCreate,
+		update:   portUpdate,
+		delete:   portDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkRouters,
+		prefix:   prefix + "/routers",
+		singular: "router",
+		plural:   "routers",
+		create:   routerCreate,
+		update:   routerUpdate,
+		delete:   routerDelete,
+	})
+	s.handle("network", "PUT", prefix+"/routers/{id}/add_router_interface", routerAddInterface)
+	s.handle("network", "PUT", prefix+"/routers/{id}/remove_router_interface", routerRemoveInterface)
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkSecGroups,
+		prefix:   prefix + "/security-groups",
+		singular: "security_group",
+		plural:   "security_groups",
+		create:   secGroupCreate,
+		delete:   secGroupDelete,
+	})
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkSecRules,
+		prefix:   prefix + "/security-group-rules",
+		singular: "security_group_rule",
+		plural:   "security_group_rules",
+		create:   secGroupRuleCreate,
+	})
+
+	s.serve(&collectionAPI{
+		service:  "network",
+		kind:     NetworkFloatIPs,
+		prefix:   prefix + "/floatingips",
+		singular: "floatingip",
+		plural:   "floatingips",
+		create:   floatingIPCreate,
+		update:   floatingIPUpdate,
+		delete:   floatingIPDelete,
+	})
+
+	s.handle("network", "GET", prefix+"/{resource}/{id}/tags", networkTagsList)
+	s.handle("network", "PUT", prefix+"/{resource}/{id}/tags", networkTagsReplace)
+	s.handle("network", "DELETE", prefix+"/{resource}/{id}/tags", networkTagsReplace)
+	s.handle("network", "PUT", prefix+"/{resource}/{id}/tags/{tag}", networkTagAdd)
+	s.handle("network", "GET", prefix+"/{resource}/{id}/tags/{tag}", networkTagCheck)
+	s.handle("network", "DELETE", prefix+"/{resource}/{id}/tags/{tag}", networkTagDelete)
+
+	s.handle("network", "GET", prefix+"/extensions", networkExtensionsList)
+	s.handle("network", "GET", prefix+"/extensions/{alias}", networkExtensionGet)
+}
+
+// neutronDefaults sets the attributes common to all Neutron objects.
+func neutronDefaults(s *Server, data map[string]interface{}) {
+	setDefault(data, "project_id", s.ProjectID)
+	setDefault(data, "tenant_id", data["project_id"])
+	setDefault(data, "name", "")
+	setDefault(data, "description", "")
+	data["tags"] = stringList(data["tags"])
+	data["revision_number"] = 1
+}
+
+func networkCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	neutronDefaults(s, data)
+	setDefault(data, "status", "ACTIVE")
+	setDefault(data, "admin_state_up", true)
+	setDefault(data, "shared", false)
+	setDefault(data, "router:external", false)
+	setDefault(data, "mtu", 1450)
+	setDefault(data, "port_security_enabled", true)
+	setDefault(data, "availability_zone_hints", []interface{}{})
+	setDefault(data, "availability_zones", []interface{}{"nova"})
+
+	return 0, ""
+}
+
+func networkDelete(s *Server, r *request, obj *object) (int, string) {
+	for _, port := range s.coll(NetworkPorts).find(map[string]string{"network_id": obj.id()}) {
+		if port.str("device_owner") != "network:dhcp" {
+			return http.StatusConflict, fmt.Sprintf(
+				"Unable to complete operation on network %s. There are one or more ports still in use on the network.", obj.id())
+		}
+	}
+
+	subnets := s.coll(NetworkSubnets)
+	for _, subnet := range subnets.find(map[string]string{"network_id": obj.id()}) {
+		subnets.remove(subnet.id())
+	}
+
+	return 0, ""
+}
+
+func subnetCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	networkID, _ := data["network_id"].(string)
+	if _, ok := s.coll(NetworkNetworks).get(networkID); !ok {
+		return http.StatusNotFound, fmt.Sprintf("Network %s could not be found.", networkID)
+	}
+
+	cidr, _ := data["cidr"].(string)
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Sprintf("Invalid input for cidr. Reason: '%s' is not a valid IP subnet.", cidr)
+	}
+	data["cidr"] = ipNet.String()
+
+	version := 4
+	if ipNet.IP.To4() == nil {
+		version = 6
+	}
+	data["ip_version"] = version
+
+	if v, ok := data["gateway_ip"]; !ok {
+		data["gateway_ip"] = nextIP(ipNet.IP).String()
+	} else if v == "" {
+		data["gateway_ip"] = nil
+	}
+
+	if _, ok := data["allocation_pools"]; !ok {
+		start := nextIP(nextIP(ipNet.IP))
+		end := lastIP(ipNet)
+		if version == 4 {
+			end = prevIP(end)
+		}
+		data["allocation_pools"] = []interface{}{
+			map[string]interface{}{"start": start.String(), "end": end.String()},
+		}
+	}
+
+	neutronDefaults(s, data)
+	setDefault(data, "enable_dhcp", true)
+	setDefault(data, "dns_nameservers", []interface{}{})
+	setDefault(data, "host_routes", []interface{}{})
+	setDefault(data, "ipv6_address_mode", nil)
+	setDefault(data, "ipv6_ra_mode", nil)
+	setDefault(data, "subnetpool_id", nil)
+
+	return 0, ""
+}
+
+func subnetDelete(s *Server, r *request, obj *object) (int, string) {
+	for _, port := range s.coll(NetworkPorts).list() {
+		for _, ip := range portFixedIPs(port) {
+			if ip["subnet_id"] == obj.id() && port.str("device_owner") != "network:dhcp" {
+				return http.StatusConflict, fmt.Sprintf(
+					"Unable to complete operation on subnet %s: One or more ports have an IP allocation from this subnet.", obj.id())
+			}
+		}
+	}
+
+	return 0, ""
+}
+
+func portCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	networkID, _ := data["network_id"].(string)
+	if _, ok := s.coll(NetworkNetworks).get(networkID); !ok {
+		return http.StatusNotFound, fmt.Sprintf("Network %s could not be found.", networkID)
+	}
+
+	fixedIPs, status, msg := s.allocateFixedIPs(networkID, data["fixed_ips"])
+	if status != 0 {
+		return status, msg
+	}
+	data["fixed_ips"] = fixedIPs
+
+	neutronDefaults(s, data)
+	setDefault(data, "admin_state_up", true)
+	setDefault(data, "mac_address", newMAC())
+	setDefault(data, "device_id", "")
+	setDefault(data, "device_owner", "")
+	setDefault(data, "port_security_enabled", true)
+	setDefault(data, "allowed_address_pairs", []interface{}{})
+	setDefault(data, "extra_dhcp_opts", []interface{}{})
+	setDefault(data, "binding:vnic_type", "normal")
+	setDefault(data, "binding:host_id", "")
+	setDefault(data, "binding:profile", map[string]interface{}{})
+	setDefault(data, "binding:vif_details", map[string]interface{}{})
+	setDefault(data, "binding:vif_type", "unbound")
+	setDefault(data, "dns_name", "")
+	setDefault(data, "dns_assignment", []interface{}{})
+	setDefault(data, "qos_policy_id", nil)
+
+	if _, ok := data["security_groups"]; !ok {
+		owner, _ := data["device_owner"].(string)
+		if data["port_security_enabled"] == true && !strings.HasPrefix(owner, "network:") {
+			data["security_groups"] = []interface{}{s.defaultSecGroup().id()}
+		} else {
+			data["security_groups"] = []interface{}{}
+		}
+	}
+
+	data["status"] = portStatus(data)
+
+	return 0, ""
+}
+
+func portUpdate(s *Server, r *request, obj *object, fields map[string]interface{}) (int, string) {
+	if v, ok := fields["fixed_ips"]; ok {
+		// Release the current addresses before allocating the new ones.
+		obj.data["fixed_ips"] = []interface{}{}
+		fixedIPs, status, msg := s.allocateFixedIPs(obj.str("network_id"), v)
+		if status != 0 {
+			return status, msg
+		}
+		fields["fixed_ips"] = fixedIPs
+	}
+
+	if v, ok := fields["tags"]; ok {
+		fields["tags"] = stringList(v)
+	}
+
+	merged := copyMap(obj.data)
+	for k, v := range fields {
+		merged[k] = v
+	}
+	fields["status"] = portStatus(merged)
+
+	return 0, ""
+}
+
+func portDelete(s *Server, r *request, obj *object) (int, string) {
+	for _, fip := range s.coll(NetworkFloatIPs).find(map[string]string{"port_id": obj.id()}) {
+		fip.data["port_id"] = nil
+		fip.data["fixed_ip_address"] = nil
+		fip.data["status"] = "DOWN"
+	}
+
+	return 0, ""
+}
+
+func portStatus(data map[string]interface{}) string {
+	if id, _ := data["device_id"].(string); id != "" {
+		return "ACTIVE"
+	}
+
+	return "DOWN"
+}
+
+func portFixedIPs(port *object) []map[string]interface{} {
+	var ips []map[string]interface{}
+	raw, _ := port.data["fixed_ips"].([]interface{})
+	for _, v := range raw {
+		if ip, ok := v.(map[string]interface{}); ok {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+// allocateFixedIPs resolves the requested fixed IPs of a port. Without a
+// request, one address of every subnet of the network is allocated.
+func (s *Server) allocateFixedIPs(networkID string, requested interface{}) ([]interface{}, int, string) {
+	subnets := s.coll(NetworkSubnets).find(map[string]string{"network_id": networkID})
+
+	var reqs []map[string]interface{}
+	if raw, ok := requested.([]interface{}); ok {
+		for _, v := range raw {
+			if m, ok := v.(map[string]interface{}); ok {
+				reqs = append(reqs, m)
+			}
+		}
+	} else {
+		for _, subnet := range subnets {
+			reqs = append(reqs, map[string]interface{}{"subnet_id": subnet.id()})
+		}
+	}
+
+	fixedIPs := []interface{}{}
+	for _, req := range reqs {
+		subnetID, _ := req["subnet_id"].(string)
+		address, _ := req["ip_address"].(string)
+
+		var subnet *object
+		for _, candidate := range subnets {
+			if subnetID != "" && candidate.id() == subnetID {
+				subnet = candidate
+				break
+			}
+			if subnetID == "" && address != "" && subnetContains(candidate, address) {
+				subnet = candidate
+				break
+			}
+		}
+		if subnet == nil {
+			return nil, http.StatusBadRequest, fmt.Sprintf(
+				"Invalid input for fixed_ips: no matching subnet on network %s.", networkID)
+		}
+
+		if address == "" {
+			address = s.allocateIP(subnet)
+			if address == "" {
+				return nil, http.StatusConflict, fmt.Sprintf(
+					"No more IP addresses available on network %s.", networkID)
+			}
+		} else if s.ipInUse(subnet.id(), address) {
+			return nil, http.StatusConflict, fmt.Sprintf(
+				"IP address %s already allocated in subnet %s", address, subnet.id())
+		}
+
+		fixedIPs = append(fixedIPs, map[string]interface{}{
+			"subnet_id":  subnet.id(),
+			"ip_address": address,
+		})
+	}
+
+	return fixedIPs, 0, ""
+}
+
+// allocateIP returns the first free address of a subnet's allocation pools.
+func (s *Server) allocateIP(subnet *object) string {
+	pools, _ := subnet.data["allocation_pools"].([]interface{})
+	for _, v := range pools {
+		pool, _ := v.(map[string]interface{})
+		start := net.ParseIP(fmt.Sprint(pool["start"]))
+		end := net.ParseIP(fmt.Sprint(pool["end"]))
+		if start == nil || end == nil {
+			continue
+		}
+
+		for ip := start; compareIP(ip, end) <= 0; ip = nextIP(ip) {
+			if !s.ipInUse(subnet.id(), ip.String()) {
+				return ip.String()
+			}
+		}
+	}
+
+	return ""
+}
+
+func (s *Server) ipInUse(subnetID, address string) bool {
+	for _, port := range s.coll(NetworkPorts).list() {
+		for _, ip := range portFixedIPs(port) {
+			if ip["subnet_id"] == subnetID && ip["ip_address"] == address {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func subnetContains(subnet *object, address string) bool {
+	_, ipNet, err := net.ParseCIDR(subnet.str("cidr"))
+	if err != nil {
+		return false
+	}
+
+	return ipNet.Contains(net.ParseIP(address))
+}
+
+// subnetFor returns the first subnet of a network with the given IP version.
+func (s *Server) subnetFor(networkID string, version int) *object {
+	for _, subnet := range s.coll(NetworkSubnets).find(map[string]string{"network_id": networkID}) {
+		if fmt.Sprint(subnet.data["ip_version"]) == fmt.Sprint(version) {
+			return subnet
+		}
+	}
+
+	return nil
+}
+
+// createPort creates a port on behalf of another service.
+func (s *Server) createPort(data map[string]interface{}) (*object, int, string) {
+	data["id"] = newID()
+	setDefault(data, "created_at", now())
+	setDefault(data, "updated_at", now())
+
+	if status, msg := portCreate(s, nil, data); status != 0 {
+		return nil, status, msg
+	}
+
+	return s.coll(NetworkPorts).create(data), 0, ""
+}
+
+func routerCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	neutronDefaults(s, data)
+	setDefault(data, "status", "ACTIVE")
+	setDefault(data, "admin_state_up", true)
+	setDefault(data, "routes", []interface{}{})
+	setDefault(data, "distributed", false)
+	setDefault(data, "ha", false)
+	setDefault(data, "availability_zone_hints", []interface{}{})
+	setDefault(data, "availability_zones", []interface{}{"nova"})
+
+	return s.setRouterGateway(data["id"].(string), data)
+}
+
+func routerUpdate(s *Server, r *request, obj *object, fields map[string]interface{}) (int, string) {
+	if _, ok := fields["external_gateway_info"]; ok {
+		s.releaseRouterGateway(obj.id())
+		if status, msg := s.setRouterGateway(obj.id(), fields); status != 0 {
+			return status, msg
+		}
+	}
+
+	if v, ok := fields["tags"]; ok {
+		fields["tags"] = stringList(v)
+	}
+
+	return 0, ""
+}
+
+func routerDelete(s *Server, r *request, obj *object) (int, string) {
+	interfaces := s.coll(NetworkPorts).find(map[string]string{
+		"device_id":    obj.id(),
+		"device_owner": "network:router_interface",
+	})
+	if len(interfaces) > 0 {
+		return http.StatusConflict, fmt.Sprintf(
+			"Router %s still has ports", obj.id())
+	}
+
+	s.releaseRouterGateway(obj.id())
+
+	return 0, ""
+}
+
+// setRouterGateway allocates the gateway port of a router described by
+// data["external_gateway_info"].
+func (s *Server) setRouterGateway(routerID string, data map[string]interface{}) (int, string) {
+	info, ok := data["external_gateway_info"].(map[string]interface{})
+	if !ok {
+		data["external_gateway_info"] = nil
+		return 0, ""
+	}
+
+	networkID, _ := info["network_id"].(string)
+	port, status, msg := s.createPort(map[string]interface{}{
+		"network_id":   networkID,
+		"fixed_ips":    info["external_fixed_ips"],
+		"device_id":    routerID,
+		"device_owner": "network:router_gateway",
+		"project_id":   "",
+	})
+	if status != 0 {
+		return status, msg
+	}
+
+	setDefault(info, "enable_snat", true)
+	info["external_fixed_ips"] = port.data["fixed_ips"]
+
+	return 0, ""
+}
+
+func (s *Server) releaseRouterGateway(routerID string) {
+	ports := s.coll(NetworkPorts)
+	for _, port := range ports.find(map[string]string{
+		"device_id":    routerID,
+		"device_owner": "network:router_gateway",
+	}) {
+		ports.remove(port.id())
+	}
+}
+
+func routerAddInterface(s *Server, r *request) (int, interface{}) {
+	router, ok := s.coll(NetworkRouters).get(r.vars["id"])
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Router %s could not be found.", r.vars["id"])
+	}
+
+	body, err := r.object("")
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	var port *object
+	if portID, _ := body["port_id"].(string); portID != "" {
+		if port, ok = s.coll(NetworkPorts).get(portID); !ok {
+			return http.StatusNotFound, fmt.Sprintf("Port %s could not be found.", portID)
+		}
+		if port.str("device_id") != "" {
+			return http.StatusConflict, fmt.Sprintf("Port %s is in use.", portID)
+		}
+		port.data["device_id"] = router.id()
+		port.data["device_owner"] = "network:router_interface"
+		port.data["status"] = "ACTIVE"
+	} else {
+		subnetID, _ := body["subnet_id"].(string)
+		subnet, ok := s.coll(NetworkSubnets).get(subnetID)
+		if !ok {
+			return http.StatusNotFound, fmt.Sprintf("Subnet %s could not be found.", subnetID)
+		}
+
+		var status int
+		var msg string
+		port, status, msg = s.createPort(map[string]interface{}{
+			"network_id": subnet.str("network_id"),
+			"fixed_ips": []interface{}{
+				map[string]interface{}{"subnet_id": subnetID, "ip_address": subnet.data["gateway_ip"]},
+			},
+			"device_id":    router.id(),
+			"device_owner": "network:router_interface",
+		})
+		if status != 0 {
+			return status, msg
+		}
+	}
+
+	return http.StatusOK, routerInterfaceBody(router, port)
+}
+
+func routerRemoveInterface(s *Server, r *request) (int, interface{}) {
+	router, ok := s.coll(NetworkRouters).get(r.vars["id"])
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Router %s could not be found.", r.vars["id"])
+	}
+
+	body, err := r.object("")
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	ports := s.coll(NetworkPorts)
+	portID, _ := body["port_id"].(string)
+	subnetID, _ := body["subnet_id"].(string)
+	for _, port := range ports.find(map[string]string{
+		"device_id":    router.id(),
+		"device_owner": "network:router_interface",
+	}) {
+		match := port.id() == portID
+		for _, ip := range portFixedIPs(port) {
+			if subnetID != "" && ip["subnet_id"] == subnetID {
+				match = true
+			}
+		}
+
+		if match {
+			ports.remove(port.id())
+			return http.StatusOK, routerInterfaceBody(router, port)
+		}
+	}
+
+	return http.StatusNotFound, fmt.Sprintf("Router %s has no interface matching the request.", router.id())
+}
+
+func routerInterfaceBody(router, port *object) map[string]interface{} {
+	var subnetIDs []interface{}
+	for _, ip := range portFixedIPs(port) {
+		subnetIDs = append(subnetIDs, ip["subnet_id"])
+	}
+
+	var subnetID interface{}
+	if len(subnetIDs) > 0 {
+		subnetID = subnetIDs[0]
+	}
+
+	return map[string]interface{}{
+		"id":         router.id(),
+		"tenant_id":  router.data["tenant_id"],
+		"port_id":    port.id(),
+		"network_id": port.data["network_id"],
+		"subnet_id":  subnetID,
+		"subnet_ids": subnetIDs,
+	}
+}
+
+// defaultSecGroup returns the project's default security group, creating it
+// on first use.
+func (s *Server) defaultSecGroup() *object {
+	groups := s.coll(NetworkSecGroups)
+	if found := groups.find(map[string]string{"name": "default", "project_id": s.ProjectID}); len(found) > 0 {
+		return found[0]
+	}
+
+	data := map[string]interface{}{
+		"id":          newID(),
+		"name":        "default",
+		"description": "Default security group",
+		"created_at":  now(),
+		"updated_at":  now(),
+	}
+	secGroupCreate(s, nil, data)
+
+	id := data["id"].(string)
+	for _, ethertype := range []string{"IPv4", "IPv6"} {
+		s.createSecGroupRule(map[string]interface{}{
+			"security_group_id": id,
+			"direction":         "ingress",
+			"ethertype":         ethertype,
+			"remote_group_id":   id,
+		})
+	}
+
+	return groups.create(data)
+}
+
+func secGroupCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	neutronDefaults(s, data)
+	setDefault(data, "stateful", true)
+
+	for _, ethertype := range []string{"IPv4", "IPv6"} {
+		s.createSecGroupRule(map[string]interface{}{
+			"security_group_id": data["id"],
+			"direction":         "egress",
+			"ethertype":         ethertype,
+		})
+	}
+
+	return 0, ""
+}
+
+func secGroupDelete(s *Server, r *request, obj *object) (int, string) {
+	for _, port := range s.coll(NetworkPorts).list() {
+		groups, _ := port.data["security_groups"].([]interface{})
+		if containsString(groups, obj.id()) {
+			return http.StatusConflict, fmt.Sprintf(
+				"Security Group %s in use.", obj.id())
+		}
+	}
+
+	rules := s.coll(NetworkSecRules)
+	for _, rule := range rules.find(map[string]string{"security_group_id": obj.id()}) {
+		rules.remove(rule.id())
+	}
+
+	return 0, ""
+}
+
+func (s *Server) createSecGroupRule(data map[string]interface{}) {
+	data["id"] = newID()
+	secGroupRuleDefaults(s, data)
+	s.coll(NetworkSecRules).create(data)
+}
+
+func secGroupRuleDefaults(s *Server, data map[string]interface{}) {
+	neutronDefaults(s, data)
+	delete(data, "name")
+	setDefault(data, "ethertype", "IPv4")
+	for _, key := range []string{"protocol", "port_range_min", "port_range_max", "remote_ip_prefix", "remote_group_id"} {
+		setDefault(data, key, nil)
+	}
+}
+
+func secGroupRuleCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	groupID, _ := data["security_group_id"].(string)
+	if _, ok := s.coll(NetworkSecGroups).get(groupID); !ok {
+		return http.StatusNotFound, fmt.Sprintf("Security group %s does not exist", groupID)
+	}
+
+	direction, _ := data["direction"].(string)
+	if direction != "ingress" && direction != "egress" {
+		return http.StatusBadRequest, fmt.Sprintf("Invalid input for direction: %v", data["direction"])
+	}
+
+	secGroupRuleDefaults(s, data)
+
+	for _, rule := range s.coll(NetworkSecRules).find(map[string]string{"security_group_id": groupID}) {
+		duplicate := true
+		for _, key := range []string{"direction", "ethertype", "protocol", "port_range_min", "port_range_max", "remote_ip_prefix", "remote_group_id"} {
+			if fmt.Sprint(rule.data[key]) != fmt.Sprint(data[key]) {
+				duplicate = false
+				break
+			}
+		}
+		if duplicate {
+			return http.StatusConflict, fmt.Sprintf("Security group rule already exists. Rule id is %s.", rule.id())
+		}
+	}
+
+	return 0, ""
+}
+
+func floatingIPCreate(s *Server, r *request, data map[string]interface{}) (int, string) {
+	networkID, _ := data["floating_network_id"].(string)
+	if _, ok := s.coll(NetworkNetworks).get(networkID); !ok {
+		return http.StatusNotFound, fmt.Sprintf("Network %s could not be found.", networkID)
+	}
+
+	var fixedIPs interface{}
+	if address, _ := data["floating_ip_address"].(string); address != "" {
+		fixedIPs = []interface{}{map[string]interface{}{"ip_address": address}}
+	} else if subnetID, _ := data["subnet_id"].(string); subnetID != "" {
+		fixedIPs = []interface{}{map[string]interface{}{"subnet_id": subnetID}}
+	} else if subnet := s.subnetFor(networkID, 4); subnet != nil {
+		fixedIPs = []interface{}{map[string]interface{}{"subnet_id": subnet.id()}}
+	}
+
+	port, status, msg := s.createPort(map[string]interface{}{
+		"network_id":   networkID,
+		"fixed_ips":    fixedIPs,
+		"device_id":    data["id"],
+		"device_owner": "network:floatingip",
+	})
+	if status != 0 {
+		return status, msg
+	}
+
+	ips := portFixedIPs(port)
+	if len(ips) == 0 {
+		return http.StatusBadRequest, fmt.Sprintf("Network %s has no subnet to allocate floating IPs from.", networkID)
+	}
+	data["floating_ip_address"] = ips[0]["ip_address"]
+
+	neutronDefaults(s, data)
+	delete(data, "name")
+	setDefault(data, "router_id", nil)
+	setDefault(data, "dns_name", "")
+	setDefault(data, "dns_domain", "")
+
+	return associateFloatingIP(s, data, data["port_id"])
+}
+
+func floatingIPUpdate(s *Server, r *request, obj *object, fields map[string]interface{}) (int, string) {
+	if v, ok := fields["port_id"]; ok {
+		if status, msg := associateFloatingIP(s, fields, v); status != 0 {
+			return status, msg
+		}
+	}
+
+	return 0, ""
+}
+
+func floatingIPDelete(s *Server, r *request, obj *object) (int, string) {
+	ports := s.coll(NetworkPorts)
+	for _, port := range ports.find(map[string]string{
+		"device_id":    obj.id(),
+		"device_owner": "network:floatingip",
+	}) {
+		ports.remove(port.id())
+	}
+
+	return 0, ""
+}
+
+// associateFloatingIP sets the association fields of a floating IP for the
+// given port ID, which may be nil to disassociate it.
+func associateFloatingIP(s *Server, data map[string]interface{}, portID interface{}) (int, string) {
+	id, _ := portID.(string)
+	if id == "" {
+		data["port_id"] = nil
+		data["fixed_ip_address"] = nil
+		data["status"] = "DOWN"
+		return 0, ""
+	}
+
+	port, ok := s.coll(NetworkPorts).get(id)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("Port %s could not be found.", id)
+	}
+
+	fixedIP := data["fixed_ip_address"]
+	if fixedIP == nil {
+		if ips := portFixedIPs(port); len(ips) > 0 {
+			fixedIP = ips[0]["ip_address"]
+		}
+	}
+
+	data["port_id"] = id
+	data["fixed_ip_address"] = fixedIP
+	data["status"] = "ACTIVE"
+
+	return 0, ""
+}
+
+func (s *Server) taggedObject(r *request) (*object, bool) {
+	kind, ok := networkTagKinds[r.vars["resource"]]
+	if !ok {
+		return nil, false
+	}
+
+	return s.coll(kind).get(r.vars["id"])
+}
+
+func networkTagsList(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.taggedObject(r)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", r.vars["resource"], r.vars["id"])
+	}
+
+	return http.StatusOK, map[string]interface{}{"tags": stringList(obj.data["tags"])}
+}
+
+func networkTagsReplace(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.taggedObject(r)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", r.vars["resource"], r.vars["id"])
+	}
+
+	tags := []interface{}{}
+	if r.Method == "PUT" {
+		body, err := r.object("")
+		if err != nil {
+			return http.StatusBadRequest, err.Error()
+		}
+		tags = stringList(body["tags"])
+	}
+	obj.data["tags"] = tags
+
+	if r.Method == "DELETE" {
+		return http.StatusNoContent, nil
+	}
+
+	return http.StatusOK, map[string]interface{}{"tags": tags}
+}
+
+func networkTagAdd(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.taggedObject(r)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", r.vars["resource"], r.vars["id"])
+	}
+
+	tags, _ := obj.data["tags"].([]interface{})
+	if !containsString(tags, r.vars["tag"]) {
+		obj.data["tags"] = stringList(append(tags, r.vars["tag"]))
+	}
+
+	return http.StatusCreated, nil
+}
+
+func networkTagCheck(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.taggedObject(r)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", r.vars["resource"], r.vars["id"])
+	}
+
+	tags, _ := obj.data["tags"].([]interface{})
+	if !containsString(tags, r.vars["tag"]) {
+		return http.StatusNotFound, fmt.Sprintf("Tag %s could not be found.", r.vars["tag"])
+	}
+
+	return http.StatusNoContent, nil
+}
+
+func networkTagDelete(s *Server, r *request) (int, interface{}) {
+	obj, ok := s.taggedObject(r)
+	if !ok {
+		return http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", r.vars["resource"], r.vars["id"])
+	}
+
+	tags := []interface{}{}
+	existing, _ := obj.data["tags"].([]interface{})
+	for _, tag := range existing {
+		if tag != r.vars["tag"] {
+			tags = append(tags, tag)
+		}
+	}
+	obj.data["tags"] = tags
+
+	return http.StatusNoContent, nil
+}
+
+func networkExtensionsList(s *Server, r *request) (int, interface{}) {
+	extensions := []interface{}{}
+	for _, alias := range networkExtensions {
+		extensions = append(extensions, networkExtension(alias))
+	}
+
+	return http.StatusOK, map[string]interface{}{"extensions": extensions}
+}
+
+func networkExtensionGet(s *Server, r *request) (int, interface{}) {
+	for _, alias := range networkExtensions {
+		if alias == r.vars["alias"] {
+			return http.StatusOK, map[string]interface{}{"extension": networkExtension(alias)}
+		}
+	}
+
+	return http.StatusNotFound, fmt.Sprintf("Extension with alias %s does not exist", r.vars["alias"])
+}
+
+func networkExtension(alias string) map[string]interface{} {
+	return map[string]interface{}{
+		"alias":       alias,
+		"name":        alias,
+		"description": "",
+		"updated":     "2020-01-01T00:00:00-00:00",
+		"links":       []interface{}{},
+	}
+}
+
+func newMAC() string {
+	b := make([]byte, 3)
+	rand.Read(b)
+
+	return fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", b[0], b[1], b[2])
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
+
+func prevIP(ip net.IP) net.IP {
+	prev := make(net.IP, len(ip))
+	copy(prev, ip)
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 0xff {
+			break
+		}
+	}
+
+	return prev
+}
+
+func lastIP(ipNet *net.IPNet) net.IP {
+	ip := ipNet.IP
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+
+	last := make(net.IP, len(ip))
+	for i := range ip {
+		last[i] = ip[i] | ^ipNet.Mask[i]
+	}
+
+	return last
+}
+
+func compareIP(a, b net.IP) int {
+	if v4 := a.To4(); v4 != nil {
+		a = v4
+	}
+	if v4 := b.To4(); v4 != nil {
+		b = v4
+	}
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return int(a[i]) - int(b[i])
+		}
+	}
+
+	return 0
+}
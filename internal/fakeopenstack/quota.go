@@ -0,0 +1,129 @@
+package fakeopenstack
+
+import (
+	"net/http"
+)
+
+// Quotas of the project, named as in the quota APIs of Nova, Neutron and
+// Cinder.
+const (
+	QuotaInstances   = "instances"
+	QuotaCores       = "cores"
+	QuotaRAM         = "ram"
+	QuotaPorts       = "port"
+	QuotaFloatingIPs = "floatingip"
+	QuotaVolumes     = "volumes"
+	QuotaGigabytes   = "gigabytes"
+)
+
+// SetQuota sets the limit of a quota of the project. Quotas are unlimited,
+// -1, unless set. Their usage is derived from the stored objects.
+func (s *Server) SetQuota(name string, limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.quotas[name] = limit
+}
+
+func (s *Server) registerQuotas() {
+	s.handle("compute", "GET", "/compute/v2.1/os-quota-sets/{project_id}/detail", computeQuotaDetail)
+	s.handle("network", "GET", "/network/v2.0/quotas/{project_id}/details.json", networkQuotaDetail)
+	for _, version := range []string{"v2", "v3"} {
+		s.handle("volume", "GET", "/volume/"+version+"/"+s.ProjectID+"/os-quota-sets/{project_id}", volumeQuotaSet)
+	}
+}
+
+func (s *Server) quotaLimit(name string) int {
+	if limit, ok := s.quotas[name]; ok {
+		return limit
+	}
+
+	return -1
+}
+
+// quotaUsage returns the usage of the compute, network and volume quotas.
+func (s *Server) quotaUsage() map[string]int {
+	usage := map[string]int{
+		QuotaPorts:       len(s.coll(NetworkPorts).list()),
+		QuotaFloatingIPs: len(s.coll(NetworkFloatIPs).list()),
+	}
+
+	for _, server := range s.coll(ComputeServers).list() {
+		usage[QuotaInstances]++
+		flavor, _ := server.data["flavor"].(map[string]interface{})
+		if f, ok := s.coll(ComputeFlavors).get(toString(flavor["id"])); ok {
+			usage[QuotaCores] += toInt(f.data["vcpus"])
+			usage[QuotaRAM] += toInt(f.data["ram"])
+		}
+	}
+
+	for _, volume := range s.coll(VolumeVolumes).list() {
+		usage[QuotaVolumes]++
+		usage[QuotaGigabytes] += toInt(volume.data["size"])
+	}
+
+	return usage
+}
+
+func computeQuotaDetail(s *Server, r *request) (int, interface{}) {
+	usage := s.quotaUsage()
+	set := map[string]interface{}{"id": r.vars["project_id"]}
+	for _, name := range []string{QuotaInstances, QuotaCores, QuotaRAM} {
+		set[name] = map[string]interface{}{
+			"in_use":   usage[name],
+			"reserved": 0,
+			"limit":    s.quotaLimit(name),
+		}
+	}
+
+	return http.StatusOK, map[string]interface{}{"quota_set": set}
+}
+
+func networkQuotaDetail(s *Server, r *request) (int, interface{}) {
+	usage := s.quotaUsage()
+	quota := make(map[string]interface{})
+	for _, name := range []string{QuotaPorts, QuotaFloatingIPs} {
+		quota[name] = map[string]interface{}{
+			"used":     usage[name],
+			"reserved": 0,
+			"limit":    s.quotaLimit(name),
+		}
+	}
+
+	return http.StatusOK, map[string]interface{}{"quota": quota}
+}
+
+func volumeQuotaSet(s *Server, r *request) (int, interface{}) {
+	usage := s.quotaUsage()
+	set := map[string]interface{}{"id": r.vars["project_id"]}
+	for _, name := range []string{QuotaVolumes, QuotaGigabytes} {
+		if r.URL.Query().Get("usage") == "true" {
+			set[name] = map[string]interface{}{
+				"in_use":    usage[name],
+				"allocated": 0,
+				"reserved":  0,
+				"limit":     s.quotaLimit(name),
+			}
+		} else {
+			set[name] = s.quotaLimit(name)
+		}
+	}
+
+	return http.StatusOK, map[string]interface{}{"quota_set": set}
+}
+
+func toInt(v interface{}) int {
+	switch v := v.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+
+	return 0
+}
+
+func toString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
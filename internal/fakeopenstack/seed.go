@@ -0,0 +1,97 @@
+package fakeopenstack
+
+// Names of the objects every fake cloud starts with.
+const (
+	ExternalNetworkName = "public"
+	ImageName           = "cirros-0.5.1-x86_64-disk"
+	FlavorName          = "m1.small"
+	VolumeTypeName      = "lvmdriver-1"
+)
+
+// seed sets up the default lifecycles and populates the cloud with the
+// objects a freshly deployed OpenStack has.
+func (s *Server) seed() {
+	s.coll(ComputeServers).lifecycle = Lifecycle{
+		Create: []string{"BUILD", "ACTIVE"},
+	}
+	s.coll(VolumeVolumes).lifecycle = Lifecycle{
+		Create: []string{"creating", "available"},
+		Delete: []string{"deleting"},
+	}
+	for _, kind := range lbKinds {
+		c := s.coll(kind)
+		c.statusField = "provisioning_status"
+		c.lifecycle = Lifecycle{
+			Create: []string{"PENDING_CREATE", "ACTIVE"},
+			Update: []string{"PENDING_UPDATE", "ACTIVE"},
+			Delete: []string{"PENDING_DELETE"},
+		}
+	}
+
+	flavors := []struct {
+		id, name         string
+		ram, vcpus, disk int
+	}{
+		{"1", "m1.tiny", 512, 1, 1},
+		{"2", FlavorName, 2048, 1, 20},
+		{"3", "m1.medium", 4096, 2, 40},
+	}
+	for _, f := range flavors {
+		data := map[string]interface{}{
+			"id":    f.id,
+			"name":  f.name,
+			"ram":   f.ram,
+			"vcpus": f.vcpus,
+			"disk":  f.disk,
+		}
+		flavorDefaults(data)
+		s.coll(ComputeFlavors).put(data)
+	}
+
+	image := map[string]interface{}{
+		"id":               newID(),
+		"name":             ImageName,
+		"status":           "active",
+		"visibility":       "public",
+		"container_format": "bare",
+		"disk_format":      "qcow2",
+		"size":             16338944,
+		"min_disk":         0,
+		"min_ram":          0,
+		"created_at":       now(),
+		"updated_at":       now(),
+	}
+	imageDefaults(s, image)
+	s.coll(ImageImages).put(image)
+
+	s.coll(VolumeTypes).put(map[string]interface{}{
+		"name":                            VolumeTypeName,
+		"description":                     nil,
+		"extra_specs":                     map[string]interface{}{"volume_backend_name": "lvmdriver-1"},
+		"is_public":                       true,
+		"os-volume-type-access:is_public": true,
+	})
+
+	network := map[string]interface{}{
+		"id":              newID(),
+		"name":            ExternalNetworkName,
+		"router:external": true,
+		"shared":          false,
+		"created_at":      now(),
+		"updated_at":      now(),
+	}
+	networkCreate(s, nil, network)
+	s.coll(NetworkNetworks).put(network)
+
+	subnet := map[string]interface{}{
+		"id":         newID(),
+		"name":       "public-subnet",
+		"network_id": network["id"],
+		"cidr":       "172.24.4.0/24",
+		"ip_version": 4,
+		"created_at": now(),
+		"updated_at": now(),
+	}
+	subnetCreate(s, nil, subnet)
+	s.coll(NetworkSubnets).put(subnet)
+}
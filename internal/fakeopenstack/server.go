@@ -0,0 +1,491 @@
+// Package fakeopenstack provides an in-process fake OpenStack cloud.
+//
+// The fake implements the subset of the Identity (Keystone), Compute (Nova),
+// Networking (Neutron), Block Storage (Cinder), Image (Glance) and Load
+// Balancer (Octavia) APIs that the provider relies on, along with an identity
+// provider for federated authentication through OpenID Connect and SAML2
+// ECP. Objects are kept in memory and report scripted status transitions
+// (for example BUILD -> ACTIVE or PENDING_UPDATE -> ACTIVE) as they are
+// polled, so the provider's wait loops can be exercised without a real
+// cloud.
+//
+// A typical test starts a server, points the provider's auth_url at
+// AuthURL() and authenticates with the default credentials:
+//
+//	srv := fakeopenstack.New()
+//	defer srv.Close()
+//
+//	config := auth.Config{
+//		IdentityEndpoint: srv.AuthURL(),
+//		Username:         srv.Username,
+//		Password:         srv.Password,
+//		TenantName:       srv.ProjectName,
+//		DomainName:       "Default",
+//	}
+package fakeopenstack
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults used by New.
+const (
+	DefaultRegion      = "RegionOne"
+	DefaultUsername    = "admin"
+	DefaultPassword    = "password"
+	DefaultProjectName = "admin"
+	DefaultDomainID    = "default"
+	DefaultDomainName  = "Default"
+
+	DefaultComputeMicroversion = "2.79"
+	DefaultVolumeMicroversion  = "3.59"
+)
+
+// Server is an in-process fake OpenStack cloud.
+//
+// The exported fields may be changed after New returns, but before the
+// first request is issued.
+type Server struct {
+	// URL is the base URL of the fake cloud. Each service is mounted below
+	// it, e.g. URL + "/compute/v2.1".
+	URL string
+
+	Region      string
+	Username    string
+	Password    string
+	UserID      string
+	ProjectID   string
+	ProjectName string
+
+	// TokenTTL is how long issued tokens stay valid.
+	TokenTTL time.Duration
+
+	// ComputeMicroversion and VolumeMicroversion are the maximum
+	// microversions advertised by the Compute and Block Storage v3 APIs.
+	ComputeMicroversion string
+	VolumeMicroversion  string
+
+	// TOTPSecret enables multi-factor authentication for the user if set.
+	// Password authentication then requires a TOTP passcode generated from
+	// this base32 secret as well.
+	TOTPSecret string
+
+	// AccessToken is the OpenID Connect access token issued by the fake
+	// identity provider and accepted by Keystone.
+	AccessToken string
+
+	// SAMLAssertion is the SAML assertion issued by the fake identity
+	// provider and accepted by Keystone.
+	SAMLAssertion string
+
+	httpServer *httptest.Server
+	routes     []*route
+
+	mu          sync.Mutex
+	collections map[Kind]*collection
+	tokens      map[string]time.Time
+	sessions    map[string]bool
+	relayState  string
+	faults      []*Fault
+	quotas      map[string]int
+	requests    []Request
+}
+
+// Request is a request recorded by the fake cloud.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// Fault describes a scripted API error. Matching requests are answered with
+// Status instead of being handled.
+type Fault struct {
+	// Method matches the HTTP method. An empty Method matches any method.
+	Method string
+
+	// Path matches the suffix of the request path.
+	Path string
+
+	// Status is the HTTP status code to respond with.
+	Status int
+
+	// Header holds additional response headers, e.g. Retry-After.
+	Header map[string]string
+
+	// Times is how many matching requests fail. Zero fails every matching
+	// request.
+	Times int
+}
+
+// New starts a fake OpenStack cloud. Close must be called to shut it down.
+func New() *Server {
+	s := &Server{
+		Region:      DefaultRegion,
+		Username:    DefaultUsername,
+		Password:    DefaultPassword,
+		ProjectName: DefaultProjectName,
+		TokenTTL:    time.Hour,
+
+		ComputeMicroversion: DefaultComputeMicroversion,
+		VolumeMicroversion:  DefaultVolumeMicroversion,
+
+		collections: make(map[Kind]*collection),
+		tokens:      make(map[string]time.Time),
+		sessions:    make(map[string]bool),
+		quotas:      make(map[string]int),
+	}
+	s.UserID = newID()
+	s.ProjectID = newID()
+	s.AccessToken = newID()
+	s.SAMLAssertion = newID()
+	s.relayState = newID()
+
+	s.registerIdentity()
+	s.registerFederation()
+	s.registerCompute()
+	s.registerNetwork()
+	s.registerBlockStorage()
+	s.registerImage()
+	s.registerLoadBalancer("/load-balancer/v2.0/lbaas", "load-balancer")
+	s.registerLoadBalancer("/network/v2.0/lbaas", "network")
+	s.registerQuotas()
+
+	s.seed()
+
+	s.httpServer = httptest.NewServer(s)
+	s.URL = s.httpServer.URL
+
+	return s
+}
+
+// Close shuts down the fake cloud.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// AuthURL returns the Identity v3 endpoint of the fake cloud.
+func (s *Server) AuthURL() string {
+	return s.URL + "/identity/v3"
+}
+
+// Endpoint returns the catalog URL of the given service type.
+func (s *Server) Endpoint(serviceType string) string {
+	for _, e := range s.catalog() {
+		if e.serviceType == serviceType {
+			return e.url
+		}
+	}
+
+	return ""
+}
+
+// AddFault scripts an API error.
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+
+	return requests
+}
+
+// RevokeTokens invalidates all issued tokens, forcing clients to
+// re-authenticate.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = make(map[string]time.Time)
+}
+
+// request is an incoming API request matched to a route.
+type request struct {
+	*http.Request
+
+	vars   map[string]string
+	raw    []byte
+	header http.Header
+}
+
+// decode unmarshals the JSON request body into v.
+func (r *request) decode(v interface{}) error {
+	if len(r.raw) == 0 {
+		return fmt.Errorf("empty request body")
+	}
+
+	return json.Unmarshal(r.raw, v)
+}
+
+// object decodes a JSON object request body, optionally unwrapping it from
+// the given envelope key.
+func (r *request) object(key string) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+
+	if key == "" {
+		return body, nil
+	}
+
+	v, ok := body[key].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing %q in request body", key)
+	}
+
+	return v, nil
+}
+
+// handlerFunc handles a request and returns the response status and body.
+// A nil body results in an empty response, a []byte body is written as is
+// and anything else is encoded as JSON.
+type handlerFunc func(s *Server, r *request) (int, interface{})
+
+type route struct {
+	method   string
+	segments []string
+	service  string
+	public   bool
+	handler  handlerFunc
+}
+
+func (s *Server) handle(service, method, pattern string, h handlerFunc) *route {
+	rt := &route{
+		method:   method,
+		segments: splitPath(pattern),
+		service:  service,
+		handler:  h,
+	}
+	s.routes = append(s.routes, rt)
+
+	return rt
+}
+
+func (rt *route) match(method string, segments []string) (map[string]string, bool) {
+	if rt.method != method || len(rt.segments) != len(segments) {
+		return nil, false
+	}
+
+	vars := make(map[string]string)
+	for i, seg := range rt.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			vars[seg[1:len(seg)-1]] = segments[i]
+			continue
+		}
+
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
+
+func splitPath(p string) []string {
+	var segments []string
+	for _, seg := range strings.Split(p, "/") {
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+
+	return segments
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	raw, _ := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Header: req.Header.Clone(),
+		Body:   raw,
+	})
+
+	requestID := "req-" + newID()
+	w.Header().Set("X-Openstack-Request-Id", requestID)
+
+	segments := splitPath(req.URL.Path)
+	var rt *route
+	var vars map[string]string
+	for _, candidate := range s.routes {
+		if v, ok := candidate.match(req.Method, segments); ok {
+			rt, vars = candidate, v
+			break
+		}
+	}
+
+	if rt == nil {
+		writeResponse(w, http.StatusNotFound, errorBody("", http.StatusNotFound,
+			fmt.Sprintf("The resource could not be found: %s %s", req.Method, req.URL.Path)))
+		return
+	}
+
+	if rt.service == "compute" {
+		w.Header().Set("X-Compute-Request-Id", requestID)
+	}
+
+	if f := s.fault(req); f != nil {
+		for k, v := range f.Header {
+			w.Header().Set(k, v)
+		}
+		writeResponse(w, f.Status, errorBody(rt.service, f.Status, http.StatusText(f.Status)))
+		return
+	}
+
+	if !rt.public && !s.validToken(req.Header.Get("X-Auth-Token")) {
+		writeResponse(w, http.StatusUnauthorized, errorBody("identity", http.StatusUnauthorized,
+			"The request you have made requires authentication."))
+		return
+	}
+
+	r := &request{
+		Request: req,
+		vars:    vars,
+		raw:     raw,
+		header:  w.Header(),
+	}
+
+	status, body := rt.handler(s, r)
+	if status >= 400 {
+		if msg, ok := body.(string); ok {
+			body = errorBody(rt.service, status, msg)
+		}
+	}
+
+	writeResponse(w, status, body)
+}
+
+func (s *Server) fault(req *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != req.Method {
+			continue
+		}
+		if !strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), f.Path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, body interface{}) {
+	switch v := body.(type) {
+	case nil:
+		w.WriteHeader(status)
+	case []byte:
+		w.WriteHeader(status)
+		w.Write(v)
+	default:
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(v); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(buf.Bytes())
+	}
+}
+
+// errorBody builds an error document in the format of the given service.
+func errorBody(service string, status int, msg string) interface{} {
+	switch service {
+	case "compute", "volume":
+		return map[string]interface{}{
+			errorKey(status): map[string]interface{}{
+				"code":    status,
+				"message": msg,
+			},
+		}
+	case "network":
+		return map[string]interface{}{
+			"NeutronError": map[string]interface{}{
+				"type":    strings.Replace(http.StatusText(status), " ", "", -1),
+				"message": msg,
+				"detail":  "",
+			},
+		}
+	case "load-balancer":
+		return map[string]interface{}{
+			"faultcode":   "Client",
+			"faultstring": msg,
+			"debuginfo":   nil,
+		}
+	}
+
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"title":   http.StatusText(status),
+			"message": msg,
+		},
+	}
+}
+
+func errorKey(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "badRequest"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "itemNotFound"
+	case http.StatusConflict:
+		return "conflictingRequest"
+	}
+
+	return "computeFault"
+}
+
+// newID returns a random UUID.
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05Z")
+}
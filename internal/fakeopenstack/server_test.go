@@ -0,0 +1,217 @@
+package fakeopenstack
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProvider(t *testing.T, s *Server) *gophercloud.ProviderClient {
+	provider, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: s.AuthURL(),
+		Username:         s.Username,
+		Password:         s.Password,
+		TenantName:       s.ProjectName,
+		DomainName:       DefaultDomainName,
+		AllowReauth:      true,
+	})
+	if err != nil {
+		t.Fatalf("Error authenticating: %s", err)
+	}
+
+	return provider
+}
+
+func testNetwork(t *testing.T, client *gophercloud.ServiceClient) (*networks.Network, *subnets.Subnet) {
+	network, err := networks.Create(client, networks.CreateOpts{Name: "private"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating network: %s", err)
+	}
+
+	subnet, err := subnets.Create(client, subnets.CreateOpts{
+		NetworkID: network.ID,
+		CIDR:      "192.168.199.0/24",
+		IPVersion: gophercloud.IPv4,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating subnet: %s", err)
+	}
+
+	return network, subnet
+}
+
+func TestAuthentication(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	_, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: s.AuthURL(),
+		Username:         s.Username,
+		Password:         "wrong",
+		TenantName:       s.ProjectName,
+		DomainName:       DefaultDomainName,
+	})
+	assert.Error(t, err)
+
+	provider := testProvider(t, s)
+	client, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{Region: s.Region})
+	assert.NoError(t, err)
+	assert.Equal(t, s.Endpoint("compute")+"/", client.Endpoint)
+
+	_, err = servers.List(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	// A revoked token is transparently renewed.
+	s.RevokeTokens()
+	_, err = servers.List(client, nil).AllPages()
+	assert.NoError(t, err)
+}
+
+func TestServerLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	provider := testProvider(t, s)
+	compute, _ := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{Region: s.Region})
+	network, _ := openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{Region: s.Region})
+
+	private, _ := testNetwork(t, network)
+
+	image := s.Objects(ImageImages)[0]
+	server, err := servers.Create(compute, servers.CreateOpts{
+		Name:      "test",
+		ImageRef:  image["id"].(string),
+		FlavorRef: "2",
+		Networks:  []servers.Network{{UUID: private.ID}},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating server: %s", err)
+	}
+
+	server, err = servers.Get(compute, server.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "BUILD", server.Status)
+
+	server, err = servers.Get(compute, server.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", server.Status)
+	assert.Len(t, server.Addresses["private"], 1)
+
+	assert.NoError(t, s.SetStatus(ComputeServers, server.ID, "ERROR"))
+	server, err = servers.Get(compute, server.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "ERROR", server.Status)
+
+	assert.NoError(t, servers.Delete(compute, server.ID).ExtractErr())
+	_, err = servers.Get(compute, server.ID).Extract()
+	_, ok := err.(gophercloud.ErrDefault404)
+	assert.True(t, ok)
+
+	// The port Nova created for the server is gone as well.
+	assert.Empty(t, s.Objects(NetworkPorts))
+}
+
+func TestVolumeLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	provider := testProvider(t, s)
+	client, _ := openstack.NewBlockStorageV3(provider, gophercloud.EndpointOpts{Region: s.Region})
+
+	volume, err := volumes.Create(client, volumes.CreateOpts{Size: 1}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating volume: %s", err)
+	}
+
+	var statuses []string
+	for i := 0; i < 2; i++ {
+		v, err := volumes.Get(client, volume.ID).Extract()
+		assert.NoError(t, err)
+		statuses = append(statuses, v.Status)
+	}
+	assert.Equal(t, []string{"creating", "available"}, statuses)
+
+	assert.NoError(t, volumes.Delete(client, volume.ID, nil).ExtractErr())
+	v, err := volumes.Get(client, volume.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "deleting", v.Status)
+
+	_, err = volumes.Get(client, volume.ID).Extract()
+	_, ok := err.(gophercloud.ErrDefault404)
+	assert.True(t, ok)
+}
+
+func TestLoadBalancerLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	provider := testProvider(t, s)
+	lbClient, _ := openstack.NewLoadBalancerV2(provider, gophercloud.EndpointOpts{Region: s.Region})
+	network, _ := openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{Region: s.Region})
+
+	_, subnet := testNetwork(t, network)
+
+	lb, err := loadbalancers.Create(lbClient, loadbalancers.CreateOpts{
+		Name:        "test",
+		VipSubnetID: subnet.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating load balancer: %s", err)
+	}
+	assert.Equal(t, "PENDING_CREATE", lb.ProvisioningStatus)
+	assert.NotEmpty(t, lb.VipAddress)
+
+	// The load balancer is immutable until it becomes ACTIVE.
+	name := "updated"
+	_, err = loadbalancers.Update(lbClient, lb.ID, loadbalancers.UpdateOpts{Name: &name}).Extract()
+	_, ok := err.(gophercloud.ErrDefault409)
+	assert.True(t, ok)
+
+	lb, _ = loadbalancers.Get(lbClient, lb.ID).Extract()
+	assert.Equal(t, "PENDING_CREATE", lb.ProvisioningStatus)
+	lb, _ = loadbalancers.Get(lbClient, lb.ID).Extract()
+	assert.Equal(t, "ACTIVE", lb.ProvisioningStatus)
+
+	lb, err = loadbalancers.Update(lbClient, lb.ID, loadbalancers.UpdateOpts{Name: &name}).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "PENDING_UPDATE", lb.ProvisioningStatus)
+	assert.Equal(t, name, lb.Name)
+}
+
+func TestFaults(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	provider := testProvider(t, s)
+	client, _ := openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{Region: s.Region})
+
+	s.AddFault(Fault{
+		Method: "GET",
+		Path:   "/networks",
+		Status: http.StatusTooManyRequests,
+		Header: map[string]string{"Retry-After": "1"},
+		Times:  1,
+	})
+
+	_, err := networks.List(client, nil).AllPages()
+	assert.Error(t, err)
+
+	_, err = networks.List(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	var failed int
+	for _, r := range s.Requests() {
+		if r.Method == "GET" && r.Path == "/network/v2.0/networks" {
+			failed++
+		}
+	}
+	assert.Equal(t, 2, failed)
+}
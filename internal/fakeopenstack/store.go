@@ -0,0 +1,403 @@
+package fakeopenstack
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Kind identifies a collection of objects in the fake cloud.
+type Kind string
+
+// Collections served by the fake cloud.
+const (
+	ComputeServers    Kind = "compute/servers"
+	ComputeFlavors    Kind = "compute/flavors"
+	ComputeKeypairs   Kind = "compute/keypairs"
+	ComputeAggregates Kind = "compute/aggregates"
+	NetworkNetworks   Kind = "network/networks"
+	NetworkSubnets    Kind = "network/subnets"
+	NetworkPorts      Kind = "network/ports"
+	NetworkRouters    Kind = "network/routers"
+	NetworkSecGroups  Kind = "network/security-groups"
+	NetworkSecRules   Kind = "network/security-group-rules"
+	NetworkFloatIPs   Kind = "network/floatingips"
+	VolumeVolumes     Kind = "volume/volumes"
+	VolumeTypes       Kind = "volume/types"
+	ImageImages       Kind = "image/images"
+	LBLoadBalancers   Kind = "load-balancer/loadbalancers"
+	LBListeners       Kind = "load-balancer/listeners"
+	LBPools           Kind = "load-balancer/pools"
+	LBMembers         Kind = "load-balancer/members"
+	LBMonitors        Kind = "load-balancer/healthmonitors"
+)
+
+// Lifecycle scripts the statuses an object reports while it is polled.
+//
+// Every read of a single object reports its current status and then advances
+// to the next status of the active list. The last status of Create and
+// Update sticks. Once the Delete list is exhausted the object disappears;
+// an empty Delete list removes the object immediately.
+type Lifecycle struct {
+	Create []string
+	Update []string
+	Delete []string
+}
+
+// object is a single stored API object.
+type object struct {
+	data map[string]interface{}
+
+	// meta holds bookkeeping which is never rendered.
+	meta map[string]string
+
+	queue    []string
+	deleting bool
+}
+
+type collection struct {
+	kind        Kind
+	statusField string
+	lifecycle   Lifecycle
+	objects     map[string]*object
+	order       []string
+}
+
+// SetLifecycle changes the scripted statuses of a collection. It only
+// affects objects created or changed afterwards.
+func (s *Server) SetLifecycle(kind Kind, l Lifecycle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.coll(kind).lifecycle = l
+}
+
+// SetStatus scripts the statuses an existing object reports on its next
+// reads, e.g. SetStatus(ComputeServers, id, "ERROR").
+func (s *Server) SetStatus(kind Kind, id string, statuses ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.coll(kind)
+	obj, ok := c.objects[id]
+	if !ok {
+		return fmt.Errorf("%s %s not found", kind, id)
+	}
+
+	c.transition(obj, statuses...)
+
+	return nil
+}
+
+// Seed stores an object as is and returns its ID. It is meant to populate
+// the cloud with objects the provider does not create itself, such as
+// external networks.
+func (s *Server) Seed(kind Kind, data map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.coll(kind).put(copyMap(data)).id()
+}
+
+// Object returns a copy of a stored object without advancing its status.
+func (s *Server) Object(kind Kind, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.coll(kind).objects[id]
+	if !ok {
+		return nil, false
+	}
+
+	return s.render(kind, obj), true
+}
+
+// Objects returns copies of all stored objects of a collection.
+func (s *Server) Objects(kind Kind) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var objects []map[string]interface{}
+	for _, obj := range s.coll(kind).list() {
+		objects = append(objects, s.render(kind, obj))
+	}
+
+	return objects
+}
+
+func (s *Server) coll(kind Kind) *collection {
+	c, ok := s.collections[kind]
+	if !ok {
+		c = &collection{
+			kind:        kind,
+			statusField: "status",
+			objects:     make(map[string]*object),
+		}
+		s.collections[kind] = c
+	}
+
+	return c
+}
+
+func (o *object) id() string {
+	id, _ := o.data["id"].(string)
+	return id
+}
+
+func (o *object) str(key string) string {
+	v, _ := o.data[key].(string)
+	return v
+}
+
+// put stores data as a new object, assigning an ID if it has none.
+func (c *collection) put(data map[string]interface{}) *object {
+	if id, _ := data["id"].(string); id == "" {
+		data["id"] = newID()
+	}
+
+	obj := &object{
+		data: data,
+		meta: make(map[string]string),
+	}
+	c.objects[obj.id()] = obj
+	c.order = append(c.order, obj.id())
+
+	return obj
+}
+
+// create stores data as a new object and starts its Create lifecycle.
+func (c *collection) create(data map[string]interface{}) *object {
+	obj := c.put(data)
+	c.transition(obj, c.lifecycle.Create...)
+
+	return obj
+}
+
+// update merges fields into an object and starts its Update lifecycle.
+func (c *collection) update(obj *object, fields map[string]interface{}) {
+	for k, v := range fields {
+		if k == "id" {
+			continue
+		}
+		obj.data[k] = v
+	}
+	obj.data["updated_at"] = now()
+
+	c.transition(obj, c.lifecycle.Update...)
+}
+
+// delete starts the Delete lifecycle of an object.
+func (c *collection) delete(obj *object) {
+	if len(c.lifecycle.Delete) == 0 {
+		c.remove(obj.id())
+		return
+	}
+
+	c.transition(obj, c.lifecycle.Delete...)
+	obj.deleting = true
+}
+
+func (c *collection) remove(id string) {
+	delete(c.objects, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// transition sets the current status of an object and queues the rest.
+func (c *collection) transition(obj *object, statuses ...string) {
+	if len(statuses) == 0 {
+		return
+	}
+
+	obj.data[c.statusField] = statuses[0]
+	obj.queue = append([]string(nil), statuses[1:]...)
+}
+
+// observe is called after an object was reported to a client and advances
+// its scripted status.
+func (c *collection) observe(obj *object) {
+	if len(obj.queue) > 0 {
+		obj.data[c.statusField] = obj.queue[0]
+		obj.queue = obj.queue[1:]
+		return
+	}
+
+	if obj.deleting {
+		c.remove(obj.id())
+	}
+}
+
+func (c *collection) get(id string) (*object, bool) {
+	obj, ok := c.objects[id]
+	return obj, ok
+}
+
+func (c *collection) list() []*object {
+	objects := make([]*object, 0, len(c.order))
+	for _, id := range c.order {
+		objects = append(objects, c.objects[id])
+	}
+
+	return objects
+}
+
+// find returns the objects whose fields equal all of the given values.
+func (c *collection) find(fields map[string]string) []*object {
+	var objects []*object
+	for _, obj := range c.list() {
+		match := true
+		for k, v := range fields {
+			if fmt.Sprint(obj.data[k]) != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects
+}
+
+// ignoredFilters are query parameters that never filter by field.
+var ignoredFilters = map[string]bool{
+	"fields":       true,
+	"limit":        true,
+	"marker":       true,
+	"sort_key":     true,
+	"sort_dir":     true,
+	"sort":         true,
+	"all_tenants":  true,
+	"all_projects": true,
+	"page_reverse": true,
+	"cascade":      true,
+}
+
+// filter applies list query parameters to a set of rendered objects.
+// Parameters naming a scalar field must match it; "tags" requires all
+// given tags. Anything else is ignored.
+func filter(objects []map[string]interface{}, query url.Values) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, obj := range objects {
+		if matches(obj, query) {
+			result = append(result, obj)
+		}
+	}
+
+	return result
+}
+
+func matches(obj map[string]interface{}, query url.Values) bool {
+	for k, values := range query {
+		if ignoredFilters[k] || len(values) == 0 {
+			continue
+		}
+
+		if k == "tags" {
+			have, _ := obj["tags"].([]interface{})
+			for _, want := range strings.Split(values[0], ",") {
+				if !containsString(have, want) {
+					return false
+				}
+			}
+			continue
+		}
+
+		v, ok := obj[k]
+		if !ok {
+			continue
+		}
+
+		switch v.(type) {
+		case string, bool, float64, int:
+			if fmt.Sprint(v) != values[0] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// render returns a copy of an object, including computed fields.
+func (s *Server) render(kind Kind, obj *object) map[string]interface{} {
+	data := copyMap(obj.data)
+	if fn, ok := renderers[kind]; ok {
+		fn(s, obj, data)
+	}
+
+	return data
+}
+
+// renderers add computed fields to rendered objects of a collection.
+var renderers = map[Kind]func(s *Server, obj *object, data map[string]interface{}){}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = copyValue(v)
+	}
+
+	return c
+}
+
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return copyMap(v)
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			c[i] = copyValue(e)
+		}
+		return c
+	}
+
+	return v
+}
+
+func containsString(list []interface{}, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringList converts a decoded JSON list into a sorted []interface{} of
+// unique strings.
+func stringList(v interface{}) []interface{} {
+	var list []string
+	seen := make(map[string]bool)
+	if raw, ok := v.([]interface{}); ok {
+		for _, e := range raw {
+			if s, ok := e.(string); ok && !seen[s] {
+				seen[s] = true
+				list = append(list, s)
+			}
+		}
+	}
+	sort.Strings(list)
+
+	result := make([]interface{}, len(list))
+	for i, s := range list {
+		result[i] = s
+	}
+
+	return result
+}
+
+// setDefault sets a field unless it is already present.
+func setDefault(data map[string]interface{}, key string, value interface{}) {
+	if _, ok := data[key]; !ok || data[key] == nil {
+		data[key] = value
+	}
+}
@@ -0,0 +1,341 @@
+package openstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxAPIErrors is the number of failed requests remembered per operation.
+const maxAPIErrors = 10
+
+// maxFaultLength is the length up to which error bodies which are not JSON
+// are included in diagnostics.
+const maxFaultLength = 256
+
+// apiError describes an API request which failed.
+type apiError struct {
+	Method    string
+	URL       string
+	Status    int
+	RequestID string
+	Fault     string
+}
+
+// detail describes the failed request in the detail of a diagnostic.
+func (e apiError) detail() string {
+	lines := []string{
+		fmt.Sprintf("Request: %s %s", e.Method, e.URL),
+		fmt.Sprintf("Status: %d", e.Status),
+	}
+	if e.RequestID != "" {
+		lines = append(lines, "Request ID: "+e.RequestID)
+	}
+	if e.Fault != "" {
+		lines = append(lines, "Fault: "+e.Fault)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// matches returns whether e is the failed request gophercloud returned err
+// for.
+func (e apiError) matches(err gophercloud.ErrUnexpectedResponseCode) bool {
+	return e.Method == err.Method && e.URL == err.URL && e.Status == err.Actual
+}
+
+// responseCodeError returns the failed request of a gophercloud error
+// returned for an unexpected response status.
+func responseCodeError(err error) (gophercloud.ErrUnexpectedResponseCode, bool) {
+	switch e := err.(type) {
+	case gophercloud.ErrUnexpectedResponseCode:
+		return e, true
+	case *gophercloud.ErrUnexpectedResponseCode:
+		return *e, true
+	case gophercloud.ErrDefault400:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault401:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault403:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault404:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault405:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault408:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault409:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault429:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault500:
+		return e.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault503:
+		return e.ErrUnexpectedResponseCode, true
+	}
+
+	return gophercloud.ErrUnexpectedResponseCode{}, false
+}
+
+// parseFault returns the message of an OpenStack error document, e.g.
+// {"badRequest": {"message": "..."}} as returned by Nova and Cinder,
+// {"NeutronError": {"message": "..."}}, {"error": {"message": "..."}} as
+// returned by Keystone and Heat, or {"faultstring": "..."} as returned by
+// Octavia.
+func parseFault(body []byte) string {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > maxFaultLength {
+			msg = msg[:maxFaultLength] + "..."
+		}
+		return msg
+	}
+
+	if msg, ok := doc["faultstring"].(string); ok {
+		return msg
+	}
+	if msg, ok := doc["message"].(string); ok {
+		return msg
+	}
+
+	for _, key := range []string{"NeutronError", "error"} {
+		if fault, ok := doc[key].(map[string]interface{}); ok {
+			if msg, ok := fault["message"].(string); ok {
+				return msg
+			}
+		}
+	}
+
+	if len(doc) == 1 {
+		for _, v := range doc {
+			if fault, ok := v.(map[string]interface{}); ok {
+				if msg, ok := fault["message"].(string); ok {
+					return msg
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// apiErrors records the failed requests of an operation.
+type apiErrors struct {
+	mu     sync.Mutex
+	errors []apiError
+
+	// lastFailed is whether the last request of the operation failed.
+	lastFailed bool
+}
+
+type apiErrorsContextKey struct{}
+
+// withAPIErrors records the failed requests using ctx in the returned
+// apiErrors.
+func withAPIErrors(ctx context.Context) (context.Context, *apiErrors) {
+	apiErrs := &apiErrors{}
+	return context.WithValue(ctx, apiErrorsContextKey{}, apiErrs), apiErrs
+}
+
+// apiErrorsFromContext returns the apiErrors recorded by withAPIErrors.
+func apiErrorsFromContext(ctx context.Context) *apiErrors {
+	apiErrs, _ := ctx.Value(apiErrorsContextKey{}).(*apiErrors)
+	return apiErrs
+}
+
+func (r *apiErrors) add(e apiError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastFailed = true
+	r.errors = append(r.errors, e)
+	if len(r.errors) > maxAPIErrors {
+		r.errors = r.errors[len(r.errors)-maxAPIErrors:]
+	}
+}
+
+// succeeded records a request which did not fail with an error status.
+func (r *apiErrors) succeeded() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastFailed = false
+}
+
+// find returns the failed request gophercloud returned err for. Errors
+// which wrap it are matched by their message.
+func (r *apiErrors) find(err error) (apiError, bool) {
+	respErr, ok := responseCodeError(err)
+	if !ok {
+		return r.findMessage(err.Error())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.errors) - 1; i >= 0; i-- {
+		if r.errors[i].matches(respErr) {
+			return r.errors[i], true
+		}
+	}
+
+	return apiError{}, false
+}
+
+// findMessage returns the latest failed request which an error message
+// names by the "[METHOD URL]" gophercloud includes in its errors.
+func (r *apiErrors) findMessage(msg string) (apiError, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := len(r.errors) - 1; i >= 0; i-- {
+		e := r.errors[i]
+		if strings.Contains(msg, "["+e.Method+" "+e.URL+"]") {
+			return e, true
+		}
+	}
+
+	return apiError{}, false
+}
+
+// last returns the last request of the operation if it failed. Errors which
+// have been turned into text are attributed to it, since operations stop at
+// the first request they cannot handle.
+func (r *apiErrors) last() (apiError, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.lastFailed || len(r.errors) == 0 {
+		return apiError{}, false
+	}
+
+	return r.errors[len(r.errors)-1], true
+}
+
+// annotate adds the failed request an operation failed with to its first
+// error diagnostic: the request the diagnostic names, or else the last
+// request if it failed.
+func (r *apiErrors) annotate(diags diag.Diagnostics) diag.Diagnostics {
+	for i, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+
+		e, ok := r.findMessage(d.Summary + "\n" + d.Detail)
+		if !ok {
+			e, ok = r.last()
+		}
+		if !ok {
+			return diags
+		}
+
+		if d.Detail != "" {
+			diags[i].Detail = d.Detail + "\n\n" + e.detail()
+		} else {
+			diags[i].Detail = e.detail()
+		}
+
+		return diags
+	}
+
+	return diags
+}
+
+// apiErrorTransport is an http.RoundTripper which records the failed
+// requests in the apiErrors of their context. It wraps the retry transport,
+// so that only the final attempt of a request is recorded.
+type apiErrorTransport struct {
+	rt http.RoundTripper
+}
+
+func newAPIErrorTransport(rt http.RoundTripper) http.RoundTripper {
+	return &apiErrorTransport{rt: rt}
+}
+
+func (t *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(req)
+
+	apiErrs := apiErrorsFromContext(req.Context())
+	if apiErrs == nil {
+		return resp, err
+	}
+
+	if err != nil || resp.StatusCode < 400 {
+		apiErrs.succeeded()
+		return resp, err
+	}
+
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return resp, err
+	}
+
+	e := apiError{
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Status:    resp.StatusCode,
+		RequestID: resp.Header.Get("X-Openstack-Request-Id"),
+		Fault:     parseFault(body),
+	}
+	if e.RequestID == "" {
+		e.RequestID = resp.Header.Get("X-Compute-Request-Id")
+	}
+	apiErrs.add(e)
+
+	return resp, err
+}
+
+// apiErrorDiagnostics adds the request ID, method, URL and fault of the
+// failed API requests to the error diagnostics of all operations of a
+// resource or data source. It is applied to all resources and data sources
+// of the provider.
+func apiErrorDiagnostics(r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			ctx, apiErrs := withAPIErrors(ctx)
+			return apiErrs.annotate(f(ctx, d, meta))
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			ctx, apiErrs := withAPIErrors(ctx)
+			results, err := importState(ctx, d, meta)
+			if err != nil {
+				e, ok := apiErrs.find(err)
+				if !ok {
+					e, ok = apiErrs.last()
+				}
+				if ok {
+					return results, fmt.Errorf("%s\n\n%s", err, e.detail())
+				}
+			}
+
+			return results, err
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,151 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestParseFault(t *testing.T) {
+	for body, expected := range map[string]string{
+		`{"badRequest": {"code": 400, "message": "Invalid flavorRef provided."}}`:                  "Invalid flavorRef provided.",
+		`{"NeutronError": {"type": "Conflict", "message": "IP address in use.", "detail": ""}}`:    "IP address in use.",
+		`{"error": {"code": 400, "title": "Bad Request", "message": "The Parameter is invalid."}}`: "The Parameter is invalid.",
+		`{"faultcode": "Client", "faultstring": "Invalid input.", "debuginfo": null}`:              "Invalid input.",
+		`{"message": "Quota exceeded."}`: "Quota exceeded.",
+		`404 Not Found`:                  "404 Not Found",
+		`{"unrelated": true}`:            "",
+	} {
+		assert.Equal(t, expected, parseFault([]byte(body)))
+	}
+
+	assert.Len(t, parseFault([]byte(strings.Repeat("x", 1000))), maxFaultLength+3)
+}
+
+func TestAPIErrorsFind(t *testing.T) {
+	apiErrs := &apiErrors{}
+	apiErrs.add(apiError{Method: "GET", URL: "https://neutron/v2.0/ports/1", Status: http.StatusNotFound, RequestID: "req-1"})
+	apiErrs.add(apiError{Method: "POST", URL: "https://nova/v2.1/servers", Status: http.StatusConflict, RequestID: "req-2"})
+
+	// gophercloud errors are matched by method, URL and status.
+	err := gophercloud.ErrDefault404{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{
+		Method: "GET", URL: "https://neutron/v2.0/ports/1", Actual: http.StatusNotFound,
+	}}
+	e, ok := apiErrs.find(err)
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", e.RequestID)
+
+	err.URL = "https://neutron/v2.0/ports/2"
+	_, ok = apiErrs.find(err)
+	assert.False(t, ok)
+
+	_, ok = apiErrs.find(fmt.Errorf("Resource not found"))
+	assert.False(t, ok)
+
+	// Other errors are attributed to the last request, if it failed.
+	e, ok = apiErrs.last()
+	assert.True(t, ok)
+	assert.Equal(t, "req-2", e.RequestID)
+
+	apiErrs.succeeded()
+	_, ok = apiErrs.last()
+	assert.False(t, ok)
+
+	// Wrapped errors are matched by the request in their message.
+	e, ok = apiErrs.find(fmt.Errorf("Error retrieving port: %s", gophercloud.ErrDefault400{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{
+		Method: "GET", URL: "https://neutron/v2.0/ports/1", Actual: http.StatusBadRequest,
+	}}))
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", e.RequestID)
+}
+
+func TestAPIErrorsAnnotate(t *testing.T) {
+	apiErrs := &apiErrors{}
+	apiErrs.add(apiError{Method: "POST", URL: "https://nova/v2.1/servers", Status: http.StatusBadRequest, RequestID: "req-1"})
+	apiErrs.add(apiError{Method: "GET", URL: "https://neutron/v2.0/ports/1", Status: http.StatusNotFound, RequestID: "req-2"})
+
+	// An error is annotated with the request it names, not with a later
+	// failed request.
+	diags := apiErrs.annotate(diag.Errorf("Error creating server: Bad request with: [POST https://nova/v2.1/servers], error message: {}"))
+	assert.Contains(t, diags[0].Detail, "Request ID: req-1")
+	assert.NotContains(t, diags[0].Detail, "req-2")
+
+	// Errors which name no request are attributed to the last one.
+	diags = apiErrs.annotate(diag.Errorf("Resource not found"))
+	assert.Contains(t, diags[0].Detail, "Request ID: req-2")
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	srv.AddFault(fakeopenstack.Fault{
+		Method: "POST",
+		Path:   "/os-keypairs",
+		Status: http.StatusBadRequest,
+		Times:  1,
+	})
+
+	keypair := Provider().ResourcesMap["openstack_compute_keypair_v2"]
+	d := testFakeResourceData(t, keypair, map[string]interface{}{
+		"name":       "keypair_1",
+		"public_key": "ssh-rsa AAAAB3NzaC1yc2E fake",
+	})
+	diags := keypair.CreateContext(context.Background(), d, config)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Detail, "Request: POST "+srv.Endpoint("compute")+"/os-keypairs")
+		assert.Contains(t, diags[0].Detail, "Status: 400")
+		assert.Contains(t, diags[0].Detail, "Request ID: req-")
+		assert.Contains(t, diags[0].Detail, "Fault: Bad Request")
+	}
+
+	srv.AddFault(fakeopenstack.Fault{
+		Method: "POST",
+		Path:   "/networks",
+		Status: http.StatusConflict,
+		Times:  1,
+	})
+
+	network := Provider().ResourcesMap["openstack_networking_network_v2"]
+	d = testFakeResourceData(t, network, map[string]interface{}{
+		"name": "network_1",
+	})
+	diags = network.CreateContext(context.Background(), d, config)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Detail, "Request: POST "+srv.Endpoint("network")+"/v2.0/networks")
+		assert.Contains(t, diags[0].Detail, "Status: 409")
+		assert.Contains(t, diags[0].Detail, "Fault: Conflict")
+	}
+
+	// A handled failure is not attributed to an error which happens after
+	// later requests succeeded.
+	r := apiErrorDiagnostics(&schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			client, err := meta.(*Config).NetworkingV2Client(ctx, srv.Region)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if _, err := networks.Get(client, "missing").Extract(); err == nil {
+				return diag.Errorf("Expected network missing to be missing")
+			}
+			if _, err := networks.List(client, nil).AllPages(); err != nil {
+				return diag.FromErr(err)
+			}
+			return diag.Errorf("Resource not found")
+		},
+	})
+	diags = r.CreateContext(context.Background(), testFakeResourceData(t, r, map[string]interface{}{}), config)
+	if assert.Len(t, diags, 1) {
+		assert.Empty(t, diags[0].Detail)
+	}
+}
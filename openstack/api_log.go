@@ -0,0 +1,221 @@
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// APILog configures the structured log of API requests.
+type APILog struct {
+	Enabled bool
+
+	// Services restricts the log to the requests sent to the given
+	// services. All requests are logged if it is empty.
+	Services []string
+
+	// File is the file the log lines are appended to. The Terraform log is
+	// used if it is empty.
+	File string
+
+	// Bodies enables logging of redacted JSON request and response bodies.
+	Bodies bool
+}
+
+// apiLogEntry is a line of the API log.
+type apiLogEntry struct {
+	Time         string      `json:"time"`
+	Service      string      `json:"service,omitempty"`
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Status       int         `json:"status,omitempty"`
+	DurationMS   int64       `json:"duration_ms"`
+	RequestID    string      `json:"request_id,omitempty"`
+	Retry        int         `json:"retry"`
+	Error        string      `json:"error,omitempty"`
+	RequestBody  interface{} `json:"request_body,omitempty"`
+	ResponseBody interface{} `json:"response_body,omitempty"`
+}
+
+// redactedValue replaces the values of sensitive fields in logged bodies.
+const redactedValue = "***"
+
+// sensitiveField matches the names of body fields whose values are redacted,
+// e.g. password, adminPass, passcode, secret, token, payload or private_key.
+var sensitiveField = regexp.MustCompile(`(?i)pass|secret|token|payload|private_key|user_data`)
+
+// idSegment matches URL path segments which are resource IDs: UUIDs, hex
+// IDs as used by Keystone, and numbers.
+var idSegment = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{32}|[0-9]+)$`)
+
+// urlTemplate returns the URL of a request without its query and with the
+// IDs in its path replaced by {id}, so that requests to different resources
+// of the same kind can be grouped.
+func urlTemplate(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, seg := range segments {
+		if idSegment.MatchString(seg) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return req.URL.Scheme + "://" + req.URL.Host + strings.Join(segments, "/")
+}
+
+// redactBody parses a JSON body and redacts its sensitive fields. Bodies
+// which are not JSON are omitted.
+func redactBody(body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+
+	return redactValue(v)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if sensitiveField.MatchString(k) {
+				v[k] = redactedValue
+			} else {
+				v[k] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
+
+func isJSONContent(header http.Header) bool {
+	return strings.HasPrefix(header.Get("Content-Type"), "application/json")
+}
+
+// apiLogTransport is an http.RoundTripper which logs each request as a JSON
+// line.
+type apiLogTransport struct {
+	rt       http.RoundTripper
+	services map[string]bool
+	bodies   bool
+
+	mu     sync.Mutex
+	output *os.File
+}
+
+func newAPILogTransport(rt http.RoundTripper, config APILog) (http.RoundTripper, error) {
+	if !config.Enabled {
+		return rt, nil
+	}
+
+	t := &apiLogTransport{rt: rt, bodies: config.Bodies}
+
+	if len(config.Services) > 0 {
+		t.services = make(map[string]bool)
+		for _, service := range config.Services {
+			t.services[service] = true
+		}
+	}
+
+	if config.File != "" {
+		path, err := homedir.Expand(config.File)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening the API log file: %s", err)
+		}
+		t.output, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening the API log file: %s", err)
+		}
+	}
+
+	return t, nil
+}
+
+func (t *apiLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	service := serviceFromContext(req.Context())
+	if t.services != nil && !t.services[service] {
+		return t.rt.RoundTrip(req)
+	}
+
+	entry := apiLogEntry{
+		Service: service,
+		Method:  req.Method,
+		URL:     urlTemplate(req),
+		Retry:   retryFromContext(req.Context()),
+	}
+
+	if t.bodies && req.GetBody != nil && isJSONContent(req.Header) {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(body)
+			body.Close()
+			entry.RequestBody = redactBody(data)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	entry.Time = start.UTC().Format(time.RFC3339Nano)
+	entry.DurationMS = int64(time.Since(start) / time.Millisecond)
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		entry.RequestID = resp.Header.Get("X-Openstack-Request-Id")
+		if entry.RequestID == "" {
+			entry.RequestID = resp.Header.Get("X-Compute-Request-Id")
+		}
+
+		if t.bodies && isJSONContent(resp.Header) {
+			data, readErr := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+			if readErr != nil {
+				entry.Error = readErr.Error()
+			}
+			entry.ResponseBody = redactBody(data)
+		}
+	}
+
+	t.write(entry)
+
+	return resp, err
+}
+
+func (t *apiLogTransport) write(entry apiLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to log OpenStack API request: %s", err)
+		return
+	}
+
+	if t.output == nil {
+		log.Printf("[DEBUG] OpenStack API: %s", line)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.output.Write(append(line, '\n')); err != nil {
+		log.Printf("[DEBUG] Unable to write the OpenStack API log: %s", err)
+	}
+}
@@ -0,0 +1,128 @@
+package openstack
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestURLTemplate(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://cloud:9876/v2.0/lbaas/loadbalancers/0c1d7a4a-7bd3-4bc5-9c6f-1e2b8e0d6f3a/stats?fields=id", nil)
+	assert.Equal(t, "https://cloud:9876/v2.0/lbaas/loadbalancers/{id}/stats", urlTemplate(req))
+
+	req, _ = http.NewRequest("GET", "https://cloud/volume/v3/6c3b5ed2b0c14d0ba4d2a6b2ffd3cd1c/volumes/detail", nil)
+	assert.Equal(t, "https://cloud/volume/v3/{id}/volumes/detail", urlTemplate(req))
+
+	req, _ = http.NewRequest("GET", "https://cloud/compute/v2.1/flavors/42", nil)
+	assert.Equal(t, "https://cloud/compute/v2.1/flavors/{id}", urlTemplate(req))
+}
+
+func TestRedactBody(t *testing.T) {
+	body := redactBody([]byte(`{
+		"auth": {"identity": {"password": {"user": {"name": "admin", "password": "secret"}}}},
+		"server": {"name": "web", "adminPass": "pass", "user_data": "c2VjcmV0"},
+		"secret": {"payload": "data", "name": "key"},
+		"credentials": [{"access_token": "abc", "id": "1"}]
+	}`))
+
+	assert.Equal(t, map[string]interface{}{
+		"auth":        map[string]interface{}{"identity": map[string]interface{}{"password": redactedValue}},
+		"server":      map[string]interface{}{"name": "web", "adminPass": redactedValue, "user_data": redactedValue},
+		"secret":      redactedValue,
+		"credentials": []interface{}{map[string]interface{}{"access_token": redactedValue, "id": "1"}},
+	}, body)
+
+	assert.Nil(t, redactBody([]byte("not json")))
+}
+
+func TestConfigAPILog(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "api-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "api.log")
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"api_log": []interface{}{
+			map[string]interface{}{
+				"services":   []interface{}{"compute"},
+				"file":       path,
+				"log_bodies": true,
+			},
+		},
+		"retry": []interface{}{
+			map[string]interface{}{
+				"min_backoff": "1ms",
+				"max_backoff": "1ms",
+			},
+		},
+	})
+
+	srv.AddFault(fakeopenstack.Fault{
+		Method: "GET",
+		Path:   "/os-keypairs",
+		Status: http.StatusTooManyRequests,
+		Header: map[string]string{"Retry-After": "0"},
+		Times:  1,
+	})
+
+	networkingClient, _ := config.NetworkingV2Client(context.Background(), srv.Region)
+	_, err = networks.List(networkingClient, nil).AllPages()
+	assert.NoError(t, err)
+
+	computeClient, _ := config.ComputeV2Client(context.Background(), srv.Region)
+	_, err = keypairs.Create(computeClient, keypairs.CreateOpts{Name: "kp"}).Extract()
+	assert.NoError(t, err)
+	_, err = keypairs.List(computeClient).AllPages()
+	assert.NoError(t, err)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []apiLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry apiLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	// The networking request is not logged, the retried request is logged
+	// once per attempt.
+	if assert.Len(t, entries, 3) {
+		create := entries[0]
+		assert.Equal(t, "compute", create.Service)
+		assert.Equal(t, "POST", create.Method)
+		assert.Equal(t, srv.URL+"/compute/v2.1/os-keypairs", create.URL)
+		assert.Equal(t, http.StatusOK, create.Status)
+		assert.NotEmpty(t, create.RequestID)
+		assert.Equal(t, map[string]interface{}{"keypair": map[string]interface{}{"name": "kp"}}, create.RequestBody)
+		keypair := create.ResponseBody.(map[string]interface{})["keypair"].(map[string]interface{})
+		assert.Equal(t, redactedValue, keypair["private_key"])
+
+		assert.Equal(t, http.StatusTooManyRequests, entries[1].Status)
+		assert.Equal(t, 0, entries[1].Retry)
+		assert.Equal(t, http.StatusOK, entries[2].Status)
+		assert.Equal(t, 1, entries[2].Retry)
+	}
+}
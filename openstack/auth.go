@@ -0,0 +1,279 @@
+package openstack
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+)
+
+// authOptions builds the authentication options the same way the base
+// Config does, either from a clouds.yaml entry or from the provider
+// arguments.
+func (c *Config) authOptions() (*gophercloud.AuthOptions, error) {
+	clientOpts := new(clientconfig.ClientOpts)
+
+	if c.Cloud != "" {
+		clientOpts.Cloud = c.Cloud
+	} else {
+		clientOpts.AuthInfo = &clientconfig.AuthInfo{
+			AuthURL:                     c.IdentityEndpoint,
+			DefaultDomain:               c.DefaultDomain,
+			DomainID:                    c.DomainID,
+			DomainName:                  c.DomainName,
+			Password:                    c.Password,
+			ProjectDomainID:             c.ProjectDomainID,
+			ProjectDomainName:           c.ProjectDomainName,
+			ProjectID:                   c.TenantID,
+			ProjectName:                 c.TenantName,
+			Token:                       c.Token,
+			UserDomainID:                c.UserDomainID,
+			UserDomainName:              c.UserDomainName,
+			Username:                    c.Username,
+			UserID:                      c.UserID,
+			ApplicationCredentialID:     c.ApplicationCredentialID,
+			ApplicationCredentialName:   c.ApplicationCredentialName,
+			ApplicationCredentialSecret: c.ApplicationCredentialSecret,
+		}
+	}
+
+	ao, err := clientconfig.AuthOptions(clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	ao.AllowReauth = c.AllowReauth
+
+	return ao, nil
+}
+
+// authenticate authenticates the provider client once and refreshes tokens
+// of the auth command before they expire. Swauth clients are authenticated
+// by the Object Storage client getter instead.
+func (c *Config) authenticate() error {
+	if c.Swauth {
+		return nil
+	}
+
+	c.authMutex.Lock()
+	defer c.authMutex.Unlock()
+
+	if c.authFailed != nil {
+		return c.authFailed
+	}
+
+	if !c.authenticated {
+		if err := c.authenticateClient(c.OsClient, *c.authOpts); err != nil {
+			if authRejected(err) {
+				c.authFailed = err
+			}
+			return err
+		}
+		c.authenticated = true
+		return nil
+	}
+
+	return c.refreshCommandToken(c.OsClient)
+}
+
+// authRejected returns whether Keystone rejected the credentials. Only such
+// failures are remembered, authentication is tried again after any other
+// error, e.g. when Keystone is unavailable.
+func authRejected(err error) bool {
+	var unauthorized gophercloud.ErrDefault401
+	return errors.As(err, &unauthorized)
+}
+
+// authenticateSystemScope authenticates the system-scoped provider client
+// once, like authenticate.
+func (c *Config) authenticateSystemScope() error {
+	c.authMutex.Lock()
+	defer c.authMutex.Unlock()
+
+	if c.systemAuthFailed != nil {
+		return c.systemAuthFailed
+	}
+
+	if !c.systemAuthenticated {
+		if err := c.authenticateClient(c.systemConfig.OsClient, *c.systemAuthOpts); err != nil {
+			if authRejected(err) {
+				c.systemAuthFailed = err
+			}
+			return err
+		}
+		c.systemAuthenticated = true
+		return nil
+	}
+
+	return c.refreshCommandToken(c.systemConfig.OsClient)
+}
+
+// authenticateClient authenticates a provider client, reusing a cached token
+// if the token cache is enabled.
+func (c *Config) authenticateClient(client *gophercloud.ProviderClient, ao gophercloud.AuthOptions) error {
+	if c.authCommand() {
+		return cachedV3Auth(client, ao, c.tokenCache, c.authCommandMethod(), c.tokenCacheSecrets(), c.createCommandToken, true)
+	}
+
+	if c.federated() {
+		return cachedV3Auth(client, ao, c.tokenCache, c.federatedAuthMethod(), c.tokenCacheSecrets(), c.createFederatedToken, true)
+	}
+
+	if c.multiFactor() {
+		return cachedV3Auth(client, ao, c.tokenCache, append(authMethod(ao), "totp"), c.tokenCacheSecrets(), c.createMultiFactorToken, true)
+	}
+
+	if c.tokenCache == nil || !tokenCacheSupported(ao) {
+		return openstack.Authenticate(client, ao)
+	}
+
+	return cachedV3Auth(client, ao, c.tokenCache, authMethod(ao), c.tokenCacheSecrets(), createToken, true)
+}
+
+// tokenCacheSecrets returns the secrets beyond the auth options which the
+// tokens in the cache are issued for.
+func (c *Config) tokenCacheSecrets() []string {
+	return []string{c.Passcode, c.TOTPSecret, c.ClientSecret, c.AccessToken}
+}
+
+// tokenCreator requests a new token from Identity v3.
+type tokenCreator func(client *gophercloud.ServiceClient, ao gophercloud.AuthOptions) tokens.CreateResult
+
+func createToken(client *gophercloud.ServiceClient, ao gophercloud.AuthOptions) tokens.CreateResult {
+	return tokens.Create(client, &ao)
+}
+
+// authMethod identifies the Identity v3 authentication method of the given
+// options in the token cache.
+func authMethod(ao gophercloud.AuthOptions) []string {
+	if ao.ApplicationCredentialID != "" || ao.ApplicationCredentialName != "" {
+		return []string{"application_credential"}
+	}
+
+	return []string{"password"}
+}
+
+// multiFactor returns whether the password is sent along with a TOTP
+// passcode.
+func (c *Config) multiFactor() bool {
+	return c.Passcode != "" || c.TOTPSecret != ""
+}
+
+// validateMultiFactor checks that TOTP authentication is combined with
+// Identity v3 password authentication.
+func (c *Config) validateMultiFactor(ao *gophercloud.AuthOptions) error {
+	if strings.Contains(ao.IdentityEndpoint, "/v2.0") {
+		return fmt.Errorf("passcode and totp_secret require Identity v3")
+	}
+
+	if ao.Password == "" || (ao.Username == "" && ao.UserID == "") {
+		return fmt.Errorf("passcode and totp_secret require user_name or user_id and password")
+	}
+
+	if c.TOTPSecret != "" {
+		if _, err := decodeTOTPSecret(c.TOTPSecret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createMultiFactorToken requests a token with the password and a TOTP
+// passcode. A passcode is only valid for a short time, so it is generated
+// anew for each request if the TOTP secret is known.
+func (c *Config) createMultiFactorToken(client *gophercloud.ServiceClient, ao gophercloud.AuthOptions) tokens.CreateResult {
+	ao.Passcode = c.Passcode
+	if c.TOTPSecret != "" {
+		passcode, err := totpPasscode(c.TOTPSecret, time.Now())
+		if err != nil {
+			var result tokens.CreateResult
+			result.Err = err
+			return result
+		}
+		ao.Passcode = passcode
+	}
+
+	return tokens.Create(client, &ao)
+}
+
+// tokenCacheSupported returns whether tokens for the given options can be
+// cached. Only Identity v3 tokens issued for credentials are cached, a
+// given token is used as is.
+func tokenCacheSupported(ao gophercloud.AuthOptions) bool {
+	return ao.TokenID == "" && !strings.Contains(ao.IdentityEndpoint, "/v2.0")
+}
+
+// cachedV3Auth authenticates a provider client against Identity v3 like
+// openstack.AuthenticateV3 does, with tokens requested by create. The tokens
+// are cached by method, ao and secrets. A valid
+// token from the cache is reused if useCache is true, new tokens are stored
+// in the cache. The cache may be nil.
+func cachedV3Auth(client *gophercloud.ProviderClient, ao gophercloud.AuthOptions, cache *tokenCache, method, secrets []string, create tokenCreator, useCache bool) error {
+	v3Client, err := openstack.NewIdentityV3(client, gophercloud.EndpointOpts{})
+	if err != nil {
+		return err
+	}
+
+	key := cache.key(v3Client.Endpoint, method, ao, secrets)
+
+	var entry *cachedToken
+	if useCache {
+		entry = cache.get(key)
+	}
+
+	var result tokens.CreateResult
+	if entry != nil {
+		log.Printf("[DEBUG] Using cached OpenStack token expiring at %s", entry.ExpiresAt)
+		result = entry.result()
+	} else {
+		result = create(v3Client, ao)
+		if result.Err == nil {
+			if err := cache.put(key, result); err != nil {
+				log.Printf("[WARN] Unable to cache OpenStack token: %s", err)
+			}
+		}
+	}
+
+	if err := client.SetTokenAndAuthResult(result); err != nil {
+		return err
+	}
+
+	catalog, err := result.ExtractServiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	if ao.AllowReauth {
+		// As in openstack.AuthenticateV3, re-authentication uses a
+		// throw-away copy of the client which does not re-authenticate
+		// itself. It bypasses the cache and replaces the rejected token.
+		tac := *client
+		tac.SetThrowaway(true)
+		tac.ReauthFunc = nil
+		tac.SetTokenAndAuthResult(nil)
+
+		tao := ao
+		tao.AllowReauth = false
+
+		client.ReauthFunc = func() error {
+			if err := cachedV3Auth(&tac, tao, cache, method, secrets, create, false); err != nil {
+				return err
+			}
+			client.CopyTokenFrom(&tac)
+
+			return nil
+		}
+	}
+
+	client.EndpointLocator = func(opts gophercloud.EndpointOpts) (string, error) {
+		return openstack.V3EndpointURL(catalog, opts)
+	}
+
+	return nil
+}
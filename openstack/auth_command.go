@@ -0,0 +1,254 @@
+package openstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+// defaultAuthCommandTimeout is the time an auth command may run if no
+// timeout is configured.
+const defaultAuthCommandTimeout = time.Minute
+
+// AuthCommand is an external program which prints the credentials to
+// authenticate with as JSON, see authCommandCredential. It runs with the
+// environment of the provider and Env.
+type AuthCommand struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+	Timeout time.Duration
+}
+
+// authCommandCredential is the output of an auth command. It holds either a
+// token, an application credential or a password. The user of an
+// application credential or password defaults to the configured user.
+type authCommandCredential struct {
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	ApplicationCredentialID     string `json:"application_credential_id"`
+	ApplicationCredentialName   string `json:"application_credential_name"`
+	ApplicationCredentialSecret string `json:"application_credential_secret"`
+
+	UserName string `json:"user_name"`
+	UserID   string `json:"user_id"`
+	Password string `json:"password"`
+}
+
+// authCommand returns whether the credentials are requested from an auth
+// command.
+func (c *Config) authCommand() bool {
+	return c.AuthCommand.Command != ""
+}
+
+// authCommandMethod identifies the credentials of the auth command in the
+// token cache.
+func (c *Config) authCommandMethod() []string {
+	return append([]string{"auth_command", c.AuthCommand.Command}, c.AuthCommand.Args...)
+}
+
+// validateAuthCommand checks that the auth command is used with Identity v3
+// and not combined with another way of requesting credentials.
+func (c *Config) validateAuthCommand(ao *gophercloud.AuthOptions) error {
+	if strings.Contains(ao.IdentityEndpoint, "/v2.0") {
+		return fmt.Errorf("auth_command requires Identity v3")
+	}
+
+	if c.federated() {
+		return fmt.Errorf("auth_command cannot be combined with auth_type %s", c.AuthType)
+	}
+
+	if c.multiFactor() {
+		return fmt.Errorf("auth_command cannot be combined with passcode or totp_secret")
+	}
+
+	return nil
+}
+
+// runAuthCommand runs the auth command and parses its output. The standard
+// error of the command is part of the returned error if it fails.
+func runAuthCommand(authCommand AuthCommand, now time.Time) (*authCommandCredential, error) {
+	timeout := authCommand.Timeout
+	if timeout == 0 {
+		timeout = defaultAuthCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, authCommand.Command, authCommand.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range authCommand.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	log.Printf("[DEBUG] Running OpenStack auth_command %s", authCommand.Command)
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("Error running auth_command %s: %s: %s", authCommand.Command, err, msg)
+		}
+		return nil, fmt.Errorf("Error running auth_command %s: %s", authCommand.Command, err)
+	}
+
+	var cred authCommandCredential
+	if err := json.Unmarshal(stdout.Bytes(), &cred); err != nil {
+		return nil, fmt.Errorf("Error parsing the output of auth_command %s: %s", authCommand.Command, err)
+	}
+	if err := cred.validate(now); err != nil {
+		return nil, fmt.Errorf("Invalid output of auth_command %s: %s", authCommand.Command, err)
+	}
+
+	return &cred, nil
+}
+
+// validate checks that the credential holds exactly one kind of
+// credentials and that a token has not expired yet.
+func (cred *authCommandCredential) validate(now time.Time) error {
+	var kinds []string
+	if cred.Token != "" {
+		kinds = append(kinds, "token")
+	}
+	if cred.ApplicationCredentialID != "" || cred.ApplicationCredentialName != "" {
+		kinds = append(kinds, "application credential")
+		if cred.ApplicationCredentialSecret == "" {
+			return fmt.Errorf("application_credential_secret is missing")
+		}
+	}
+	if cred.Password != "" {
+		kinds = append(kinds, "password")
+	}
+
+	switch len(kinds) {
+	case 0:
+		return fmt.Errorf("one of token, application_credential_id, application_credential_name or password is required")
+	case 1:
+	default:
+		return fmt.Errorf("only one of %s may be returned", strings.Join(kinds, ", "))
+	}
+
+	if cred.Token != "" && cred.ExpiresAt != nil && !cred.ExpiresAt.After(now) {
+		return fmt.Errorf("the token expired at %s", cred.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// authOptions returns the options to request a token with the credential.
+// The scope and user default to those of ao. Application credentials are
+// scoped by themselves.
+func (cred *authCommandCredential) authOptions(ao gophercloud.AuthOptions) gophercloud.AuthOptions {
+	opts := gophercloud.AuthOptions{
+		IdentityEndpoint: ao.IdentityEndpoint,
+		UserID:           ao.UserID,
+		Username:         ao.Username,
+		DomainID:         ao.DomainID,
+		DomainName:       ao.DomainName,
+		TenantID:         ao.TenantID,
+		TenantName:       ao.TenantName,
+		Scope:            ao.Scope,
+	}
+
+	if cred.UserID != "" {
+		opts.UserID, opts.Username = cred.UserID, ""
+	} else if cred.UserName != "" {
+		opts.UserID, opts.Username = "", cred.UserName
+	}
+
+	switch {
+	case cred.Token != "":
+		// As for federated tokens, the scope is made explicit, since the
+		// user and domain must not be sent along with a token.
+		opts = gophercloud.AuthOptions{
+			IdentityEndpoint: ao.IdentityEndpoint,
+			TokenID:          cred.Token,
+			Scope:            federatedScope(&ao),
+		}
+	case cred.Password != "":
+		opts.Password = cred.Password
+	default:
+		opts.ApplicationCredentialID = cred.ApplicationCredentialID
+		opts.ApplicationCredentialName = cred.ApplicationCredentialName
+		opts.ApplicationCredentialSecret = cred.ApplicationCredentialSecret
+		opts.TenantID, opts.TenantName = "", ""
+		opts.Scope = &gophercloud.AuthScope{}
+	}
+
+	return opts
+}
+
+// createCommandToken runs the auth command and requests a token with the
+// returned credentials. It runs again for each re-authentication, so that
+// the command can hand out fresh short-lived credentials.
+//
+// A returned token is used as is if no scope is configured, otherwise it is
+// rescoped.
+func (c *Config) createCommandToken(client *gophercloud.ServiceClient, ao gophercloud.AuthOptions) tokens.CreateResult {
+	var result tokens.CreateResult
+
+	cred, err := runAuthCommand(c.AuthCommand, time.Now())
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	opts := cred.authOptions(ao)
+	if opts.TokenID != "" && *opts.Scope == (gophercloud.AuthScope{}) {
+		// Validating the token requires the token itself. It is sent by a
+		// separate provider client, so that the token of the client being
+		// authenticated only changes with the result.
+		tokenClient := *client
+		tokenClient.ProviderClient = &gophercloud.ProviderClient{
+			IdentityBase:     client.ProviderClient.IdentityBase,
+			IdentityEndpoint: client.ProviderClient.IdentityEndpoint,
+			TokenID:          opts.TokenID,
+			HTTPClient:       client.ProviderClient.HTTPClient,
+			UserAgent:        client.ProviderClient.UserAgent,
+			Context:          client.ProviderClient.Context,
+		}
+		get := tokens.Get(&tokenClient, opts.TokenID)
+		result.Body, result.Header, result.Err = get.Body, get.Header, get.Err
+		return result
+	}
+
+	return tokens.Create(client, &opts)
+}
+
+// refreshCommandToken re-authenticates a client authenticated by the auth
+// command before its token expires. Tokens handed out by auth commands are
+// often short-lived, so they are replaced with the same margin as cached
+// tokens instead of failing requests on expiry.
+func (c *Config) refreshCommandToken(client *gophercloud.ProviderClient) error {
+	if !c.authCommand() {
+		return nil
+	}
+
+	result, ok := client.GetAuthResult().(tokens.CreateResult)
+	if !ok {
+		return nil
+	}
+	token, err := result.ExtractToken()
+	if err != nil || token.ExpiresAt.IsZero() || time.Until(token.ExpiresAt) > tokenCacheExpiryMargin {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Refreshing OpenStack token of auth_command expiring at %s", token.ExpiresAt)
+
+	return client.Reauthenticate(token.ID)
+}
@@ -0,0 +1,226 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+// testAuthCommand writes a shell script printing output and recording each
+// run in a file next to it. It returns the script and a function counting
+// the runs.
+func testAuthCommand(t *testing.T, output string) (string, func() int) {
+	dir, err := ioutil.TempDir("", "auth-command")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	runs := filepath.Join(dir, "runs")
+	script := filepath.Join(dir, "auth-command.sh")
+	content := fmt.Sprintf("#!/bin/sh\necho run >> %s\ncat <<'EOF'\n%s\nEOF\n", runs, output)
+	if err := ioutil.WriteFile(script, []byte(content), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	return script, func() int {
+		data, _ := ioutil.ReadFile(runs)
+		return strings.Count(string(data), "run\n")
+	}
+}
+
+func testAuthCommandConfig(t *testing.T, srv *fakeopenstack.Server, script string, raw map[string]interface{}) *Config {
+	c := map[string]interface{}{
+		"delayed_auth": false,
+		"user_name":    "",
+		"password":     "",
+		"auth_command": []interface{}{
+			map[string]interface{}{
+				"command": script,
+			},
+		},
+	}
+	for k, v := range raw {
+		c[k] = v
+	}
+
+	return testFakeProviderConfig(t, srv, c)
+}
+
+func testAuthCommandToken(t *testing.T, srv *fakeopenstack.Server) string {
+	client, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: srv.AuthURL(),
+		Username:         srv.Username,
+		Password:         srv.Password,
+		TenantName:       srv.ProjectName,
+		DomainID:         fakeopenstack.DefaultDomainID,
+	})
+	if err != nil {
+		t.Fatalf("Error authenticating: %s", err)
+	}
+
+	return client.Token()
+}
+
+func TestConfigAuthCommandApplicationCredential(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	script, runs := testAuthCommand(t, fmt.Sprintf(`{"application_credential_id": "app-cred", "application_credential_secret": %q}`, srv.Password))
+	config := testAuthCommandConfig(t, srv, script, nil)
+
+	client, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runs())
+
+	// The command runs again for fresh credentials.
+	srv.RevokeTokens()
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runs())
+}
+
+func TestConfigAuthCommandPassword(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	script, runs := testAuthCommand(t, fmt.Sprintf(`{"user_name": %q, "password": %q}`, srv.Username, srv.Password))
+	testAuthCommandConfig(t, srv, script, nil)
+
+	assert.Equal(t, 1, runs())
+}
+
+func TestConfigAuthCommandToken(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	token := testAuthCommandToken(t, srv)
+	expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	script, _ := testAuthCommand(t, fmt.Sprintf(`{"token": %q, "expires_at": %q}`, token, expiresAt))
+
+	// The token is rescoped to the configured project.
+	testAuthCommandConfig(t, srv, script, nil)
+
+	var rescoped int
+	for _, r := range srv.Requests() {
+		if r.Path == "/identity/v3/auth/tokens" && strings.Contains(string(r.Body), `"methods":["token"]`) {
+			rescoped++
+		}
+	}
+	assert.Equal(t, 1, rescoped)
+
+	// Without a project the token is used as is.
+	config := testAuthCommandConfig(t, srv, script, map[string]interface{}{
+		"tenant_name": "",
+	})
+	assert.Equal(t, token, config.OsClient.Token())
+}
+
+func TestConfigAuthCommandRefresh(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	// The tokens expire within the refresh margin.
+	srv.TokenTTL = time.Minute
+
+	script, runs := testAuthCommand(t, fmt.Sprintf(`{"user_name": %q, "password": %q}`, srv.Username, srv.Password))
+	config := testAuthCommandConfig(t, srv, script, nil)
+	assert.Equal(t, 1, runs())
+	token := config.OsClient.Token()
+
+	_, err := config.ComputeV2Client(context.Background(), srv.Region)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runs())
+	assert.NotEqual(t, token, config.OsClient.Token())
+
+	// A token used as is is validated with itself on refresh, and the
+	// client keeps its token until the new one is validated.
+	token = testAuthCommandToken(t, srv)
+	script, runs = testAuthCommand(t, fmt.Sprintf(`{"token": %q}`, token))
+	config = testAuthCommandConfig(t, srv, script, map[string]interface{}{
+		"tenant_name": "",
+	})
+	assert.Equal(t, 1, runs())
+
+	_, err = config.ComputeV2Client(context.Background(), srv.Region)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runs())
+	assert.Equal(t, token, config.OsClient.Token())
+
+	// Tokens with enough time left are kept.
+	srv.TokenTTL = time.Hour
+	script, runs = testAuthCommand(t, fmt.Sprintf(`{"user_name": %q, "password": %q}`, srv.Username, srv.Password))
+	config = testAuthCommandConfig(t, srv, script, nil)
+	_, err = config.ComputeV2Client(context.Background(), srv.Region)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runs())
+}
+
+func TestConfigAuthCommandErrors(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	failing, _ := testAuthCommand(t, "")
+	ioutil.WriteFile(failing, []byte("#!/bin/sh\necho 'broker unavailable' >&2\nexit 3\n"), 0700)
+
+	for _, tc := range []struct {
+		script string
+		err    string
+	}{
+		{failing, "exit status 3: broker unavailable"},
+		{"", "unexpected end of JSON input"},
+		{`{"token": "abc", "expires_at": "` + expired + `"}`, "the token expired at " + expired},
+		{`{"token": "abc", "password": "secret"}`, "only one of token, password may be returned"},
+		{`{"application_credential_name": "app-cred"}`, "application_credential_secret is missing"},
+		{`{}`, "one of token, application_credential_id, application_credential_name or password is required"},
+	} {
+		script := tc.script
+		if script != failing {
+			script, _ = testAuthCommand(t, tc.script)
+		}
+
+		config := &Config{AuthCommand: AuthCommand{Command: script}}
+		config.IdentityEndpoint = srv.AuthURL()
+		ao, err := config.authOptions()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client, err := openstack.NewClient(srv.AuthURL())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = config.authenticateClient(client, *ao)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func TestConfigAuthCommandValidation(t *testing.T) {
+	config := &Config{AuthCommand: AuthCommand{Command: "broker"}, TOTPSecret: "JBSWY3DPEHPK3PXP"}
+
+	err := config.validateAuthCommand(&gophercloud.AuthOptions{IdentityEndpoint: "https://keystone.example.com/v2.0"})
+	assert.EqualError(t, err, "auth_command requires Identity v3")
+
+	err = config.validateAuthCommand(&gophercloud.AuthOptions{IdentityEndpoint: "https://keystone.example.com/v3"})
+	assert.EqualError(t, err, "auth_command cannot be combined with passcode or totp_secret")
+}
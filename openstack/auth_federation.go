@@ -0,0 +1,561 @@
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"gopkg.in/yaml.v2"
+)
+
+// Federated authentication types, named as in keystoneauth and clouds.yaml.
+const (
+	authTypeOIDCPassword          = "v3oidcpassword"
+	authTypeOIDCClientCredentials = "v3oidcclientcredentials"
+	authTypeOIDCAccessToken       = "v3oidcaccesstoken"
+	authTypeSAMLPassword          = "v3samlpassword"
+)
+
+var federatedAuthTypes = []string{
+	authTypeOIDCPassword,
+	authTypeOIDCClientCredentials,
+	authTypeOIDCAccessToken,
+	authTypeSAMLPassword,
+}
+
+// authTypes are the accepted values of the auth_type argument. Only the
+// federated types change the authentication, the others are selected by
+// the given credentials.
+var authTypes = append([]string{
+	"password",
+	"token",
+	"v3password",
+	"v3token",
+	"v3applicationcredential",
+}, federatedAuthTypes...)
+
+const (
+	soapNS = "http://schemas.xmlsoap.org/soap/envelope/"
+	paosNS = "urn:liberty:paos:2003-08"
+	ecpNS  = "urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"
+
+	paosMediaType = "application/vnd.paos+xml"
+	paosHeader    = `ver="urn:liberty:paos:2003-08";"urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"`
+)
+
+// federated returns whether a federated authentication type is used.
+func (c *Config) federated() bool {
+	return strSliceContains(federatedAuthTypes, c.AuthType)
+}
+
+// cloudFederation holds the federated authentication settings of a
+// clouds.yaml entry, which the base Config does not read. The user's
+// credentials are sent to the identity provider, so they are read as well.
+type cloudFederation struct {
+	AuthType string `yaml:"auth_type"`
+	Auth     struct {
+		Username            string `yaml:"username"`
+		Password            string `yaml:"password"`
+		IdentityProvider    string `yaml:"identity_provider"`
+		Protocol            string `yaml:"protocol"`
+		ClientID            string `yaml:"client_id"`
+		ClientSecret        string `yaml:"client_secret"`
+		DiscoveryEndpoint   string `yaml:"discovery_endpoint"`
+		AccessTokenEndpoint string `yaml:"access_token_endpoint"`
+		AccessToken         string `yaml:"access_token"`
+		OpenIDScope         string `yaml:"openid_scope"`
+		IdentityProviderURL string `yaml:"identity_provider_url"`
+	} `yaml:"auth"`
+}
+
+// loadCloudFederation sets the federated authentication settings from the
+// clouds.yaml and secure.yaml entries of the cloud, unless they have been
+// set in the provider configuration.
+func (c *Config) loadCloudFederation() error {
+	for _, content := range readCloudsYAML() {
+		var clouds struct {
+			Clouds map[string]cloudFederation `yaml:"clouds"`
+		}
+		if err := yaml.Unmarshal(content, &clouds); err != nil {
+			return fmt.Errorf("Error parsing clouds.yaml: %s", err)
+		}
+
+		cloud, ok := clouds.Clouds[c.Cloud]
+		if !ok {
+			continue
+		}
+		if c.AuthType != "" && !strSliceContains(federatedAuthTypes, c.AuthType) {
+			// The entry is not used for federated authentication.
+			return nil
+		}
+
+		for _, v := range []struct {
+			field *string
+			value string
+		}{
+			{&c.AuthType, cloud.AuthType},
+			{&c.Username, cloud.Auth.Username},
+			{&c.Password, cloud.Auth.Password},
+			{&c.IdentityProvider, cloud.Auth.IdentityProvider},
+			{&c.Protocol, cloud.Auth.Protocol},
+			{&c.ClientID, cloud.Auth.ClientID},
+			{&c.ClientSecret, cloud.Auth.ClientSecret},
+			{&c.DiscoveryEndpoint, cloud.Auth.DiscoveryEndpoint},
+			{&c.AccessTokenEndpoint, cloud.Auth.AccessTokenEndpoint},
+			{&c.AccessToken, cloud.Auth.AccessToken},
+			{&c.OpenIDScope, cloud.Auth.OpenIDScope},
+			{&c.IdentityProviderURL, cloud.Auth.IdentityProviderURL},
+		} {
+			if *v.field == "" {
+				*v.field = v.value
+			}
+		}
+	}
+
+	return nil
+}
+
+// readCloudsYAML returns the contents of clouds.yaml and secure.yaml, for
+// the settings the base Config does not read. secure.yaml is optional, a
+// missing clouds.yaml has been reported by the base Config.
+func readCloudsYAML() [][]byte {
+	var contents [][]byte
+	for _, read := range []func() (string, []byte, error){
+		clientconfig.FindAndReadCloudsYAML,
+		clientconfig.FindAndReadSecureCloudsYAML,
+	} {
+		if _, content, err := read(); err == nil {
+			contents = append(contents, content)
+		}
+	}
+
+	return contents
+}
+
+// validateFederation checks that the settings required by the federated
+// authentication type are present.
+func (c *Config) validateFederation(ao *gophercloud.AuthOptions) error {
+	required := map[string]string{
+		"identity_provider": c.IdentityProvider,
+		"protocol":          c.Protocol,
+	}
+
+	switch c.AuthType {
+	case authTypeOIDCPassword:
+		required["user_name"] = c.Username
+		required["password"] = c.Password
+		fallthrough
+	case authTypeOIDCClientCredentials:
+		required["client_id"] = c.ClientID
+		if c.DiscoveryEndpoint == "" {
+			required["access_token_endpoint"] = c.AccessTokenEndpoint
+		}
+	case authTypeOIDCAccessToken:
+		required["access_token"] = c.AccessToken
+	case authTypeSAMLPassword:
+		required["user_name"] = c.Username
+		required["password"] = c.Password
+		required["identity_provider_url"] = c.IdentityProviderURL
+	}
+
+	var missing []string
+	for arg, v := range required {
+		if v == "" {
+			missing = append(missing, arg)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("Missing %s for auth_type %s", strings.Join(missing, ", "), c.AuthType)
+	}
+
+	// Federated users get an unscoped token, which has to be rescoped to
+	// the configured project or domain.
+	scope, err := (&gophercloud.AuthOptions{Scope: federatedScope(ao)}).ToTokenV3ScopeMap()
+	if err != nil {
+		return err
+	}
+	if len(scope) == 0 {
+		return fmt.Errorf("A project or domain scope is required for auth_type %s", c.AuthType)
+	}
+
+	return nil
+}
+
+// federatedAuthMethod identifies the federated credentials in the token
+// cache, including the endpoints of the identity provider. The access token
+// is one of the secrets.
+func (c *Config) federatedAuthMethod() []string {
+	return []string{
+		c.AuthType, c.IdentityProvider, c.Protocol, c.ClientID, c.Username,
+		c.DiscoveryEndpoint, c.AccessTokenEndpoint, c.IdentityProviderURL,
+	}
+}
+
+// createFederatedToken authenticates at the identity provider, exchanges
+// the result for an unscoped Keystone token and rescopes it.
+func (c *Config) createFederatedToken(client *gophercloud.ServiceClient, ao gophercloud.AuthOptions) tokens.CreateResult {
+	var result tokens.CreateResult
+
+	var unscoped string
+	var err error
+	if c.AuthType == authTypeSAMLPassword {
+		unscoped, err = c.samlUnscopedToken(client)
+	} else {
+		unscoped, err = c.oidcUnscopedToken(client)
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	return tokens.Create(client, &gophercloud.AuthOptions{
+		TokenID: unscoped,
+		Scope:   federatedScope(&ao),
+	})
+}
+
+// federatedScope returns the scope of the authentication options. Unlike
+// gophercloud, it falls back to a domain scope if no project is set, since
+// the domain of a federated user is not part of the credentials.
+func federatedScope(ao *gophercloud.AuthOptions) *gophercloud.AuthScope {
+	switch {
+	case ao.Scope != nil:
+		return ao.Scope
+	case ao.TenantID != "":
+		return &gophercloud.AuthScope{ProjectID: ao.TenantID}
+	case ao.TenantName != "":
+		return &gophercloud.AuthScope{ProjectName: ao.TenantName, DomainID: ao.DomainID, DomainName: ao.DomainName}
+	}
+
+	return &gophercloud.AuthScope{DomainID: ao.DomainID, DomainName: ao.DomainName}
+}
+
+// federatedAuthURL returns the Keystone endpoint which issues unscoped
+// tokens to federated users.
+func (c *Config) federatedAuthURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL("OS-FEDERATION", "identity_providers", c.IdentityProvider, "protocols", c.Protocol, "auth")
+}
+
+// oidcUnscopedToken gets an OpenID Connect access token and exchanges it for
+// an unscoped Keystone token.
+func (c *Config) oidcUnscopedToken(client *gophercloud.ServiceClient) (string, error) {
+	accessToken := c.AccessToken
+	if c.AuthType != authTypeOIDCAccessToken {
+		var err error
+		accessToken, err = c.oidcAccessToken(&client.HTTPClient)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	resp, err := client.Request("POST", c.federatedAuthURL(client), &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{
+			"Authorization": "Bearer " + accessToken,
+			"X-Auth-Token":  "",
+		},
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error exchanging the OpenID Connect access token: %s", err)
+	}
+	resp.Body.Close()
+
+	return subjectToken(resp)
+}
+
+// oidcAccessToken requests an access token from the identity provider with
+// the password or client credentials grant.
+func (c *Config) oidcAccessToken(httpClient *http.Client) (string, error) {
+	endpoint := c.AccessTokenEndpoint
+	if endpoint == "" {
+		var discovery struct {
+			TokenEndpoint string `json:"token_endpoint"`
+		}
+		resp, err := httpClient.Get(c.DiscoveryEndpoint)
+		if err != nil {
+			return "", fmt.Errorf("Error discovering the OpenID Connect token endpoint: %s", err)
+		}
+		err = decodeFederationResponse(resp, &discovery)
+		if err != nil {
+			return "", fmt.Errorf("Error discovering the OpenID Connect token endpoint: %s", err)
+		}
+		endpoint = discovery.TokenEndpoint
+	}
+
+	scope := c.OpenIDScope
+	if scope == "" {
+		scope = "openid"
+	}
+
+	form := url.Values{"scope": {scope}}
+	if c.AuthType == authTypeOIDCPassword {
+		form.Set("grant_type", "password")
+		form.Set("username", c.Username)
+		form.Set("password", c.Password)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Error requesting an OpenID Connect access token: %s", err)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := decodeFederationResponse(resp, &token); err != nil {
+		return "", fmt.Errorf("Error requesting an OpenID Connect access token: %s", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("Error requesting an OpenID Connect access token: no access_token in response")
+	}
+
+	return token.AccessToken, nil
+}
+
+// samlUnscopedToken gets an unscoped Keystone token through the SAML2
+// Enhanced Client or Proxy (ECP) profile:
+//
+//  1. Keystone, as service provider, answers with an authentication request.
+//  2. The request is sent to the identity provider with the user's
+//     credentials, which answers with a SAML response.
+//  3. The SAML response is sent back to Keystone, which issues the token.
+func (c *Config) samlUnscopedToken(client *gophercloud.ServiceClient) (string, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return "", err
+	}
+	httpClient := client.HTTPClient
+	httpClient.Jar = jar
+
+	authURL := c.federatedAuthURL(client)
+
+	req, err := http.NewRequest("GET", authURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", paosMediaType)
+	req.Header.Set("PAOS", paosHeader)
+
+	authnRequest, err := doSAMLRequest(&httpClient, req)
+	if err != nil {
+		return "", fmt.Errorf("Error requesting a SAML authentication request: %s", err)
+	}
+
+	paosRequest, err := findXMLElement(authnRequest, paosNS, "Request")
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the SAML authentication request: %s", err)
+	}
+	relayState, err := findXMLElement(authnRequest, ecpNS, "RelayState")
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the SAML authentication request: %s", err)
+	}
+	consumerURL := paosRequest.attr("responseConsumerURL")
+
+	authnRequest, err = removeSOAPHeader(authnRequest)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the SAML authentication request: %s", err)
+	}
+
+	req, err = http.NewRequest("POST", c.IdentityProviderURL, bytes.NewReader(authnRequest))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	req.SetBasicAuth(c.Username, c.Password)
+
+	samlResponse, err := doSAMLRequest(&httpClient, req)
+	if err != nil {
+		return "", fmt.Errorf("Error authenticating at the SAML identity provider: %s", err)
+	}
+
+	ecpResponse, err := findXMLElement(samlResponse, ecpNS, "Response")
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the SAML response: %s", err)
+	}
+
+	// The identity provider must send the response to the service provider
+	// which requested it, otherwise the assertion might be leaked.
+	if acsURL := ecpResponse.attr("AssertionConsumerServiceURL"); acsURL != consumerURL {
+		return "", fmt.Errorf("The SAML assertion consumer %q does not match the service provider %q", acsURL, consumerURL)
+	}
+
+	samlResponse, err = removeSOAPHeader(samlResponse)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the SAML response: %s", err)
+	}
+	samlResponse, err = insertSOAPHeader(samlResponse, relayState)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the SAML response: %s", err)
+	}
+
+	req, err = http.NewRequest("POST", consumerURL, bytes.NewReader(samlResponse))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", paosMediaType)
+
+	// Keystone redirects to the federated authentication endpoint, which
+	// issues the token for the established session.
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Error sending the SAML response: %s", err)
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("Error sending the SAML response: %s", resp.Status)
+	}
+
+	return subjectToken(resp)
+}
+
+func doSAMLRequest(httpClient *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned %s", req.Method, req.URL, resp.Status)
+	}
+
+	return body, nil
+}
+
+func decodeFederationResponse(resp *http.Response, v interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned %s: %s", resp.Request.Method, resp.Request.URL, resp.Status, body)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func subjectToken(resp *http.Response) (string, error) {
+	token := resp.Header.Get("X-Subject-Token")
+	if token == "" {
+		return "", fmt.Errorf("Keystone did not return an unscoped token")
+	}
+
+	return token, nil
+}
+
+// xmlElement is an element found in an XML document.
+type xmlElement struct {
+	start, end int64
+	attrs      []xml.Attr
+	text       string
+}
+
+func (e *xmlElement) attr(local string) string {
+	for _, a := range e.attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// findXMLElement returns the position of the first element with the given
+// namespace and name in data.
+func findXMLElement(data []byte, space, local string) (*xmlElement, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var e *xmlElement
+	depth := 0
+	for {
+		offset := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no %s element found", local)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if e == nil && t.Name.Space == space && t.Name.Local == local {
+				e = &xmlElement{start: offset, attrs: t.Attr}
+			}
+			if e != nil {
+				depth++
+			}
+		case xml.CharData:
+			if e != nil && depth == 1 {
+				e.text += string(t)
+			}
+		case xml.EndElement:
+			if e != nil {
+				depth--
+				if depth == 0 {
+					e.end = dec.InputOffset()
+					return e, nil
+				}
+			}
+		}
+	}
+}
+
+// removeSOAPHeader removes the header of a SOAP envelope.
+func removeSOAPHeader(envelope []byte) ([]byte, error) {
+	header, err := findXMLElement(envelope, soapNS, "Header")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(envelope[:header.start:header.start], envelope[header.end:]...), nil
+}
+
+// insertSOAPHeader adds a header with the given ECP relay state to a SOAP
+// envelope.
+func insertSOAPHeader(envelope []byte, relayState *xmlElement) ([]byte, error) {
+	body, err := findXMLElement(envelope, soapNS, "Body")
+	if err != nil {
+		return nil, err
+	}
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, `<S:Header xmlns:S="%s">`, soapNS)
+	fmt.Fprintf(&header, `<ecp:RelayState xmlns:ecp="%s" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1">`, ecpNS)
+	if err := xml.EscapeText(&header, []byte(relayState.text)); err != nil {
+		return nil, err
+	}
+	header.WriteString(`</ecp:RelayState></S:Header>`)
+
+	result := append([]byte{}, envelope[:body.start]...)
+	result = append(result, header.Bytes()...)
+
+	return append(result, envelope[body.start:]...), nil
+}
@@ -0,0 +1,175 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func testFederatedAuth(t *testing.T, srv *fakeopenstack.Server, raw map[string]interface{}) {
+	raw["delayed_auth"] = false
+	config := testFakeProviderConfig(t, srv, raw)
+
+	client, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	// The token is renewed through the identity provider.
+	srv.RevokeTokens()
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	// Each federated authentication rescopes an unscoped token.
+	var rescoped int
+	for _, r := range srv.Requests() {
+		if r.Path == "/identity/v3/auth/tokens" && strings.Contains(string(r.Body), `"methods":["token"]`) {
+			rescoped++
+		}
+	}
+	assert.Equal(t, 2, rescoped)
+}
+
+func TestConfigFederatedOIDCPassword(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	testFederatedAuth(t, srv, map[string]interface{}{
+		"auth_type":          "v3oidcpassword",
+		"identity_provider":  fakeopenstack.DefaultIdentityProvider,
+		"protocol":           "openid",
+		"client_id":          fakeopenstack.DefaultClientID,
+		"client_secret":      fakeopenstack.DefaultClientSecret,
+		"discovery_endpoint": srv.DiscoveryEndpoint(),
+	})
+}
+
+func TestConfigFederatedOIDCClientCredentials(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	testFederatedAuth(t, srv, map[string]interface{}{
+		"auth_type":             "v3oidcclientcredentials",
+		"identity_provider":     fakeopenstack.DefaultIdentityProvider,
+		"protocol":              "openid",
+		"client_id":             fakeopenstack.DefaultClientID,
+		"client_secret":         fakeopenstack.DefaultClientSecret,
+		"access_token_endpoint": srv.URL + "/idp/token",
+		"openid_scope":          "openid profile",
+	})
+}
+
+func TestConfigFederatedOIDCAccessToken(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	testFederatedAuth(t, srv, map[string]interface{}{
+		"auth_type":         "v3oidcaccesstoken",
+		"identity_provider": fakeopenstack.DefaultIdentityProvider,
+		"protocol":          "openid",
+		"access_token":      srv.AccessToken,
+	})
+}
+
+func TestConfigFederatedSAMLPassword(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	testFederatedAuth(t, srv, map[string]interface{}{
+		"auth_type":             "v3samlpassword",
+		"identity_provider":     fakeopenstack.DefaultIdentityProvider,
+		"protocol":              "saml2",
+		"identity_provider_url": srv.IdentityProviderURL(),
+	})
+}
+
+func TestConfigFederatedCloudsYAML(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "clouds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	clouds := fmt.Sprintf(`clouds:
+  federated:
+    auth_type: v3oidcpassword
+    region_name: %s
+    auth:
+      auth_url: %s
+      username: %s
+      password: %s
+      project_name: %s
+      project_domain_id: %s
+      identity_provider: %s
+      protocol: openid
+      client_id: %s
+      client_secret: %s
+      discovery_endpoint: %s
+`, srv.Region, srv.AuthURL(), srv.Username, srv.Password, srv.ProjectName, fakeopenstack.DefaultDomainID,
+		fakeopenstack.DefaultIdentityProvider, fakeopenstack.DefaultClientID,
+		fakeopenstack.DefaultClientSecret, srv.DiscoveryEndpoint())
+
+	path := filepath.Join(dir, "clouds.yaml")
+	if err := ioutil.WriteFile(path, []byte(clouds), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("OS_CLIENT_CONFIG_FILE", path)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+
+	testFederatedAuth(t, srv, map[string]interface{}{
+		"cloud":       "federated",
+		"auth_url":    "",
+		"user_name":   "",
+		"password":    "",
+		"tenant_name": "",
+	})
+}
+
+func TestConfigFederatedMissingArguments(t *testing.T) {
+	config := Config{AuthType: "v3oidcpassword", Protocol: "openid"}
+	config.Username = "admin"
+
+	err := config.validateFederation(&gophercloud.AuthOptions{TenantName: "admin"})
+	assert.EqualError(t, err, "Missing access_token_endpoint, client_id, identity_provider, password for auth_type v3oidcpassword")
+}
+
+func TestSOAPHeader(t *testing.T) {
+	envelope := []byte(`<S:Envelope xmlns:S="` + soapNS + `">` +
+		`<S:Header><ecp:RelayState xmlns:ecp="` + ecpNS + `">state &amp; more</ecp:RelayState></S:Header>` +
+		`<S:Body><Response/></S:Body></S:Envelope>`)
+
+	relayState, err := findXMLElement(envelope, ecpNS, "RelayState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "state & more", relayState.text)
+
+	removed, err := removeSOAPHeader(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `<S:Envelope xmlns:S="`+soapNS+`"><S:Body><Response/></S:Body></S:Envelope>`, string(removed))
+
+	inserted, err := insertSOAPHeader(removed, relayState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `<S:Envelope xmlns:S="`+soapNS+`">`+
+		`<S:Header xmlns:S="`+soapNS+`"><ecp:RelayState xmlns:ecp="`+ecpNS+`" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1">state &amp; more</ecp:RelayState></S:Header>`+
+		`<S:Body><Response/></S:Body></S:Envelope>`, string(inserted))
+}
@@ -12,6 +12,10 @@ import (
 	"github.com/terraform-providers/terraform-provider-openstack/internal/helper/hashcode"
 )
 
+// blockStorageV3VolumeOnlineExtendMicroversion is needed to extend volumes
+// which are attached to an instance.
+const blockStorageV3VolumeOnlineExtendMicroversion = "3.42"
+
 func flattenBlockStorageVolumeV3Attachments(v []volumes.Attachment) []map[string]interface{} {
 	attachments := make([]map[string]interface{}, len(v))
 	for i, attachment := range v {
@@ -0,0 +1,103 @@
+package openstack
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/aggregates"
+)
+
+// computeAggregateV2AvailabilityZoneKey is the metadata key under which Nova
+// stores the availability zone of an aggregate.
+const computeAggregateV2AvailabilityZoneKey = "availability_zone"
+
+// computeAggregateV2ID parses the ID of an aggregate, which Nova numbers.
+func computeAggregateV2ID(id string) (int, error) {
+	v, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid openstack_compute_aggregate_v2 ID %q: it must be an integer", id)
+	}
+
+	return v, nil
+}
+
+// computeAggregateV2Update updates the name and the availability zone of an
+// aggregate. An empty availability zone is left out of the update request,
+// so the aggregate is removed from its zone through its metadata.
+func computeAggregateV2Update(client *gophercloud.ServiceClient, id int, name, availabilityZone string) error {
+	updateOpts := aggregates.UpdateOpts{
+		Name:             name,
+		AvailabilityZone: availabilityZone,
+	}
+	if _, err := aggregates.Update(client, id, updateOpts).Extract(); err != nil {
+		return err
+	}
+
+	if availabilityZone == "" {
+		setMetadataOpts := aggregates.SetMetadataOpts{
+			Metadata: map[string]interface{}{computeAggregateV2AvailabilityZoneKey: nil},
+		}
+		if _, err := aggregates.SetMetadata(client, id, setMetadataOpts).Extract(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// computeAggregateV2UpdateHosts adds and removes hosts of an aggregate so
+// that its hosts match the given ones.
+func computeAggregateV2UpdateHosts(client *gophercloud.ServiceClient, id int, old, new []string) error {
+	for _, host := range old {
+		if strSliceContains(new, host) {
+			continue
+		}
+		if _, err := aggregates.RemoveHost(client, id, aggregates.RemoveHostOpts{Host: host}).Extract(); err != nil {
+			return err
+		}
+	}
+
+	for _, host := range new {
+		if strSliceContains(old, host) {
+			continue
+		}
+		if _, err := aggregates.AddHost(client, id, aggregates.AddHostOpts{Host: host}).Extract(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// computeAggregateV2MetadataChanges returns the metadata to set on an
+// aggregate to change it from old to new. Removed keys are set to nil.
+func computeAggregateV2MetadataChanges(old, new map[string]interface{}) map[string]interface{} {
+	changes := make(map[string]interface{})
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			changes[k] = nil
+		}
+	}
+	for k, v := range new {
+		if ov, ok := old[k]; !ok || ov != v {
+			changes[k] = v
+		}
+	}
+
+	return changes
+}
+
+// computeAggregateV2Metadata returns the metadata of an aggregate without the
+// availability zone, which is managed through its own argument.
+func computeAggregateV2Metadata(aggregate *aggregates.Aggregate) map[string]string {
+	metadata := make(map[string]string, len(aggregate.Metadata))
+	for k, v := range aggregate.Metadata {
+		if k == computeAggregateV2AvailabilityZoneKey {
+			continue
+		}
+		metadata[k] = v
+	}
+
+	return metadata
+}
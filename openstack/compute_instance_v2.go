@@ -10,15 +10,29 @@
 package openstack
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/lockunlock"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/pauseunpause"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/rescueunrescue"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/shelveunshelve"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/suspendresume"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/tenantnetworks"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -27,6 +41,10 @@ const (
 	computeV2InstanceCreateServerWithTagsMicroversion        = "2.52"
 	computeV2TagsExtensionMicroversion                       = "2.26"
 	computeV2InstanceBlockDeviceVolumeTypeMicroversion       = "2.67"
+	computeV2InstanceRebuildKeyPairMicroversion              = "2.54"
+	computeV2InstanceRebuildUserDataMicroversion             = "2.57"
+	computeV2InstanceLockedMicroversion                      = "2.9"
+	computeV2InstanceVolumeDeleteOnTerminationMicroversion   = "2.79"
 )
 
 // InstanceNIC is a structured representation of a Gophercloud servers.Server
@@ -75,77 +93,85 @@ type InstanceNetwork struct {
 // reference the actual port resource itself.
 //
 // So, let's begin the journey.
-func getAllInstanceNetworks(d *schema.ResourceData, meta interface{}) ([]InstanceNetwork, error) {
+func getAllInstanceNetworks(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]InstanceNetwork, error) {
 	var instanceNetworks []InstanceNetwork
 
 	networks := d.Get("network").([]interface{})
 	for _, v := range networks {
-		network := v.(map[string]interface{})
-		networkID := network["uuid"].(string)
-		networkName := network["name"].(string)
-		portID := network["port"].(string)
-
-		if networkID == "" && networkName == "" && portID == "" {
-			return nil, fmt.Errorf(
-				"At least one of network.uuid, network.name, or network.port must be set.")
-		}
-
-		// If a user specified both an ID and name, that makes things easy
-		// since both name and ID are already satisfied. No need to query
-		// further.
-		if networkID != "" && networkName != "" {
-			v := InstanceNetwork{
-				UUID:          networkID,
-				Name:          networkName,
-				Port:          portID,
-				FixedIP:       network["fixed_ip_v4"].(string),
-				AccessNetwork: network["access_network"].(bool),
-			}
-			instanceNetworks = append(instanceNetworks, v)
-			continue
-		}
-
-		// But if at least one of name or ID was missing, we have to query
-		// for that other piece.
-		//
-		// Priority is given to a port since a network ID or name usually isn't
-		// specified when using a port.
-		//
-		// Next priority is given to the network ID since it's guaranteed to be
-		// an exact match.
-		queryType := "name"
-		queryTerm := networkName
-		if networkID != "" {
-			queryType = "id"
-			queryTerm = networkID
-		}
-		if portID != "" {
-			queryType = "port"
-			queryTerm = portID
-		}
-
-		networkInfo, err := getInstanceNetworkInfo(d, meta, queryType, queryTerm)
+		v, err := getInstanceNetwork(ctx, d, meta, v.(map[string]interface{}))
 		if err != nil {
 			return nil, err
 		}
 
-		v := InstanceNetwork{
+		instanceNetworks = append(instanceNetworks, v)
+	}
+
+	log.Printf("[DEBUG] getAllInstanceNetworks: %#v", instanceNetworks)
+	return instanceNetworks, nil
+}
+
+// getInstanceNetwork resolves the network and port of a network block of the
+// instance.
+func getInstanceNetwork(ctx context.Context, d *schema.ResourceData, meta interface{}, network map[string]interface{}) (InstanceNetwork, error) {
+	networkID := network["uuid"].(string)
+	networkName := network["name"].(string)
+	portID := network["port"].(string)
+
+	if networkID == "" && networkName == "" && portID == "" {
+		return InstanceNetwork{}, fmt.Errorf(
+			"At least one of network.uuid, network.name, or network.port must be set.")
+	}
+
+	// If a user specified both an ID and name, that makes things easy
+	// since both name and ID are already satisfied. No need to query
+	// further.
+	if networkID != "" && networkName != "" {
+		return InstanceNetwork{
+			UUID:          networkID,
+			Name:          networkName,
 			Port:          portID,
 			FixedIP:       network["fixed_ip_v4"].(string),
 			AccessNetwork: network["access_network"].(bool),
-		}
-		if networkInfo["uuid"] != nil {
-			v.UUID = networkInfo["uuid"].(string)
-		}
-		if networkInfo["name"] != nil {
-			v.Name = networkInfo["name"].(string)
-		}
+		}, nil
+	}
 
-		instanceNetworks = append(instanceNetworks, v)
+	// But if at least one of name or ID was missing, we have to query
+	// for that other piece.
+	//
+	// Priority is given to a port since a network ID or name usually isn't
+	// specified when using a port.
+	//
+	// Next priority is given to the network ID since it's guaranteed to be
+	// an exact match.
+	queryType := "name"
+	queryTerm := networkName
+	if networkID != "" {
+		queryType = "id"
+		queryTerm = networkID
+	}
+	if portID != "" {
+		queryType = "port"
+		queryTerm = portID
 	}
 
-	log.Printf("[DEBUG] getAllInstanceNetworks: %#v", instanceNetworks)
-	return instanceNetworks, nil
+	networkInfo, err := getInstanceNetworkInfo(ctx, d, meta, queryType, queryTerm)
+	if err != nil {
+		return InstanceNetwork{}, err
+	}
+
+	v := InstanceNetwork{
+		Port:          portID,
+		FixedIP:       network["fixed_ip_v4"].(string),
+		AccessNetwork: network["access_network"].(bool),
+	}
+	if networkInfo["uuid"] != nil {
+		v.UUID = networkInfo["uuid"].(string)
+	}
+	if networkInfo["name"] != nil {
+		v.Name = networkInfo["name"].(string)
+	}
+
+	return v, nil
 }
 
 // getInstanceNetworkInfo will query for network information in order to make
@@ -156,15 +182,15 @@ func getAllInstanceNetworks(d *schema.ResourceData, meta interface{}) ([]Instanc
 //
 // If OS_NOVA_NETWORK is set, query nova-network even if Neutron is available.
 // This is to be able to explicitly test the nova-network API.
-func getInstanceNetworkInfo(
+func getInstanceNetworkInfo(ctx context.Context,
 	d *schema.ResourceData, meta interface{}, queryType, queryTerm string) (map[string]interface{}, error) {
 
 	config := meta.(*Config)
 
 	if _, ok := os.LookupEnv("OS_NOVA_NETWORK"); !ok {
-		networkClient, err := config.NetworkingV2Client(GetRegion(d, config))
+		networkClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 		if err == nil {
-			networkInfo, err := getInstanceNetworkInfoNeutron(networkClient, queryType, queryTerm)
+			networkInfo, err := config.instanceNetworkInfo(networkClient, queryType, queryTerm, getInstanceNetworkInfoNeutron)
 			if err != nil {
 				return nil, fmt.Errorf("Error trying to get network information from the Network API: %s", err)
 			}
@@ -175,12 +201,12 @@ func getInstanceNetworkInfo(
 
 	log.Printf("[DEBUG] Unable to obtain a network client")
 
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
 		return nil, fmt.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
-	networkInfo, err := getInstanceNetworkInfoNovaNet(computeClient, queryType, queryTerm)
+	networkInfo, err := config.instanceNetworkInfo(computeClient, queryType, queryTerm, getInstanceNetworkInfoNovaNet)
 	if err != nil {
 		return nil, fmt.Errorf("Error trying to get network information from the Nova API: %s", err)
 	}
@@ -410,11 +436,11 @@ func expandInstanceNetworks(allInstanceNetworks []InstanceNetwork) []servers.Net
 
 // flattenInstanceNetworks collects instance network information from different
 // sources and aggregates it all together into a map array.
-func flattenInstanceNetworks(
+func flattenInstanceNetworks(ctx context.Context,
 	d *schema.ResourceData, meta interface{}) ([]map[string]interface{}, error) {
 
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
 		return nil, fmt.Errorf("Error creating OpenStack compute client: %s", err)
 	}
@@ -425,7 +451,7 @@ func flattenInstanceNetworks(
 	}
 
 	allInstanceAddresses := getInstanceAddresses(server.Addresses)
-	allInstanceNetworks, err := getAllInstanceNetworks(d, meta)
+	allInstanceNetworks, err := getAllInstanceNetworks(ctx, d, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -448,7 +474,7 @@ func flattenInstanceNetworks(
 				}
 
 				// Use the same method as getAllInstanceNetworks to get the network uuid
-				networkInfo, err := getInstanceNetworkInfo(d, meta, "name", instanceAddresses.NetworkName)
+				networkInfo, err := getInstanceNetworkInfo(ctx, d, meta, "name", instanceAddresses.NetworkName)
 				if err != nil {
 					log.Printf("[WARN] Error getting default network uuid: %s", err)
 				} else {
@@ -540,10 +566,1242 @@ func computeV2InstanceReadTags(d *schema.ResourceData, tags []string) {
 	expandObjectReadTags(d, tags)
 }
 
-func computeV2InstanceUpdateTags(d *schema.ResourceData) []string {
-	return expandObjectUpdateTags(d)
+func computeV2InstanceUpdateTags(d *schema.ResourceData, defaultTags []string) []string {
+	return expandObjectUpdateTags(d, defaultTags)
+}
+
+// computeV2InstanceCreateMicroversions returns the microversions needed to
+// create an instance with the configured attributes.
+func computeV2InstanceCreateMicroversions(d resourceGetter) []microversionRequirement {
+	var required []microversionRequirement
+
+	if networkMode := d.Get("network_mode").(string); networkMode == "auto" || networkMode == "none" {
+		required = append(required, microversionRequirement{"network_mode " + networkMode, computeV2InstanceCreateServerWithNetworkModeMicroversion})
+	}
+
+	if len(expandTags(d.Get("tags"))) > 0 || len(expandTags(d.Get("all_tags"))) > 0 {
+		required = append(required, microversionRequirement{"tags", computeV2InstanceCreateServerWithTagsMicroversion})
+	}
+
+	for _, v := range d.Get("block_device").([]interface{}) {
+		if bd, ok := v.(map[string]interface{}); ok && bd["volume_type"] != "" && bd["volume_type"] != nil {
+			required = append(required, microversionRequirement{"block_device.volume_type", computeV2InstanceBlockDeviceVolumeTypeMicroversion})
+			break
+		}
+	}
+
+	return required
+}
+
+// computeV2InstanceDiffMicroversions returns the microversions needed to
+// apply a plan.
+func computeV2InstanceDiffMicroversions(diff *schema.ResourceDiff) []microversionRequirement {
+	if diff.Id() == "" {
+		return computeV2InstanceCreateMicroversions(diff)
+	}
+
+	var required []microversionRequirement
+	if diff.HasChange("tags") || diff.HasChange("all_tags") {
+		required = append(required, microversionRequirement{"tags", computeV2TagsExtensionMicroversion})
+	}
+
+	if computeV2InstanceRebuild(diff) {
+		required = append(required, computeV2InstanceRebuildMicroversions(diff)...)
+	}
+
+	if computeV2InstanceBlockDevicesInPlace(diff) {
+		required = append(required, computeV2InstanceBlockDeviceMicroversions(diff)...)
+	}
+
+	return required
+}
+
+func computeV2InstanceTags(d *schema.ResourceData, defaultTags []string) []string {
+	return expandObjectCreateTags(d, defaultTags)
+}
+
+// resourceChanges is implemented by schema.ResourceData and
+// schema.ResourceDiff.
+type resourceChanges interface {
+	resourceGetter
+	GetChange(string) (interface{}, interface{})
+	HasChange(string) bool
+}
+
+// computeV2InstanceRebuild returns true if the instance is rebuilt with a
+// new image instead of being replaced.
+func computeV2InstanceRebuild(d resourceChanges) bool {
+	return d.Get("rebuild_on_image_change").(bool) && (d.HasChange("image_id") || d.HasChange("image_name"))
+}
+
+// computeV2InstanceRebuildMicroversions returns the microversions needed to
+// change the key pair and the user data with a rebuild.
+func computeV2InstanceRebuildMicroversions(d resourceChanges) []microversionRequirement {
+	var required []microversionRequirement
+
+	if d.HasChange("key_pair") {
+		required = append(required, microversionRequirement{"key_pair", computeV2InstanceRebuildKeyPairMicroversion})
+	}
+
+	if d.HasChange("user_data") {
+		required = append(required, microversionRequirement{"user_data", computeV2InstanceRebuildUserDataMicroversion})
+	}
+
+	return required
+}
+
+// customizeDiffComputeInstanceV2Rebuild replaces the instance if its image,
+// user_data or key_pair change, unless rebuild_on_image_change is set and
+// the image changes. A rebuild keeps the server with its ports and volumes,
+// and also applies the new user_data and key_pair.
+func customizeDiffComputeInstanceV2Rebuild(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if computeV2InstanceReplaced(diff) {
+		for _, key := range computeV2InstanceRebuildAttributes {
+			if diff.HasChange(key) {
+				if err := diff.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if diff.Id() == "" || !computeV2InstanceRebuild(diff) {
+		return nil
+	}
+
+	// The image is resolved again by the other of its attributes.
+	if diff.HasChange("image_name") && !diff.HasChange("image_id") {
+		return diff.SetNewComputed("image_id")
+	}
+	if diff.HasChange("image_id") && !diff.HasChange("image_name") {
+		return diff.SetNewComputed("image_name")
+	}
+
+	return nil
+}
+
+// computeV2InstanceRebuildAttributes force a new instance unless it is
+// rebuilt.
+var computeV2InstanceRebuildAttributes = []string{"image_id", "image_name", "user_data", "key_pair"}
+
+// computeV2InstanceReplaced returns true if a change of the image,
+// user_data, key_pair or block devices replaces the instance.
+func computeV2InstanceReplaced(diff *schema.ResourceDiff) bool {
+	if diff.Id() == "" {
+		return false
+	}
+
+	if !computeV2InstanceBlockDevicesInPlace(diff) {
+		return true
+	}
+
+	if computeV2InstanceRebuild(diff) {
+		return false
+	}
+
+	for _, key := range computeV2InstanceRebuildAttributes {
+		if diff.HasChange(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ComputeV2InstanceRebuildOpts adds the key pair and the user data, which
+// need the microversions 2.54 and 2.57, to the rebuild of a server. A nil
+// value keeps the key pair or user data of the server, an empty one removes
+// it.
+type ComputeV2InstanceRebuildOpts struct {
+	servers.RebuildOpts
+	KeyName  *string
+	UserData *string
+}
+
+// ToServerRebuildMap casts a RebuildOpts struct to a map.
+// It overrides servers.ToServerRebuildMap to add the KeyName and UserData
+// fields.
+func (opts ComputeV2InstanceRebuildOpts) ToServerRebuildMap() (map[string]interface{}, error) {
+	b, err := opts.RebuildOpts.ToServerRebuildMap()
+	if err != nil {
+		return nil, err
+	}
+	rebuild := b["rebuild"].(map[string]interface{})
+
+	if opts.KeyName != nil {
+		rebuild["key_name"] = nil
+		if *opts.KeyName != "" {
+			rebuild["key_name"] = *opts.KeyName
+		}
+	}
+
+	if opts.UserData != nil {
+		rebuild["user_data"] = nil
+		if userData := *opts.UserData; userData != "" {
+			if _, err := base64.StdEncoding.DecodeString(userData); err != nil {
+				userData = base64.StdEncoding.EncodeToString([]byte(userData))
+			}
+			rebuild["user_data"] = userData
+		}
+	}
+
+	return b, nil
+}
+
+// computeV2InstanceRebuildOpts returns the options to rebuild the instance
+// with the image imageID.
+func computeV2InstanceRebuildOpts(d *schema.ResourceData, imageID string) ComputeV2InstanceRebuildOpts {
+	opts := ComputeV2InstanceRebuildOpts{
+		RebuildOpts: servers.RebuildOpts{
+			ImageRef:  imageID,
+			Name:      d.Get("name").(string),
+			AdminPass: d.Get("admin_pass").(string),
+			Metadata:  resourceInstanceMetadataV2(d),
+		},
+	}
+
+	if d.HasChange("key_pair") {
+		keyName := d.Get("key_pair").(string)
+		opts.KeyName = &keyName
+	}
+
+	if d.HasChange("user_data") {
+		userData := d.Get("user_data").(string)
+		opts.UserData = &userData
+	}
+
+	return opts
+}
+
+// computeV2InstancePowerStateAction is a server action changing the
+// power_state of an instance, with the statuses the instance ends up in.
+type computeV2InstancePowerStateAction struct {
+	action string
+	target []string
+}
+
+// computeV2InstanceLeavePowerState are the actions bringing an instance back
+// to the active power_state.
+var computeV2InstanceLeavePowerState = map[string]computeV2InstancePowerStateAction{
+	"shutoff":           {"os-start", []string{"ACTIVE"}},
+	"paused":            {"unpause", []string{"ACTIVE"}},
+	"suspended":         {"resume", []string{"ACTIVE"}},
+	"shelved":           {"unshelve", []string{"ACTIVE"}},
+	"shelved_offloaded": {"unshelve", []string{"ACTIVE"}},
+	"rescued":           {"unrescue", []string{"ACTIVE"}},
+}
+
+// computeV2InstanceEnterPowerState are the actions bringing an active
+// instance to another power_state. Nova offloads shelved instances right
+// away if shelved_offload_time is 0.
+var computeV2InstanceEnterPowerState = map[string]computeV2InstancePowerStateAction{
+	"shutoff":   {"os-stop", []string{"SHUTOFF"}},
+	"paused":    {"pause", []string{"PAUSED"}},
+	"suspended": {"suspend", []string{"SUSPENDED"}},
+	"shelved":   {"shelve", []string{"SHELVED", "SHELVED_OFFLOADED"}},
+	"rescued":   {"rescue", []string{"RESCUE"}},
+}
+
+var computeV2InstanceShelveOffload = computeV2InstancePowerStateAction{"shelveOffload", []string{"SHELVED_OFFLOADED"}}
+
+// computeV2InstancePowerStates maps the statuses of Nova servers to the
+// power_state of instances.
+var computeV2InstancePowerStates = map[string]string{
+	"ACTIVE":            "active",
+	"SHUTOFF":           "shutoff",
+	"PAUSED":            "paused",
+	"SUSPENDED":         "suspended",
+	"SHELVED":           "shelved",
+	"SHELVED_OFFLOADED": "shelved_offloaded",
+	"RESCUE":            "rescued",
+	"ERROR":             "error",
+	"MIGRATING":         "migrating",
+}
+
+// computeV2InstancePowerStateActions returns the actions changing the
+// power_state of an instance from one state to another. The instance is
+// made active first, unless Nova allows the action from the current state.
+func computeV2InstancePowerStateActions(from, to string) []computeV2InstancePowerStateAction {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	if from == to {
+		return nil
+	}
+
+	if from == "shelved" && to == "shelved_offloaded" {
+		return []computeV2InstancePowerStateAction{computeV2InstanceShelveOffload}
+	}
+
+	var actions []computeV2InstancePowerStateAction
+	shelve := to == "shelved" || to == "shelved_offloaded"
+	switch {
+	case from == "shutoff" && to == "rescued":
+	case shelve && (from == "shutoff" || from == "paused" || from == "suspended"):
+	default:
+		if leave, ok := computeV2InstanceLeavePowerState[from]; ok {
+			actions = append(actions, leave)
+		}
+	}
+
+	if to == "shelved_offloaded" {
+		return append(actions, computeV2InstanceEnterPowerState["shelved"], computeV2InstanceShelveOffload)
+	}
+	if enter, ok := computeV2InstanceEnterPowerState[to]; ok {
+		actions = append(actions, enter)
+	}
+
+	return actions
+}
+
+// computeV2InstanceSetPowerState changes the power_state of the instance
+// from one state to another and waits for each action to complete.
+func computeV2InstanceSetPowerState(ctx context.Context, client *gophercloud.ServiceClient, d *schema.ResourceData, from, to string, timeout time.Duration) error {
+	status := ""
+	for _, a := range computeV2InstancePowerStateActions(from, to) {
+		if a.action == computeV2InstanceShelveOffload.action && status == "SHELVED_OFFLOADED" {
+			continue
+		}
+
+		if err := computeV2InstanceAction(client, d, a.action); err != nil {
+			return fmt.Errorf("Error running the %s action on OpenStack instance %s: %s", a.action, d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Target:     a.target,
+			Refresh:    ServerV2StateRefreshFunc(client, d.Id()),
+			Timeout:    timeout,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		log.Printf("[DEBUG] Waiting for instance (%s) to complete the %s action", d.Id(), a.action)
+		server, err := stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return fmt.Errorf("Error waiting for instance (%s) to complete the %s action: %s", d.Id(), a.action, err)
+		}
+		status = server.(*servers.Server).Status
+	}
+
+	return nil
+}
+
+// computeV2InstanceServerAttributes are the attributes whose change is
+// applied to the server, besides the attributes rebuilding it.
+var computeV2InstanceServerAttributes = []string{
+	"name", "network", "block_device", "power_state", "metadata", "security_groups",
+	"admin_pass", "flavor_id", "flavor_name", "tags", "all_tags",
+}
+
+// computeV2InstanceUpdateChangesServer returns whether the update of the
+// instance changes the server, other than locking or unlocking it.
+func computeV2InstanceUpdateChangesServer(d *schema.ResourceData) bool {
+	return d.HasChanges(computeV2InstanceServerAttributes...) || computeV2InstanceRebuild(d)
+}
+
+// computeV2InstanceAction runs a server action on the instance.
+func computeV2InstanceAction(client *gophercloud.ServiceClient, d *schema.ResourceData, action string) error {
+	switch action {
+	case "os-start":
+		return startstop.Start(client, d.Id()).ExtractErr()
+	case "os-stop":
+		return startstop.Stop(client, d.Id()).ExtractErr()
+	case "pause":
+		return pauseunpause.Pause(client, d.Id()).ExtractErr()
+	case "unpause":
+		return pauseunpause.Unpause(client, d.Id()).ExtractErr()
+	case "suspend":
+		return suspendresume.Suspend(client, d.Id()).ExtractErr()
+	case "resume":
+		return suspendresume.Resume(client, d.Id()).ExtractErr()
+	case "rescue":
+		return rescueunrescue.Rescue(client, d.Id(), rescueunrescue.RescueOpts{}).Err
+	case "unrescue":
+		return rescueunrescue.Unrescue(client, d.Id()).ExtractErr()
+	case "lock":
+		return lockunlock.Lock(client, d.Id()).ExtractErr()
+	case "unlock":
+		return lockunlock.Unlock(client, d.Id()).ExtractErr()
+	case "shelve":
+		return shelveunshelve.Shelve(client, d.Id()).ExtractErr()
+	case "shelveOffload":
+		return shelveunshelve.ShelveOffload(client, d.Id()).ExtractErr()
+	case "unshelve":
+		unshelveOpts := &shelveunshelve.UnshelveOpts{
+			AvailabilityZone: d.Get("availability_zone").(string),
+		}
+		return shelveunshelve.Unshelve(client, d.Id(), unshelveOpts).ExtractErr()
+	}
+
+	return fmt.Errorf("Unknown server action %s", action)
+}
+
+// computeV2InstanceNetworkFields are the attributes of a network block, except
+// access_network.
+var computeV2InstanceNetworkFields = []string{"uuid", "name", "port", "fixed_ip_v4", "fixed_ip_v6", "floating_ip", "mac"}
+
+// computeV2InstanceNetworkMatches returns whether the interface of the
+// network block old satisfies the network block n. The port decides, then
+// the network ID or name and the fixed IPv4 address if they are set.
+func computeV2InstanceNetworkMatches(old, n map[string]interface{}) bool {
+	if port := n["port"].(string); port != "" {
+		return old["port"] == port
+	}
+
+	if uuid := n["uuid"].(string); uuid != "" {
+		if old["uuid"] != uuid {
+			return false
+		}
+	} else if name := n["name"].(string); name != "" {
+		if old["name"] != name {
+			return false
+		}
+	} else {
+		return false
+	}
+
+	if ip := n["fixed_ip_v4"].(string); ip != "" && old["fixed_ip_v4"] != ip {
+		return false
+	}
+
+	return true
+}
+
+// computeV2InstanceMatchNetworks returns the index of the old network block
+// kept for each new network block, or -1 if the new block needs a new
+// interface. A block at the same index is preferred.
+func computeV2InstanceMatchNetworks(oldNetworks, newNetworks []interface{}) []int {
+	matches := make([]int, len(newNetworks))
+	used := make(map[int]bool)
+
+	for i, n := range newNetworks {
+		matches[i] = -1
+		if i < len(oldNetworks) && computeV2InstanceNetworkMatches(oldNetworks[i].(map[string]interface{}), n.(map[string]interface{})) {
+			matches[i] = i
+			used[i] = true
+		}
+	}
+
+	for i, n := range newNetworks {
+		if matches[i] >= 0 {
+			continue
+		}
+		for j, old := range oldNetworks {
+			if !used[j] && computeV2InstanceNetworkMatches(old.(map[string]interface{}), n.(map[string]interface{})) {
+				matches[i] = j
+				used[j] = true
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// computeV2InstanceNetworksDiff returns the planned network blocks. The
+// computed attributes of a block not set in the configuration keep the value
+// of the block at the same index in the state, which belongs to another
+// interface if the networks were reordered or removed. Those values are
+// cleared and replaced with the values of the kept interfaces.
+func computeV2InstanceNetworksDiff(oldNetworks, newNetworks []interface{}) ([]interface{}, bool) {
+	var changed bool
+
+	networks := make([]interface{}, len(newNetworks))
+	for i, raw := range newNetworks {
+		n := make(map[string]interface{})
+		for k, v := range raw.(map[string]interface{}) {
+			n[k] = v
+		}
+		networks[i] = n
+
+		if i >= len(oldNetworks) {
+			continue
+		}
+		old := oldNetworks[i].(map[string]interface{})
+
+		var stale []string
+		switch {
+		case n["port"] != "" && n["port"] != old["port"]:
+			stale = []string{"uuid", "name", "fixed_ip_v4"}
+		case n["uuid"] != "" && old["uuid"] != "" && n["uuid"] != old["uuid"]:
+			stale = []string{"name", "port", "fixed_ip_v4"}
+		case n["name"] != "" && n["name"] != old["name"]:
+			stale = []string{"uuid", "port", "fixed_ip_v4"}
+		default:
+			continue
+		}
+		stale = append(stale, "fixed_ip_v6", "floating_ip", "mac")
+
+		for _, k := range stale {
+			if n[k] != "" && n[k] == old[k] {
+				n[k] = ""
+				changed = true
+			}
+		}
+	}
+
+	for i, j := range computeV2InstanceMatchNetworks(oldNetworks, networks) {
+		if j < 0 {
+			continue
+		}
+		n, old := networks[i].(map[string]interface{}), oldNetworks[j].(map[string]interface{})
+		for _, k := range computeV2InstanceNetworkFields {
+			if n[k] == "" && old[k] != "" {
+				n[k] = old[k]
+				changed = true
+			}
+		}
+	}
+
+	return networks, changed
+}
+
+// customizeDiffComputeInstanceV2Networks plans the network blocks of an
+// existing instance, which are attached and detached in place. The access
+// IPs are read from the networks, so they are only known after the update.
+func customizeDiffComputeInstanceV2Networks(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("network") {
+		return nil
+	}
+
+	for _, k := range []string{"access_ip_v4", "access_ip_v6"} {
+		if err := diff.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	oldNetworks, newNetworks := diff.GetChange("network")
+	for i := range newNetworks.([]interface{}) {
+		for _, k := range computeV2InstanceNetworkFields {
+			if !diff.NewValueKnown(fmt.Sprintf("network.%d.%s", i, k)) {
+				return nil
+			}
+		}
+	}
+
+	networks, changed := computeV2InstanceNetworksDiff(oldNetworks.([]interface{}), newNetworks.([]interface{}))
+	if !changed {
+		return nil
+	}
+
+	return diff.SetNew("network", networks)
+}
+
+// computeV2InstanceNetworkPort returns the port ID of the interface of a
+// network block in the state, or "" if it is not found. The port of the
+// block decides, then its fixed IPs and MAC address, then whether it is the
+// only interface on its network which is not claimed by another block.
+func computeV2InstanceNetworkPort(network map[string]interface{}, interfaces []attachinterfaces.Interface, claimed map[string]bool) string {
+	if port := network["port"].(string); port != "" {
+		for _, iface := range interfaces {
+			if iface.PortID == port {
+				return port
+			}
+		}
+		return ""
+	}
+
+	var candidates []string
+	for _, iface := range interfaces {
+		if claimed[iface.PortID] || (network["uuid"] != "" && iface.NetID != network["uuid"]) {
+			continue
+		}
+		if mac := network["mac"].(string); mac != "" && iface.MACAddr == mac {
+			return iface.PortID
+		}
+		for _, ip := range iface.FixedIPs {
+			if ip.IPAddress != "" && (ip.IPAddress == network["fixed_ip_v4"] || ip.IPAddress == network["fixed_ip_v6"]) {
+				return iface.PortID
+			}
+		}
+		candidates = append(candidates, iface.PortID)
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	return ""
 }
 
-func computeV2InstanceTags(d *schema.ResourceData) []string {
-	return expandObjectTags(d)
+// computeV2InstanceDetachPorts returns the ports of the interfaces of the old
+// network blocks which are not kept. The interfaces of the kept blocks are
+// claimed first, so that they are never detached.
+func computeV2InstanceDetachPorts(oldNetworks []interface{}, kept map[int]bool, interfaces []attachinterfaces.Interface) ([]string, error) {
+	claimed := make(map[string]bool)
+	for j, raw := range oldNetworks {
+		if kept[j] {
+			if port := computeV2InstanceNetworkPort(raw.(map[string]interface{}), interfaces, claimed); port != "" {
+				claimed[port] = true
+			}
+		}
+	}
+
+	var detach []string
+	for j, raw := range oldNetworks {
+		if kept[j] {
+			continue
+		}
+
+		network := raw.(map[string]interface{})
+		port := computeV2InstanceNetworkPort(network, interfaces, claimed)
+		if port == "" {
+			return nil, fmt.Errorf("Unable to find the interface of network %s with fixed IP %s", network["uuid"], network["fixed_ip_v4"])
+		}
+		claimed[port] = true
+		detach = append(detach, port)
+	}
+
+	return detach, nil
+}
+
+// computeV2InstanceUpdateNetworks detaches the interfaces of the removed
+// network blocks and attaches interfaces for the added ones. The other
+// interfaces keep their ports and IPs.
+func computeV2InstanceUpdateNetworks(ctx context.Context, client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) error {
+	o, n := d.GetChange("network")
+	oldNetworks, newNetworks := o.([]interface{}), n.([]interface{})
+	matches := computeV2InstanceMatchNetworks(oldNetworks, newNetworks)
+
+	kept := make(map[int]bool)
+	for _, j := range matches {
+		if j >= 0 {
+			kept[j] = true
+		}
+	}
+
+	var detach []string
+	if len(kept) < len(oldNetworks) {
+		allPages, err := attachinterfaces.List(client, d.Id()).AllPages()
+		if err != nil {
+			return fmt.Errorf("Error listing the interfaces of instance %s: %s", d.Id(), err)
+		}
+		interfaces, err := attachinterfaces.ExtractInterfaces(allPages)
+		if err != nil {
+			return fmt.Errorf("Error extracting the interfaces of instance %s: %s", d.Id(), err)
+		}
+
+		detach, err = computeV2InstanceDetachPorts(oldNetworks, kept, interfaces)
+		if err != nil {
+			return fmt.Errorf("Error detaching interfaces from instance %s: %s", d.Id(), err)
+		}
+	}
+
+	for _, portID := range detach {
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{""},
+			Target:     []string{"DETACHED"},
+			Refresh:    computeInterfaceAttachV2DetachFunc(client, d.Id(), portID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      5 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+
+		log.Printf("[DEBUG] Detaching interface %s from instance %s", portID, d.Id())
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("Error detaching interface %s from instance %s: %s", portID, d.Id(), err)
+		}
+	}
+
+	for i, raw := range newNetworks {
+		if matches[i] >= 0 {
+			continue
+		}
+
+		network, err := getInstanceNetwork(ctx, d, meta, raw.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+
+		attachOpts := attachinterfaces.CreateOpts{
+			PortID: network.Port,
+		}
+		if network.Port == "" {
+			attachOpts.NetworkID = network.UUID
+		}
+		if network.FixedIP != "" {
+			attachOpts.FixedIPs = []attachinterfaces.FixedIP{{IPAddress: network.FixedIP}}
+		}
+
+		log.Printf("[DEBUG] Attaching interface to instance %s: %#v", d.Id(), attachOpts)
+		attachment, err := attachinterfaces.Create(client, d.Id(), attachOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error attaching an interface of network %s to instance %s: %s", network.Name, d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"ATTACHING"},
+			Target:     []string{"ATTACHED"},
+			Refresh:    computeInterfaceAttachV2AttachFunc(client, d.Id(), attachment.PortID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      5 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("Error waiting for interface %s to attach to instance %s: %s", attachment.PortID, d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+// computeV2InstanceBlockDeviceFields are the configured attributes of a
+// block_device.
+var computeV2InstanceBlockDeviceFields = []string{
+	"source_type", "uuid", "volume_size", "destination_type", "boot_index",
+	"delete_on_termination", "guest_format", "volume_type", "device_type", "disk_bus",
+}
+
+func computeV2InstanceBlockDeviceEqual(a, b map[string]interface{}) bool {
+	for _, k := range computeV2InstanceBlockDeviceFields {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// computeV2InstanceBlockDeviceHotPlug returns whether the volume of a
+// block_device can be attached to and detached from a running instance,
+// without the device type and bus only known at boot.
+func computeV2InstanceBlockDeviceHotPlug(bd map[string]interface{}) bool {
+	return bd["destination_type"] == "volume" && bd["device_type"] == "" && bd["disk_bus"] == ""
+}
+
+// resourceConfigChanges reads the changes of a resource and whether its
+// attributes are set.
+type resourceConfigChanges interface {
+	resourceChanges
+	GetOkExists(string) (interface{}, bool)
+}
+
+// computeV2InstanceBlockDeviceBootIndex returns the boot index of a
+// configured block_device, or -1 if it is not set.
+func computeV2InstanceBlockDeviceBootIndex(d resourceConfigChanges, i int) int {
+	if v, ok := d.GetOkExists(fmt.Sprintf("block_device.%d.boot_index", i)); ok {
+		return v.(int)
+	}
+
+	return -1
+}
+
+// computeV2InstanceBlockDeviceDiff holds the block devices removed from and
+// added to an instance.
+type computeV2InstanceBlockDeviceDiff struct {
+	newBlockDevices []interface{}
+
+	// kept maps the index of a new block device to the old one it keeps.
+	kept    map[int]map[string]interface{}
+	removed []map[string]interface{}
+	added   []int
+
+	// inPlace is whether the removed volumes are detached and the added ones
+	// attached without replacing the instance.
+	inPlace bool
+
+	// ambiguous explains the changes which could be meant to be applied in
+	// place, but are not known well enough to do so.
+	ambiguous []string
+}
+
+// computeV2InstanceBlockDeviceChanges compares the block devices of an
+// instance. Only whole block devices are added and removed in place: a
+// removed and an added block device with the same source are a changed one,
+// which replaces the instance. A volume is only detached by the ID recorded
+// for its block device, and only attached if it is not the boot device.
+//
+// The boot index of a block device added in front of existing ones is read
+// from the state of the block device previously at its index if it is not
+// configured, so an added block device with boot index 0 next to a kept boot
+// device is ambiguous, as is a removed volume whose ID is not recorded.
+func computeV2InstanceBlockDeviceChanges(d resourceConfigChanges) computeV2InstanceBlockDeviceDiff {
+	o, n := d.GetChange("block_device")
+	oldBlockDevices := o.([]interface{})
+	result := computeV2InstanceBlockDeviceDiff{
+		newBlockDevices: n.([]interface{}),
+		kept:            make(map[int]map[string]interface{}),
+		inPlace:         true,
+	}
+
+	kept := make(map[int]bool)
+	for i, raw := range result.newBlockDevices {
+		bd := raw.(map[string]interface{})
+		found := false
+		for j, old := range oldBlockDevices {
+			if !kept[j] && computeV2InstanceBlockDeviceEqual(old.(map[string]interface{}), bd) {
+				kept[j], found = true, true
+				result.kept[i] = old.(map[string]interface{})
+				break
+			}
+		}
+		if !found {
+			result.added = append(result.added, i)
+			if !computeV2InstanceBlockDeviceHotPlug(bd) || computeV2InstanceBlockDeviceBootIndex(d, i) == 0 {
+				result.inPlace = false
+			}
+		}
+	}
+
+	keptBootDevice := false
+	for _, old := range result.kept {
+		if old["boot_index"] == 0 {
+			keptBootDevice = true
+		}
+	}
+	for _, i := range result.added {
+		bd := result.newBlockDevices[i].(map[string]interface{})
+		if keptBootDevice && i < len(oldBlockDevices) && computeV2InstanceBlockDeviceHotPlug(bd) &&
+			computeV2InstanceBlockDeviceBootIndex(d, i) == 0 {
+			result.ambiguous = append(result.ambiguous, fmt.Sprintf(
+				"block_device.%d is added in front of the existing block devices with boot index 0 or no boot index, "+
+					"so it can't be told apart from the boot device: set its boot_index to -1 to attach its volume", i))
+		}
+	}
+
+	for j, raw := range oldBlockDevices {
+		if kept[j] {
+			continue
+		}
+		old := raw.(map[string]interface{})
+		result.removed = append(result.removed, old)
+		if !computeV2InstanceBlockDeviceHotPlug(old) || old["volume_id"] == "" {
+			result.inPlace = false
+		}
+		if computeV2InstanceBlockDeviceHotPlug(old) && old["boot_index"] != 0 && old["volume_id"] == "" {
+			result.ambiguous = append(result.ambiguous, fmt.Sprintf(
+				"the volume of the removed block_device.%d is not known, so it can't be detached: "+
+					"refresh the instance to look it up, or replace the instance", j))
+		}
+		for _, i := range result.added {
+			bd := result.newBlockDevices[i].(map[string]interface{})
+			if bd["source_type"] == old["source_type"] && bd["uuid"] == old["uuid"] {
+				result.inPlace = false
+			}
+		}
+	}
+
+	return result
+}
+
+// computeV2InstanceBlockDevicesInPlace returns whether the changes of the
+// block devices of an instance are applied in place.
+func computeV2InstanceBlockDevicesInPlace(diff *schema.ResourceDiff) bool {
+	if !diff.HasChange("block_device") {
+		return true
+	}
+
+	changes := computeV2InstanceBlockDeviceChanges(diff)
+	if !changes.inPlace {
+		return false
+	}
+
+	// A source which is not known yet may be the one of a removed block
+	// device.
+	if len(changes.removed) > 0 {
+		for _, i := range changes.added {
+			if !diff.NewValueKnown(fmt.Sprintf("block_device.%d.uuid", i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// customizeDiffComputeInstanceV2BlockDevices replaces the instance if its
+// block devices change, unless only volumes which are not the boot device
+// are added or removed. Ambiguous changes fail the plan instead of silently
+// replacing the instance.
+func customizeDiffComputeInstanceV2BlockDevices(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("block_device") {
+		return nil
+	}
+
+	if changes := computeV2InstanceBlockDeviceChanges(diff); len(changes.ambiguous) > 0 {
+		return fmt.Errorf("Unable to plan the block devices of instance %s: %s", diff.Id(), strings.Join(changes.ambiguous, "; "))
+	}
+
+	if computeV2InstanceBlockDevicesInPlace(diff) {
+		return nil
+	}
+
+	// Forcing the list itself only replaces the instance if its length
+	// changes.
+	for _, key := range diff.GetChangedKeysPrefix("block_device") {
+		if diff.HasChange(key) {
+			if err := diff.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// computeV2InstanceBlockDeviceMicroversions returns the microversions needed
+// to attach the added block devices.
+func computeV2InstanceBlockDeviceMicroversions(d resourceConfigChanges) []microversionRequirement {
+	if !d.HasChange("block_device") {
+		return nil
+	}
+
+	changes := computeV2InstanceBlockDeviceChanges(d)
+	for _, i := range changes.added {
+		if changes.newBlockDevices[i].(map[string]interface{})["delete_on_termination"].(bool) {
+			return []microversionRequirement{{"block_device.delete_on_termination", computeV2InstanceVolumeDeleteOnTerminationMicroversion}}
+		}
+	}
+
+	return nil
+}
+
+// ComputeV2InstanceVolumeAttachOpts adds delete_on_termination, which needs
+// microversion 2.79, to a volume attachment.
+type ComputeV2InstanceVolumeAttachOpts struct {
+	volumeattach.CreateOpts
+	DeleteOnTermination bool
+}
+
+// ToVolumeAttachmentCreateMap casts a ComputeV2InstanceVolumeAttachOpts into
+// a map.
+func (opts ComputeV2InstanceVolumeAttachOpts) ToVolumeAttachmentCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOpts.ToVolumeAttachmentCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DeleteOnTermination {
+		b["volumeAttachment"].(map[string]interface{})["delete_on_termination"] = true
+	}
+
+	return b, nil
+}
+
+// computeV2InstanceBlockDeviceVolumeMatches returns whether the volume may
+// have been created for the block device. Only the ID of source volumes is
+// known, the other volumes are identified by their source, size and type.
+func computeV2InstanceBlockDeviceVolumeMatches(v *volumes.Volume, bd map[string]interface{}) bool {
+	uuid := bd["uuid"].(string)
+	switch bd["source_type"] {
+	case "volume":
+		return v.ID == uuid
+	case "image":
+		if v.VolumeImageMetadata["image_id"] != uuid {
+			return false
+		}
+	case "snapshot":
+		if v.SnapshotID != uuid {
+			return false
+		}
+	case "blank":
+		if len(v.VolumeImageMetadata) > 0 || v.SnapshotID != "" || v.SourceVolID != "" {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if size := bd["volume_size"].(int); size > 0 && v.Size != size {
+		return false
+	}
+	if volumeType := bd["volume_type"].(string); volumeType != "" && v.VolumeType != volumeType {
+		return false
+	}
+
+	return true
+}
+
+// computeV2InstanceBlockDeviceVolumeIDs returns the IDs of the volumes
+// attached for the block devices which are not the boot device. Recorded IDs
+// are kept. A volume created for a block device is only identified if no
+// other attached volume matches the block device and no other block device
+// matches the volume; the ID of the others is left empty.
+func computeV2InstanceBlockDeviceVolumeIDs(computeClient, blockStorageClient *gophercloud.ServiceClient, id string, blockDevices []interface{}) ([]string, error) {
+	allPages, err := volumeattach.List(computeClient, id).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the volume attachments of instance %s: %s", id, err)
+	}
+	attachments, err := volumeattach.ExtractVolumeAttachments(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting the volume attachments of instance %s: %s", id, err)
+	}
+
+	sources := make(map[string]bool)
+	for _, raw := range blockDevices {
+		bd := raw.(map[string]interface{})
+		if bd["source_type"] == "volume" {
+			sources[bd["uuid"].(string)] = true
+		}
+		if volumeID, _ := bd["volume_id"].(string); volumeID != "" {
+			sources[volumeID] = true
+		}
+	}
+
+	var created []*volumes.Volume
+	for _, attachment := range attachments {
+		if sources[attachment.VolumeID] {
+			continue
+		}
+		v, err := volumes.Get(blockStorageClient, attachment.VolumeID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving OpenStack volume %s: %s", attachment.VolumeID, err)
+		}
+		created = append(created, v)
+	}
+
+	candidates := make([][]int, len(blockDevices))
+	matches := make([]int, len(created))
+	for i, raw := range blockDevices {
+		bd := raw.(map[string]interface{})
+		if volumeID, _ := bd["volume_id"].(string); volumeID != "" {
+			continue
+		}
+		if bd["destination_type"] != "volume" || bd["source_type"] == "volume" {
+			continue
+		}
+		for j, v := range created {
+			if computeV2InstanceBlockDeviceVolumeMatches(v, bd) {
+				candidates[i] = append(candidates[i], j)
+				matches[j]++
+			}
+		}
+	}
+
+	volumeIDs := make([]string, len(blockDevices))
+	for i, raw := range blockDevices {
+		bd := raw.(map[string]interface{})
+		if volumeID, _ := bd["volume_id"].(string); volumeID != "" {
+			volumeIDs[i] = volumeID
+			continue
+		}
+		if bd["destination_type"] != "volume" || bd["boot_index"] == 0 {
+			continue
+		}
+		if bd["source_type"] == "volume" {
+			volumeIDs[i] = bd["uuid"].(string)
+		} else if len(candidates[i]) == 1 && matches[candidates[i][0]] == 1 {
+			volumeIDs[i] = created[candidates[i][0]].ID
+		}
+	}
+
+	return volumeIDs, nil
+}
+
+// computeV2InstanceBlockDeviceVolumeIDsMissing returns whether the volume of
+// a block device which is not the boot device is not recorded, e.g. because
+// the instance was created by an earlier version of the provider.
+func computeV2InstanceBlockDeviceVolumeIDsMissing(blockDevices []interface{}) bool {
+	for _, raw := range blockDevices {
+		bd := raw.(map[string]interface{})
+		if bd["destination_type"] == "volume" && bd["boot_index"] != 0 && bd["volume_id"] == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// computeV2InstanceSetBlockDeviceVolumeIDs records the IDs of the volumes
+// attached for the block devices of an instance which are not recorded yet.
+func computeV2InstanceSetBlockDeviceVolumeIDs(ctx context.Context, computeClient *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	blockDevices := d.Get("block_device").([]interface{})
+	volumeIDs, err := computeV2InstanceBlockDeviceVolumeIDs(computeClient, blockStorageClient, d.Id(), blockDevices)
+	if err != nil {
+		return err
+	}
+
+	for i, raw := range blockDevices {
+		raw.(map[string]interface{})["volume_id"] = volumeIDs[i]
+	}
+
+	return d.Set("block_device", blockDevices)
+}
+
+// computeV2InstanceUpdateBlockDevices detaches the volumes of the removed
+// block devices and attaches volumes for the added ones, and records their
+// IDs. Volumes created for a removed block device are deleted with it if
+// delete_on_termination is set.
+func computeV2InstanceUpdateBlockDevices(ctx context.Context, computeClient *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	changes := computeV2InstanceBlockDeviceChanges(d)
+	if !changes.inPlace {
+		return fmt.Errorf("The block devices of instance %s can't be changed in place", d.Id())
+	}
+
+	// The block devices record the volumes attached so far if the update
+	// fails.
+	o, _ := d.GetChange("block_device")
+	var current []interface{}
+	for _, raw := range o.([]interface{}) {
+		current = append(current, raw)
+	}
+	remove := func(bd map[string]interface{}) {
+		for i, raw := range current {
+			if raw.(map[string]interface{})["volume_id"] == bd["volume_id"] {
+				current = append(current[:i], current[i+1:]...)
+				return
+			}
+		}
+	}
+
+	for _, bd := range changes.removed {
+		volumeID := bd["volume_id"].(string)
+		if err := computeV2InstanceDetachVolume(ctx, computeClient, blockStorageClient, d, volumeID); err != nil {
+			d.Set("block_device", current)
+			return err
+		}
+		remove(bd)
+
+		if bd["delete_on_termination"].(bool) && bd["source_type"] != "volume" {
+			log.Printf("[DEBUG] Deleting OpenStack volume %s of instance %s", volumeID, d.Id())
+			if err := volumes.Delete(blockStorageClient, volumeID, nil).ExtractErr(); err != nil {
+				d.Set("block_device", current)
+				return fmt.Errorf("Error deleting OpenStack volume %s: %s", volumeID, err)
+			}
+		}
+	}
+
+	blockDevices := make([]interface{}, len(changes.newBlockDevices))
+	for i, raw := range changes.newBlockDevices {
+		bd := make(map[string]interface{})
+		for k, v := range raw.(map[string]interface{}) {
+			bd[k] = v
+		}
+		bd["volume_id"] = ""
+		if old, ok := changes.kept[i]; ok {
+			bd["volume_id"] = old["volume_id"]
+		}
+		blockDevices[i] = bd
+	}
+
+	for _, i := range changes.added {
+		bd := blockDevices[i].(map[string]interface{})
+		volumeID, err := computeV2InstanceAttachBlockDevice(ctx, computeClient, blockStorageClient, d, bd)
+		if err != nil {
+			d.Set("block_device", current)
+			return err
+		}
+		bd["volume_id"] = volumeID
+		current = append(current, bd)
+	}
+
+	return d.Set("block_device", blockDevices)
+}
+
+// computeV2InstanceDetachVolume detaches a volume from the instance and waits
+// for it to become available.
+func computeV2InstanceDetachVolume(ctx context.Context, computeClient, blockStorageClient *gophercloud.ServiceClient, d *schema.ResourceData, volumeID string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{""},
+		Target:     []string{"DETACHED"},
+		Refresh:    computeVolumeAttachV2DetachFunc(computeClient, d.Id(), volumeID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Detaching OpenStack volume %s from instance %s", volumeID, d.Id())
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("Error detaching OpenStack volume %s from instance %s: %s", volumeID, d.Id(), err)
+	}
+
+	stateConf = &resource.StateChangeConf{
+		Pending:    []string{"in-use", "detaching"},
+		Target:     []string{"available"},
+		Refresh:    blockStorageVolumeV3StateRefreshFunc(blockStorageClient, volumeID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("Error waiting for OpenStack volume %s to become available: %s", volumeID, err)
+	}
+
+	return nil
+}
+
+// computeV2InstanceAttachBlockDevice attaches the volume of a block device
+// to the instance, creating it unless its source is a volume, and returns
+// its ID.
+func computeV2InstanceAttachBlockDevice(ctx context.Context, computeClient, blockStorageClient *gophercloud.ServiceClient, d *schema.ResourceData, bd map[string]interface{}) (string, error) {
+	volumeID := bd["uuid"].(string)
+	created := bd["source_type"] != "volume"
+	if created {
+		createOpts := volumes.CreateOpts{
+			Size:       bd["volume_size"].(int),
+			VolumeType: bd["volume_type"].(string),
+		}
+		switch bd["source_type"] {
+		case "image":
+			createOpts.ImageID = volumeID
+		case "snapshot":
+			createOpts.SnapshotID = volumeID
+		}
+
+		log.Printf("[DEBUG] Creating OpenStack volume for instance %s: %#v", d.Id(), createOpts)
+		v, err := volumes.Create(blockStorageClient, createOpts).Extract()
+		if err != nil {
+			return "", fmt.Errorf("Error creating OpenStack volume for instance %s: %s", d.Id(), err)
+		}
+		volumeID = v.ID
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"downloading", "creating"},
+			Target:     []string{"available"},
+			Refresh:    blockStorageVolumeV3StateRefreshFunc(blockStorageClient, volumeID),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return "", fmt.Errorf("Error waiting for OpenStack volume %s to become ready: %s", volumeID, err)
+		}
+	}
+
+	attachOpts := ComputeV2InstanceVolumeAttachOpts{
+		CreateOpts: volumeattach.CreateOpts{
+			VolumeID: volumeID,
+		},
+		DeleteOnTermination: bd["delete_on_termination"].(bool),
+	}
+
+	log.Printf("[DEBUG] Attaching OpenStack volume %s to instance %s", volumeID, d.Id())
+	attachment, err := volumeattach.Create(computeClient, d.Id(), attachOpts).Extract()
+	if err != nil {
+		if created {
+			if err := volumes.Delete(blockStorageClient, volumeID, nil).ExtractErr(); err != nil {
+				log.Printf("[WARN] Error deleting OpenStack volume %s: %s", volumeID, err)
+			}
+		}
+		return "", fmt.Errorf("Error attaching OpenStack volume %s to instance %s: %s", volumeID, d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ATTACHING"},
+		Target:     []string{"ATTACHED"},
+		Refresh:    computeVolumeAttachV2AttachFunc(computeClient, d.Id(), attachment.ID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return "", fmt.Errorf("Error waiting for OpenStack volume %s to attach to instance %s: %s", volumeID, d.Id(), err)
+	}
+
+	return volumeID, nil
 }
@@ -0,0 +1,441 @@
+package openstack
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func testComputeInstanceV2State() *terraform.InstanceState {
+	return &terraform.InstanceState{
+		ID: "server-1",
+		Attributes: map[string]string{
+			"id":                      "server-1",
+			"name":                    "instance_1",
+			"region":                  "RegionOne",
+			"image_id":                "image-1",
+			"image_name":              "cirros",
+			"flavor_id":               "2",
+			"flavor_name":             fakeopenstack.FlavorName,
+			"key_pair":                "key-1",
+			"network_mode":            "none",
+			"network.#":               "0",
+			"power_state":             "active",
+			"rebuild_on_image_change": "false",
+			"stop_before_destroy":     "false",
+			"force_delete":            "false",
+		},
+	}
+}
+
+func TestCustomizeDiffComputeInstanceV2Rebuild(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+	srv.ComputeMicroversion = "2.53"
+
+	instance := resourceComputeInstanceV2()
+	raw := map[string]interface{}{
+		"name":         "instance_1",
+		"image_name":   "cirros-new",
+		"flavor_id":    "2",
+		"key_pair":     "key-1",
+		"network_mode": "none",
+	}
+
+	// A new image replaces the instance by default.
+	diff, err := instance.Diff(context.Background(), testComputeInstanceV2State(), terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	raw["rebuild_on_image_change"] = true
+	diff, err = instance.Diff(context.Background(), testComputeInstanceV2State(), terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+		assert.True(t, diff.Attributes["image_id"].NewComputed)
+	}
+
+	// Without a new image, a new key pair still replaces the instance.
+	raw["image_name"] = "cirros"
+	raw["key_pair"] = "key-2"
+	diff, err = instance.Diff(context.Background(), testComputeInstanceV2State(), terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// A rebuild with a new key pair needs microversion 2.54.
+	raw["image_name"] = "cirros-new"
+	_, err = instance.Diff(context.Background(), testComputeInstanceV2State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "key_pair requires compute API microversion 2.54, but the cloud supports microversions 2.1 to 2.53")
+}
+
+func TestComputeV2InstanceRebuildOpts(t *testing.T) {
+	keyName, userData := "", "#cloud-config"
+	opts := ComputeV2InstanceRebuildOpts{
+		RebuildOpts: servers.RebuildOpts{
+			ImageRef: "image-2",
+			Name:     "instance_1",
+		},
+		KeyName:  &keyName,
+		UserData: &userData,
+	}
+
+	b, err := opts.ToServerRebuildMap()
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"rebuild": map[string]interface{}{
+				"imageRef":  "image-2",
+				"name":      "instance_1",
+				"key_name":  nil,
+				"user_data": base64.StdEncoding.EncodeToString([]byte(userData)),
+			},
+		}, b)
+	}
+
+	// The key pair and user data of the server are kept by default.
+	opts.KeyName, opts.UserData = nil, nil
+	b, err = opts.ToServerRebuildMap()
+	if assert.NoError(t, err) {
+		assert.NotContains(t, b["rebuild"], "key_name")
+		assert.NotContains(t, b["rebuild"], "user_data")
+	}
+}
+
+func TestComputeV2InstancePowerStateActions(t *testing.T) {
+	actions := func(from, to string) []string {
+		var names []string
+		for _, a := range computeV2InstancePowerStateActions(from, to) {
+			names = append(names, a.action)
+		}
+		return names
+	}
+
+	assert.Empty(t, actions("active", "active"))
+	assert.Empty(t, actions("shutoff", "SHUTOFF"))
+	assert.Equal(t, []string{"os-stop"}, actions("active", "shutoff"))
+	assert.Equal(t, []string{"os-stop"}, actions("error", "shutoff"))
+	assert.Equal(t, []string{"os-start"}, actions("shutoff", "active"))
+	assert.Equal(t, []string{"unshelve"}, actions("shelved_offloaded", "active"))
+	assert.Equal(t, []string{"unpause", "suspend"}, actions("paused", "suspended"))
+	assert.Equal(t, []string{"unrescue", "os-stop"}, actions("rescued", "shutoff"))
+	assert.Equal(t, []string{"unshelve", "shelve"}, actions("shelved_offloaded", "shelved"))
+
+	// Nova allows some actions without making the instance active first.
+	assert.Equal(t, []string{"rescue"}, actions("shutoff", "rescued"))
+	assert.Equal(t, []string{"shelve"}, actions("paused", "shelved"))
+	assert.Equal(t, []string{"shelve", "shelveOffload"}, actions("suspended", "shelved_offloaded"))
+	assert.Equal(t, []string{"shelveOffload"}, actions("shelved", "shelved_offloaded"))
+}
+
+func TestCustomizeDiffComputeInstanceV2Networks(t *testing.T) {
+	_, config := testFakeProvider(t)
+
+	state := testComputeInstanceV2State()
+	delete(state.Attributes, "network_mode")
+	for k, v := range map[string]string{
+		"network.#":                "2",
+		"network.0.uuid":           "network-a",
+		"network.0.name":           "network_a",
+		"network.0.port":           "",
+		"network.0.fixed_ip_v4":    "192.168.1.10",
+		"network.0.fixed_ip_v6":    "",
+		"network.0.floating_ip":    "",
+		"network.0.mac":            "fa:16:3e:00:00:0a",
+		"network.0.access_network": "false",
+		"network.1.uuid":           "network-b",
+		"network.1.name":           "network_b",
+		"network.1.port":           "",
+		"network.1.fixed_ip_v4":    "192.168.2.10",
+		"network.1.fixed_ip_v6":    "",
+		"network.1.floating_ip":    "",
+		"network.1.mac":            "fa:16:3e:00:00:0b",
+		"network.1.access_network": "false",
+	} {
+		state.Attributes[k] = v
+	}
+
+	instance := resourceComputeInstanceV2()
+	raw := map[string]interface{}{
+		"name":       "instance_1",
+		"image_name": "cirros",
+		"flavor_id":  "2",
+		"key_pair":   "key-1",
+		"network": []interface{}{
+			map[string]interface{}{"name": "network_b"},
+		},
+	}
+
+	// The first network is removed, the second keeps its interface.
+	diff, err := instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+		assert.Equal(t, "1", diff.Attributes["network.#"].New)
+		assert.Equal(t, "network-b", diff.Attributes["network.0.uuid"].New)
+		assert.Equal(t, "192.168.2.10", diff.Attributes["network.0.fixed_ip_v4"].New)
+		assert.Equal(t, "fa:16:3e:00:00:0b", diff.Attributes["network.0.mac"].New)
+		assert.True(t, diff.Attributes["access_ip_v4"].NewComputed)
+		assert.True(t, diff.Attributes["access_ip_v6"].NewComputed)
+	}
+
+	// A new network in front of the others only adds an interface.
+	raw["network"] = []interface{}{
+		map[string]interface{}{"name": "network_c"},
+		map[string]interface{}{"name": "network_a"},
+		map[string]interface{}{"name": "network_b"},
+	}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+		assert.Equal(t, "", diff.Attributes["network.0.uuid"].New)
+		assert.Equal(t, "", diff.Attributes["network.0.mac"].New)
+		assert.Equal(t, "network-a", diff.Attributes["network.1.uuid"].New)
+		assert.Equal(t, "fa:16:3e:00:00:0b", diff.Attributes["network.2.mac"].New)
+	}
+}
+
+func TestComputeV2InstanceMatchNetworks(t *testing.T) {
+	network := func(uuid, name, port, fixedIP string) interface{} {
+		return map[string]interface{}{"uuid": uuid, "name": name, "port": port, "fixed_ip_v4": fixedIP}
+	}
+	oldNetworks := []interface{}{
+		network("network-a", "network_a", "", "192.168.1.10"),
+		network("network-b", "network_b", "port-b", "192.168.2.10"),
+		network("network-a", "network_a", "", "192.168.1.11"),
+	}
+
+	assert.Equal(t, []int{2, -1, 1}, computeV2InstanceMatchNetworks(oldNetworks, []interface{}{
+		network("", "network_a", "", "192.168.1.11"),
+		network("network-a", "", "", "192.168.1.12"),
+		network("", "", "port-b", ""),
+	}))
+	assert.Equal(t, []int{0, -1, 2}, computeV2InstanceMatchNetworks(oldNetworks, []interface{}{
+		network("", "network_a", "", ""),
+		network("", "network_a", "", ""),
+		network("", "network_a", "", ""),
+	}))
+}
+
+func TestComputeV2InstanceDetachPorts(t *testing.T) {
+	network := func(uuid, port, fixedIP, mac string) interface{} {
+		return map[string]interface{}{"uuid": uuid, "port": port, "fixed_ip_v4": fixedIP, "fixed_ip_v6": "", "mac": mac}
+	}
+	iface := func(netID, portID, fixedIP, mac string) attachinterfaces.Interface {
+		return attachinterfaces.Interface{
+			NetID:    netID,
+			PortID:   portID,
+			FixedIPs: []attachinterfaces.FixedIP{{IPAddress: fixedIP}},
+			MACAddr:  mac,
+		}
+	}
+	interfaces := []attachinterfaces.Interface{
+		iface("network-a", "port-1", "192.168.1.10", "fa:16:3e:00:00:01"),
+		iface("network-a", "port-2", "192.168.1.11", "fa:16:3e:00:00:02"),
+		iface("network-b", "port-3", "192.168.2.10", "fa:16:3e:00:00:03"),
+	}
+
+	// Blocks without MAC address are found by their port or fixed IP, or
+	// as the only remaining interface on their network.
+	oldNetworks := []interface{}{
+		network("network-a", "", "192.168.1.10", ""),
+		network("network-a", "", "", ""),
+		network("network-b", "port-3", "", ""),
+	}
+	ports, err := computeV2InstanceDetachPorts(oldNetworks, map[int]bool{0: true}, interfaces)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"port-2", "port-3"}, ports)
+
+	ports, err = computeV2InstanceDetachPorts(oldNetworks, map[int]bool{1: true, 2: true}, interfaces)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"port-1"}, ports)
+
+	// An interface on a network with several candidates is not guessed.
+	_, err = computeV2InstanceDetachPorts(oldNetworks[1:2], nil, interfaces)
+	assert.Error(t, err)
+
+	ports, err = computeV2InstanceDetachPorts([]interface{}{network("network-a", "", "", "fa:16:3e:00:00:02")}, nil, interfaces)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"port-2"}, ports)
+}
+
+func TestCustomizeDiffComputeInstanceV2BlockDevices(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+	srv.ComputeMicroversion = "2.60"
+
+	blockDevice := func(sourceType, uuid string, size, bootIndex int, destinationType string) map[string]interface{} {
+		return map[string]interface{}{
+			"source_type":      sourceType,
+			"uuid":             uuid,
+			"volume_size":      size,
+			"boot_index":       bootIndex,
+			"destination_type": destinationType,
+		}
+	}
+
+	state := testComputeInstanceV2State()
+	for k, v := range map[string]string{
+		"block_device.#":                       "2",
+		"block_device.0.source_type":           "image",
+		"block_device.0.uuid":                  "image-1",
+		"block_device.0.volume_size":           "0",
+		"block_device.0.boot_index":            "0",
+		"block_device.0.destination_type":      "local",
+		"block_device.0.delete_on_termination": "true",
+		"block_device.1.source_type":           "blank",
+		"block_device.1.uuid":                  "",
+		"block_device.1.volume_size":           "1",
+		"block_device.1.boot_index":            "1",
+		"block_device.1.destination_type":      "volume",
+		"block_device.1.delete_on_termination": "false",
+		"block_device.1.volume_id":             "volume-2",
+	} {
+		state.Attributes[k] = v
+	}
+
+	instance := resourceComputeInstanceV2()
+	boot := blockDevice("image", "image-1", 0, 0, "local")
+	boot["delete_on_termination"] = true
+	raw := map[string]interface{}{
+		"name":         "instance_1",
+		"image_name":   "cirros",
+		"flavor_id":    "2",
+		"key_pair":     "key-1",
+		"network_mode": "none",
+		"block_device": []interface{}{
+			boot,
+			blockDevice("blank", "", 1, 1, "volume"),
+			blockDevice("volume", "volume-1", 0, -1, "volume"),
+		},
+	}
+
+	// A data volume is attached in place.
+	diff, err := instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+		assert.Equal(t, "3", diff.Attributes["block_device.#"].New)
+	}
+
+	// And detached in place.
+	raw["block_device"] = []interface{}{boot}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+	}
+
+	// So is a data volume without a boot index.
+	dataVolume := blockDevice("volume", "volume-1", 0, 0, "volume")
+	delete(dataVolume, "boot_index")
+	raw["block_device"] = []interface{}{boot, blockDevice("blank", "", 1, 1, "volume"), dataVolume}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+	}
+
+	// But not a second boot device.
+	raw["block_device"] = []interface{}{boot, blockDevice("blank", "", 1, 1, "volume"), blockDevice("volume", "volume-1", 0, 0, "volume")}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// Changing a block device replaces the instance instead of recreating
+	// its volume.
+	changed := blockDevice("blank", "", 1, 1, "volume")
+	changed["delete_on_termination"] = true
+	raw["block_device"] = []interface{}{boot, changed}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// A volume is only detached by its recorded ID, a removed volume which
+	// is not recorded fails the plan.
+	unknown := state.DeepCopy()
+	unknown.Attributes["block_device.1.volume_id"] = ""
+	raw["block_device"] = []interface{}{boot}
+	_, err = instance.Diff(context.Background(), unknown, terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "Unable to plan the block devices of instance server-1: "+
+		"the volume of the removed block_device.1 is not known, so it can't be detached: "+
+		"refresh the instance to look it up, or replace the instance")
+
+	// A data volume added in front of the boot device reads the boot index
+	// of the boot device unless it sets its own, which fails the plan.
+	dataVolume = blockDevice("volume", "volume-1", 0, 0, "volume")
+	delete(dataVolume, "boot_index")
+	raw["block_device"] = []interface{}{dataVolume, boot, blockDevice("blank", "", 1, 1, "volume")}
+	_, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "Unable to plan the block devices of instance server-1: "+
+		"block_device.0 is added in front of the existing block devices with boot index 0 or no boot index, "+
+		"so it can't be told apart from the boot device: set its boot_index to -1 to attach its volume")
+
+	raw["block_device"] = []interface{}{blockDevice("volume", "volume-1", 0, -1, "volume"), boot, blockDevice("blank", "", 1, 1, "volume")}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+	}
+
+	// A device type is only known at boot.
+	dataVolume = blockDevice("blank", "", 2, 2, "volume")
+	dataVolume["device_type"] = "disk"
+	raw["block_device"] = []interface{}{boot, blockDevice("blank", "", 1, 1, "volume"), dataVolume}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// Changing the boot device replaces the instance.
+	raw["block_device"] = []interface{}{blockDevice("image", "image-2", 0, 0, "local"), blockDevice("blank", "", 1, 1, "volume")}
+	diff, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// Deleting an attached volume with the instance needs microversion 2.79.
+	dataVolume = blockDevice("blank", "", 2, 2, "volume")
+	dataVolume["delete_on_termination"] = true
+	raw["block_device"] = []interface{}{boot, blockDevice("blank", "", 1, 1, "volume"), dataVolume}
+	_, err = instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "block_device.delete_on_termination requires compute API microversion 2.79, but the cloud supports microversions 2.1 to 2.60")
+}
+
+func TestComputeV2InstanceBlockDeviceVolumeMatches(t *testing.T) {
+	blank := map[string]interface{}{"source_type": "blank", "uuid": "", "volume_size": 2, "volume_type": ""}
+	image := map[string]interface{}{"source_type": "image", "uuid": "image-1", "volume_size": 2, "volume_type": "ssd"}
+	volume := map[string]interface{}{"source_type": "volume", "uuid": "volume-1", "volume_size": 0, "volume_type": ""}
+
+	blankVolume := &volumes.Volume{ID: "volume-2", Size: 2}
+	imageVolume := &volumes.Volume{ID: "volume-3", Size: 2, VolumeType: "ssd", VolumeImageMetadata: map[string]string{"image_id": "image-1"}}
+
+	assert.True(t, computeV2InstanceBlockDeviceVolumeMatches(blankVolume, blank))
+	assert.False(t, computeV2InstanceBlockDeviceVolumeMatches(imageVolume, blank))
+	assert.True(t, computeV2InstanceBlockDeviceVolumeMatches(imageVolume, image))
+	assert.False(t, computeV2InstanceBlockDeviceVolumeMatches(&volumes.Volume{ID: "volume-4", Size: 2, VolumeImageMetadata: map[string]string{"image_id": "image-1"}}, image))
+	assert.True(t, computeV2InstanceBlockDeviceVolumeMatches(&volumes.Volume{ID: "volume-1", Size: 8}, volume))
+	assert.False(t, computeV2InstanceBlockDeviceVolumeMatches(blankVolume, volume))
+}
+
+func TestComputeV2InstanceVolumeAttachOpts(t *testing.T) {
+	opts := ComputeV2InstanceVolumeAttachOpts{
+		CreateOpts:          volumeattach.CreateOpts{VolumeID: "volume-1"},
+		DeleteOnTermination: true,
+	}
+
+	b, err := opts.ToVolumeAttachmentCreateMap()
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"volumeAttachment": map[string]interface{}{
+				"volumeId":              "volume-1",
+				"delete_on_termination": true,
+			},
+		}, b)
+	}
+}
@@ -89,7 +89,7 @@ func expandComputeSecGroupV2Rule(d *schema.ResourceData, rawRule interface{}) se
 	}
 }
 
-func flattenComputeSecGroupV2Rules(computeClient *gophercloud.ServiceClient, d *schema.ResourceData, sgrs []secgroups.Rule) ([]map[string]interface{}, error) {
+func flattenComputeSecGroupV2Rules(config *Config, computeClient *gophercloud.ServiceClient, d *schema.ResourceData, sgrs []secgroups.Rule) ([]map[string]interface{}, error) {
 	sgrMap := make([]map[string]interface{}, len(sgrs))
 	for i, sgr := range sgrs {
 		groupId := ""
@@ -102,11 +102,7 @@ func flattenComputeSecGroupV2Rules(computeClient *gophercloud.ServiceClient, d *
 				// we need to look up all security groups and match the name.
 				// Nevermind that Nova wants the ID when setting the Group *and* that multiple groups
 				// with the same name can exist...
-				allPages, err := secgroups.List(computeClient).AllPages()
-				if err != nil {
-					return nil, err
-				}
-				securityGroups, err := secgroups.ExtractSecurityGroups(allPages)
+				securityGroups, err := config.computeSecGroups(computeClient)
 				if err != nil {
 					return nil, err
 				}
@@ -9,8 +9,29 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const computeV2VolumeAttachMultiattachMicroversion = "2.60"
+
+// computeVolumeAttachV2Microversions returns the microversions needed to
+// create an attachment with the configured attributes.
+func computeVolumeAttachV2Microversions(d resourceGetter) []microversionRequirement {
+	if d.Get("multiattach").(bool) {
+		return []microversionRequirement{{"multiattach", computeV2VolumeAttachMultiattachMicroversion}}
+	}
+
+	return nil
+}
+
+func computeVolumeAttachV2DiffMicroversions(diff *schema.ResourceDiff) []microversionRequirement {
+	if diff.Id() == "" || diff.HasChange("multiattach") {
+		return computeVolumeAttachV2Microversions(diff)
+	}
+
+	return nil
+}
+
 func computeVolumeAttachV2ParseID(id string) (string, string, error) {
 	parts := strings.Split(id, "/")
 	if len(parts) < 2 {
@@ -0,0 +1,314 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/terraform/auth"
+)
+
+// Use openstackbase.Config as the base/foundation of this provider's
+// Config struct.
+type Config struct {
+	auth.Config
+
+	// RequestLimit applies to all API requests, ServiceRequestLimits to
+	// the requests sent to a single service.
+	RequestLimit         RequestLimit
+	ServiceRequestLimits map[string]RequestLimit
+
+	// RetryPolicy applies to all API requests.
+	RetryPolicy RetryPolicy
+
+	// APILog configures the structured log of API requests.
+	APILog APILog
+
+	// DefaultTags are merged into the tags of all resources supporting
+	// tags, see mergeDefaultTags.
+	DefaultTags []string
+
+	// TokenCache enables the on-disk cache of Keystone tokens in
+	// TokenCacheDir. The cache is encrypted if TokenCacheKey is set.
+	TokenCache    bool
+	TokenCacheDir string
+	TokenCacheKey string
+
+	// Passcode is a TOTP passcode sent along with the password. If
+	// TOTPSecret is set, a passcode is generated for each authentication
+	// instead.
+	Passcode   string
+	TOTPSecret string
+
+	// SystemScope enables system-scoped service clients for the resources
+	// which request them, see withSystemScope.
+	SystemScope bool
+
+	// AuthType selects federated authentication at IdentityProvider with
+	// Protocol if it is one of the v3oidc* or v3samlpassword types. The
+	// other settings are used by the federated types which need them.
+	AuthType            string
+	IdentityProvider    string
+	Protocol            string
+	ClientID            string
+	ClientSecret        string
+	DiscoveryEndpoint   string
+	AccessTokenEndpoint string
+	AccessToken         string
+	OpenIDScope         string
+	IdentityProviderURL string
+
+	// AuthCommand requests the credentials from an external program, see
+	// createCommandToken.
+	AuthCommand AuthCommand
+
+	// Proxy selects the proxies of all HTTP requests of the provider.
+	Proxy ProxyConfig
+
+	// QuotaPreflight checks the quotas of the project while planning, see
+	// customizeDiffQuotas.
+	QuotaPreflight string
+
+	authOpts      *gophercloud.AuthOptions
+	proxy         func(*http.Request) (*url.URL, error)
+	tokenCache    *tokenCache
+	delayedAuth   bool
+	authMutex     sync.Mutex
+	authenticated bool
+	authFailed    error
+
+	systemConfig        *auth.Config
+	systemAuthOpts      *gophercloud.AuthOptions
+	systemAuthenticated bool
+	systemAuthFailed    error
+
+	microversionMutex sync.Mutex
+	microversions     map[string]microversionRange
+
+	lookups   lookupCache
+	quotaPlan quotaPlan
+}
+
+// LoadAndValidate configures the base Config and installs the proxies, the
+// API log, the client-side request limits, the retry policy and the
+// recording of failed requests for diagnostics in the HTTP transport shared
+// by all service clients.
+//
+// The provider authenticates the clients itself, see authenticate. The base
+// Config is only used to set up the HTTP client, its own authentication is
+// disabled.
+func (c *Config) LoadAndValidate() error {
+	c.delayedAuth = c.DelayedAuth
+	c.DelayedAuth = true
+	if err := c.Config.LoadAndValidate(); err != nil {
+		return err
+	}
+	c.DelayedAuth = false
+
+	if c.Cloud != "" {
+		if err := c.loadCloudFederation(); err != nil {
+			return err
+		}
+		if err := c.loadCloudProxy(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.setProxy(); err != nil {
+		return err
+	}
+
+	transport, err := newAPILogTransport(c.OsClient.HTTPClient.Transport, c.APILog)
+	if err != nil {
+		return err
+	}
+	transport = newLimitTransport(transport, c.RequestLimit, c.ServiceRequestLimits)
+	transport = newRetryTransport(transport, c.RetryPolicy)
+	c.OsClient.HTTPClient.Transport = newAPIErrorTransport(transport)
+
+	ao, err := c.authOptions()
+	if err != nil {
+		return err
+	}
+	c.authOpts = ao
+
+	if c.authCommand() {
+		if err := c.validateAuthCommand(ao); err != nil {
+			return err
+		}
+	} else if c.federated() {
+		if err := c.validateFederation(ao); err != nil {
+			return err
+		}
+	} else if c.multiFactor() {
+		if err := c.validateMultiFactor(ao); err != nil {
+			return err
+		}
+	}
+
+	if c.TokenCache {
+		cache, err := newTokenCache(c.TokenCacheDir, c.TokenCacheKey)
+		if err != nil {
+			return err
+		}
+		c.tokenCache = cache
+	}
+
+	if c.SystemScope && !c.Swauth {
+		c.systemConfig, c.systemAuthOpts, err = c.newSystemScopeConfig(ao)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.delayedAuth {
+		if err := c.authenticate(); err != nil {
+			return err
+		}
+		if c.systemConfig != nil {
+			return c.authenticateSystemScope()
+		}
+	}
+
+	return nil
+}
+
+// The following methods wrap the service client getters of the base Config.
+// The returned clients bind every request to the given context, so that a
+// cancelled Terraform operation aborts in-flight requests.
+
+func (c *Config) BlockStorageV1Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceBlockStorage, (*auth.Config).BlockStorageV1Client, region)
+}
+
+func (c *Config) BlockStorageV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceBlockStorage, (*auth.Config).BlockStorageV2Client, region)
+}
+
+func (c *Config) BlockStorageV3Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceBlockStorage, (*auth.Config).BlockStorageV3Client, region)
+}
+
+func (c *Config) ComputeV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceCompute, (*auth.Config).ComputeV2Client, region)
+}
+
+func (c *Config) DNSV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceDNS, (*auth.Config).DNSV2Client, region)
+}
+
+func (c *Config) IdentityV3Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceIdentity, (*auth.Config).IdentityV3Client, region)
+}
+
+func (c *Config) ImageV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceImage, (*auth.Config).ImageV2Client, region)
+}
+
+func (c *Config) NetworkingV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceNetwork, (*auth.Config).NetworkingV2Client, region)
+}
+
+func (c *Config) ObjectStorageV1Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceObjectStore, (*auth.Config).ObjectStorageV1Client, region)
+}
+
+func (c *Config) OrchestrationV1Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceOrchestration, (*auth.Config).OrchestrationV1Client, region)
+}
+
+func (c *Config) LoadBalancerV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, c.loadBalancerService(), (*auth.Config).LoadBalancerV2Client, region)
+}
+
+func (c *Config) DatabaseV1Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceDatabase, (*auth.Config).DatabaseV1Client, region)
+}
+
+func (c *Config) ContainerInfraV1Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceContainerInfra, (*auth.Config).ContainerInfraV1Client, region)
+}
+
+func (c *Config) SharedfilesystemV2Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceSharedFileSystem, (*auth.Config).SharedfilesystemV2Client, region)
+}
+
+func (c *Config) KeyManagerV1Client(ctx context.Context, region string) (*gophercloud.ServiceClient, error) {
+	return c.contextClient(ctx, serviceKeyManager, (*auth.Config).KeyManagerV1Client, region)
+}
+
+// loadBalancerService returns the service which handles load balancer
+// requests.
+func (c *Config) loadBalancerService() string {
+	if c.UseOctavia {
+		return serviceLoadBalancer
+	}
+
+	return serviceNetwork
+}
+
+// contextClient authenticates the provider if needed, creates a service
+// client and binds it to ctx. The service is recorded in ctx to select its
+// request limits.
+func (c *Config) contextClient(ctx context.Context, service string, newClient func(*auth.Config, string) (*gophercloud.ServiceClient, error), region string) (*gophercloud.ServiceClient, error) {
+	base := &c.Config
+	if c.systemConfig != nil && systemScopeFromContext(ctx) {
+		if err := c.authenticateSystemScope(); err != nil {
+			return nil, err
+		}
+		base = c.systemConfig
+	} else if err := c.authenticate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClient(base, region)
+	if err != nil {
+		return client, err
+	}
+
+	if ctx != nil {
+		ctx = withService(ctx, service)
+	}
+
+	return withContext(ctx, client), nil
+}
+
+// withContext returns a copy of a service client whose requests use ctx.
+//
+// The copy shares the token lock of the original provider client. When a
+// request of the copy triggers a re-authentication, the original provider
+// client is re-authenticated and its new token is copied over, so that the
+// token stays shared between all clients.
+func withContext(ctx context.Context, client *gophercloud.ServiceClient) *gophercloud.ServiceClient {
+	if ctx == nil || client.ProviderClient == nil {
+		return client
+	}
+
+	parent := client.ProviderClient
+	pc := *parent
+	pc.Context = ctx
+
+	if parent.ReauthFunc != nil {
+		pc.ReauthFunc = func() error {
+			// Another request may have re-authenticated already.
+			if token := parent.Token(); token != "" && token != pc.Token() {
+				pc.CopyTokenFrom(parent)
+				return nil
+			}
+
+			if err := parent.ReauthFunc(); err != nil {
+				return err
+			}
+			pc.CopyTokenFrom(parent)
+
+			return nil
+		}
+	}
+
+	sc := *client
+	sc.ProviderClient = &pc
+
+	return &sc
+}
@@ -0,0 +1,340 @@
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestConfigClientContext(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, err := config.ComputeV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	cancel()
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.Error(t, err)
+
+	// Clients bound to other contexts are not affected.
+	client, _ = config.ComputeV2Client(context.Background(), srv.Region)
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+}
+
+func TestConfigClientContextReauth(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	first, _ := config.ComputeV2Client(context.Background(), srv.Region)
+	second, _ := config.ComputeV2Client(context.Background(), srv.Region)
+
+	srv.RevokeTokens()
+
+	_, err := flavors.ListDetail(first, nil).AllPages()
+	assert.NoError(t, err)
+	_, err = flavors.ListDetail(second, nil).AllPages()
+	assert.NoError(t, err)
+
+	// The second client picks up the token renewed by the first one
+	// instead of authenticating again.
+	assert.Equal(t, 2, testFakeAuthentications(srv))
+}
+
+// testFakeAuthentications returns the number of token requests srv received.
+func testFakeAuthentications(srv *fakeopenstack.Server) int {
+	var authentications int
+	for _, r := range srv.Requests() {
+		if r.Method == "POST" && r.Path == "/identity/v3/auth/tokens" {
+			authentications++
+		}
+	}
+
+	return authentications
+}
+
+func TestConfigServiceRequestLimits(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"service_request_limit": []interface{}{
+			map[string]interface{}{
+				"service":                 "compute",
+				"max_requests_per_second": 0.1,
+			},
+		},
+	})
+
+	network, _ := config.NetworkingV2Client(context.Background(), srv.Region)
+	_, err := networks.List(network, nil).AllPages()
+	assert.NoError(t, err)
+	_, err = networks.List(network, nil).AllPages()
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	compute, _ := config.ComputeV2Client(ctx, srv.Region)
+	_, err = flavors.ListDetail(compute, nil).AllPages()
+	assert.NoError(t, err)
+	_, err = flavors.ListDetail(compute, nil).AllPages()
+	assert.Error(t, err)
+}
+
+func TestConfigRetryPolicy(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"retry": []interface{}{
+			map[string]interface{}{
+				"min_backoff": "1ms",
+				"max_backoff": "10ms",
+			},
+		},
+	})
+
+	srv.AddFault(fakeopenstack.Fault{
+		Method: "GET",
+		Path:   "/networks",
+		Status: http.StatusTooManyRequests,
+		Header: map[string]string{"Retry-After": "0"},
+		Times:  2,
+	})
+
+	client, _ := config.NetworkingV2Client(context.Background(), srv.Region)
+	_, err := networks.List(client, nil).AllPages()
+	assert.NoError(t, err)
+}
+
+func TestConfigRetryPolicyDisabled(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	// Requests are not retried without a retry block.
+	srv.AddFault(fakeopenstack.Fault{
+		Method: "GET",
+		Path:   "/networks",
+		Status: http.StatusServiceUnavailable,
+		Times:  1,
+	})
+
+	client, _ := config.NetworkingV2Client(context.Background(), srv.Region)
+	_, err := networks.List(client, nil).AllPages()
+	assert.Error(t, err)
+}
+
+func TestConfigAuthFailure(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	// A transient authentication error is not remembered.
+	config := testFakeProviderConfig(t, srv, nil)
+	srv.AddFault(fakeopenstack.Fault{
+		Method: "POST",
+		Path:   "/auth/tokens",
+		Status: http.StatusServiceUnavailable,
+		Times:  1,
+	})
+	_, err := config.ComputeV2Client(context.Background(), srv.Region)
+	assert.Error(t, err)
+	_, err = config.ComputeV2Client(context.Background(), srv.Region)
+	assert.NoError(t, err)
+
+	// Rejected credentials are not sent again.
+	config = testFakeProviderConfig(t, srv, map[string]interface{}{
+		"password": "wrong",
+	})
+	_, err = config.ComputeV2Client(context.Background(), srv.Region)
+	assert.Error(t, err)
+	authentications := testFakeAuthentications(srv)
+	_, err = config.ComputeV2Client(context.Background(), srv.Region)
+	assert.Error(t, err)
+	assert.Equal(t, authentications, testFakeAuthentications(srv))
+}
+
+func TestConfigTokenCache(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	raw := map[string]interface{}{
+		"token_cache":     true,
+		"token_cache_dir": dir,
+		"token_cache_key": "secret",
+		"delayed_auth":    false,
+	}
+
+	testFakeProviderConfig(t, srv, raw)
+	config := testFakeProviderConfig(t, srv, raw)
+	assert.Equal(t, 1, testFakeAuthentications(srv))
+
+	client, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	// A revoked token is replaced in the cache.
+	srv.RevokeTokens()
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, testFakeAuthentications(srv))
+
+	config = testFakeProviderConfig(t, srv, raw)
+	client, _ = config.ComputeV2Client(context.Background(), srv.Region)
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, testFakeAuthentications(srv))
+
+	// A wrong password does not reuse the cached token.
+	wrong := map[string]interface{}{
+		"auth_url":    srv.AuthURL(),
+		"user_name":   srv.Username,
+		"password":    "wrong",
+		"tenant_name": srv.ProjectName,
+		"region":      srv.Region,
+	}
+	for k, v := range raw {
+		wrong[k] = v
+	}
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(wrong))
+	assert.True(t, diags.HasError())
+	assert.Equal(t, 3, testFakeAuthentications(srv))
+
+	// Other credentials do not share the token.
+	raw["tenant_name"] = "other"
+	testFakeProviderConfig(t, srv, raw)
+	assert.Equal(t, 4, testFakeAuthentications(srv))
+}
+
+func TestConfigTOTP(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+	srv.TOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	// The password alone is not sufficient.
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"auth_url":     srv.AuthURL(),
+		"user_name":    srv.Username,
+		"password":     srv.Password,
+		"tenant_name":  srv.ProjectName,
+		"region":       srv.Region,
+		"delayed_auth": false,
+	}))
+	assert.True(t, diags.HasError())
+
+	passcode, err := totpPasscode(srv.TOTPSecret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFakeProviderConfig(t, srv, map[string]interface{}{
+		"passcode":     passcode,
+		"delayed_auth": false,
+	})
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"totp_secret":  srv.TOTPSecret,
+		"delayed_auth": false,
+	})
+	client, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+
+	// Re-authentication generates a new passcode.
+	srv.RevokeTokens()
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+}
+
+func TestConfigSystemScope(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"system_scope": true,
+	})
+
+	ctx := context.Background()
+	projectClient, err := config.ComputeV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	systemClient, err := config.ComputeV2Client(withSystemScope(ctx), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	assert.NotEqual(t, projectClient.Token(), systemClient.Token())
+
+	var scopes []string
+	for _, r := range srv.Requests() {
+		if r.Method == "POST" && r.Path == "/identity/v3/auth/tokens" {
+			var req struct {
+				Auth struct {
+					Scope map[string]interface{} `json:"scope"`
+				} `json:"auth"`
+			}
+			if err := json.Unmarshal(r.Body, &req); err != nil {
+				t.Fatal(err)
+			}
+			for scope := range req.Auth.Scope {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	assert.Equal(t, []string{"project", "system"}, scopes)
+
+	// Both clients work independently.
+	_, err = flavors.ListDetail(systemClient, nil).AllPages()
+	assert.NoError(t, err)
+	_, err = flavors.ListDetail(projectClient, nil).AllPages()
+	assert.NoError(t, err)
+
+	// Without system_scope, all clients are project-scoped.
+	config = testFakeProviderConfig(t, srv, nil)
+	projectClient, _ = config.ComputeV2Client(ctx, srv.Region)
+	systemClient, _ = config.ComputeV2Client(withSystemScope(ctx), srv.Region)
+	assert.Equal(t, projectClient.Token(), systemClient.Token())
+}
+
+func TestSystemScoped(t *testing.T) {
+	var systemScope bool
+	r := systemScoped(&schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			systemScope = systemScopeFromContext(ctx)
+			return nil
+		},
+	})
+
+	r.ReadContext(context.Background(), nil, nil)
+	assert.True(t, systemScope)
+	assert.Nil(t, r.CreateContext)
+}
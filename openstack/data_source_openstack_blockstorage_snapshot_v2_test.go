@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -51,7 +52,7 @@ func testAccBlockStorageV2CreateVolumeAndSnapshot(volumeName, snapshotName strin
 		return "", "", err
 	}
 
-	bsClient, err := config.BlockStorageV2Client(OS_REGION_NAME)
+	bsClient, err := config.BlockStorageV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return "", "", err
 	}
@@ -95,7 +96,7 @@ func testAccBlockStorageV2DeleteVolumeAndSnapshot(t *testing.T, volumeID, snapsh
 		t.Fatal(err)
 	}
 
-	bsClient, err := config.BlockStorageV2Client(OS_REGION_NAME)
+	bsClient, err := config.BlockStorageV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		t.Fatal(err)
 	}
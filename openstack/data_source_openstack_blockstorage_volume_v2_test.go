@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -49,7 +50,7 @@ func testAccBlockStorageV2CreateVolume(volumeName string) (string, error) {
 		return "", err
 	}
 
-	bsClient, err := config.BlockStorageV2Client(OS_REGION_NAME)
+	bsClient, err := config.BlockStorageV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return "", err
 	}
@@ -78,7 +79,7 @@ func testAccBlockStorageV2DeleteVolume(t *testing.T, volumeID string) {
 		t.Fatal(err)
 	}
 
-	bsClient, err := config.BlockStorageV2Client(OS_REGION_NAME)
+	bsClient, err := config.BlockStorageV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		t.Fatal(err)
 	}
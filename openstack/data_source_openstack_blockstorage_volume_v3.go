@@ -1,17 +1,18 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceBlockStorageVolumeV3() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBlockStorageVolumeV3Read,
+		ReadContext: dataSourceBlockStorageVolumeV3Read,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -69,11 +70,11 @@ func dataSourceBlockStorageVolumeV3() *schema.Resource {
 	}
 }
 
-func dataSourceBlockStorageVolumeV3Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBlockStorageVolumeV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV3Client(GetRegion(d, config))
+	client, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	listOpts := volumes.ListOpts{
@@ -84,23 +85,23 @@ func dataSourceBlockStorageVolumeV3Read(d *schema.ResourceData, meta interface{}
 
 	allPages, err := volumes.List(client, listOpts).AllPages()
 	if err != nil {
-		return fmt.Errorf("Unable to query openstack_blockstorage_volume_v3: %s", err)
+		return diag.Errorf("Unable to query openstack_blockstorage_volume_v3: %s", err)
 	}
 
 	allVolumes, err := volumes.ExtractVolumes(allPages)
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve openstack_blockstorage_volume_v3: %s", err)
+		return diag.Errorf("Unable to retrieve openstack_blockstorage_volume_v3: %s", err)
 	}
 
 	if len(allVolumes) > 1 {
-		return fmt.Errorf("Your openstack_blockstorage_volume_v3 query returned multiple results.")
+		return diag.Errorf("Your openstack_blockstorage_volume_v3 query returned multiple results.")
 	}
 
 	if len(allVolumes) < 1 {
-		return fmt.Errorf("Your openstack_blockstorage_volume_v3 query returned no results.")
+		return diag.Errorf("Your openstack_blockstorage_volume_v3 query returned no results.")
 	}
 
-	return dataSourceBlockStorageVolumeV3Attributes(d, allVolumes[0])
+	return diag.FromErr(dataSourceBlockStorageVolumeV3Attributes(d, allVolumes[0]))
 }
 
 func dataSourceBlockStorageVolumeV3Attributes(d *schema.ResourceData, volume volumes.Volume) error {
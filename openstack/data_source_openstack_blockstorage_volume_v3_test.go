@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -50,7 +51,7 @@ func testAccBlockStorageV3CreateVolume(volumeName string) (string, error) {
 		return "", err
 	}
 
-	bsClient, err := config.BlockStorageV3Client(OS_REGION_NAME)
+	bsClient, err := config.BlockStorageV3Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return "", err
 	}
@@ -79,7 +80,7 @@ func testAccBlockStorageV3DeleteVolume(t *testing.T, volumeID string) {
 		t.Fatal(err)
 	}
 
-	bsClient, err := config.BlockStorageV3Client(OS_REGION_NAME)
+	bsClient, err := config.BlockStorageV3Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,97 @@
+package openstack
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/aggregates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceComputeAggregateV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceComputeAggregateV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// computed-only
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"hosts": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceComputeAggregateV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	allPages, err := aggregates.List(computeClient).AllPages()
+	if err != nil {
+		return diag.Errorf("Error retrieving openstack_compute_aggregate_v2: %s", err)
+	}
+
+	allAggregates, err := aggregates.ExtractAggregates(allPages)
+	if err != nil {
+		return diag.Errorf("Error extracting openstack_compute_aggregate_v2: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	var found []aggregates.Aggregate
+	for _, aggregate := range allAggregates {
+		if aggregate.Name == name {
+			found = append(found, aggregate)
+		}
+	}
+
+	if len(found) < 1 {
+		return diag.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(found) > 1 {
+		log.Printf("[DEBUG] Multiple results found: %#v", found)
+		return diag.Errorf("Your query returned more than one result. " +
+			"Please try a more specific search criteria")
+	}
+
+	aggregate := &found[0]
+	log.Printf("[DEBUG] Retrieved openstack_compute_aggregate_v2 %d: %#v", aggregate.ID, aggregate)
+
+	d.SetId(strconv.Itoa(aggregate.ID))
+	d.Set("availability_zone", aggregate.AvailabilityZone)
+	d.Set("metadata", computeAggregateV2Metadata(aggregate))
+	d.Set("hosts", aggregate.Hosts)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
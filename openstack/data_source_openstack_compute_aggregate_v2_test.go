@@ -0,0 +1,48 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccComputeV2AggregateDataSource_basic(t *testing.T) {
+	var aggregateName = acctest.RandomWithPrefix("tf-acc-aggregate")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAdminOnly(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2AggregateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2AggregateDataSource_basic(aggregateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.openstack_compute_aggregate_v2.aggregate_1", "id",
+						"openstack_compute_aggregate_v2.aggregate_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.openstack_compute_aggregate_v2.aggregate_1", "availability_zone", "tf-acc-az"),
+					resource.TestCheckResourceAttr(
+						"data.openstack_compute_aggregate_v2.aggregate_1", "metadata.%", "2"),
+					resource.TestCheckResourceAttr(
+						"data.openstack_compute_aggregate_v2.aggregate_1", "metadata.rack", "r1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccComputeV2AggregateDataSource_basic(aggregateName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "openstack_compute_aggregate_v2" "aggregate_1" {
+  name = "${openstack_compute_aggregate_v2.aggregate_1.name}"
+}
+`, testAccComputeV2Aggregate_basic(aggregateName))
+}
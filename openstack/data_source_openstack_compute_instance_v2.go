@@ -1,20 +1,20 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"strings"
 
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/tags"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceComputeInstanceV2() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceComputeInstanceV2Read,
+		ReadContext: dataSourceComputeInstanceV2Read,
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -115,19 +115,19 @@ func dataSourceComputeInstanceV2() *schema.Resource {
 	}
 }
 
-func dataSourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceComputeInstanceV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 	log.Print("[DEBUG] Creating compute client")
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	id := d.Get("id").(string)
 	log.Printf("[DEBUG] Attempting to retrieve server %s", id)
 	server, err := servers.Get(computeClient, id).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "server")
+		return diag.FromErr(CheckDeleted(d, err, "server"))
 	}
 
 	log.Printf("[DEBUG] Retrieved Server %s: %+v", id, server)
@@ -138,9 +138,9 @@ func dataSourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) e
 	d.Set("image_id", server.Image["ID"])
 
 	// Get the instance network and address information
-	networks, err := flattenInstanceNetworks(d, meta)
+	networks, err := flattenInstanceNetworks(ctx, d, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Determine the best IPv4 and IPv6 addresses to access the instance with
@@ -175,20 +175,20 @@ func dataSourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) e
 
 	flavorId, ok := server.Flavor["id"].(string)
 	if !ok {
-		return fmt.Errorf("Error setting OpenStack server's flavor: %v", server.Flavor)
+		return diag.Errorf("Error setting OpenStack server's flavor: %v", server.Flavor)
 	}
 	d.Set("flavor_id", flavorId)
 
 	d.Set("key_pair", server.KeyName)
 	flavor, err := flavors.Get(computeClient, flavorId).Extract()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("flavor_name", flavor.Name)
 
 	// Set the instance's image information appropriately
-	if err := setImageInformation(computeClient, server, d); err != nil {
-		return err
+	if err := setImageInformation(config, computeClient, server, d); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// Build a custom struct for the availability zone extension
@@ -200,7 +200,7 @@ func dataSourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) e
 	// Do another Get so the above work is not disturbed.
 	err = servers.Get(computeClient, d.Id()).ExtractInto(&serverWithAZ)
 	if err != nil {
-		return CheckDeleted(d, err, "server")
+		return diag.FromErr(CheckDeleted(d, err, "server"))
 	}
 	// Set the availability zone
 	d.Set("availability_zone", serverWithAZ.AvailabilityZone)
@@ -209,13 +209,11 @@ func dataSourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) e
 	d.Set("region", GetRegion(d, config))
 
 	// Set the current power_state
-	currentStatus := strings.ToLower(server.Status)
-	switch currentStatus {
-	case "active", "shutoff", "error", "migrating", "shelved_offloaded", "shelved":
-		d.Set("power_state", currentStatus)
-	default:
-		return fmt.Errorf("Invalid power_state for instance %s: %s", d.Id(), server.Status)
+	powerState, ok := computeV2InstancePowerStates[server.Status]
+	if !ok {
+		return diag.Errorf("Invalid power_state for instance %s: %s", d.Id(), server.Status)
 	}
+	d.Set("power_state", powerState)
 
 	// Populate tags.
 	computeClient.Microversion = computeV2TagsExtensionMicroversion
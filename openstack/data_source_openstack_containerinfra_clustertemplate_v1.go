@@ -1,17 +1,18 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/clustertemplates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceContainerInfraClusterTemplateV1() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceContainerInfraClusterTemplateV1Read,
+		ReadContext: dataSourceContainerInfraClusterTemplateV1Read,
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -177,17 +178,17 @@ func dataSourceContainerInfraClusterTemplateV1() *schema.Resource {
 	}
 }
 
-func dataSourceContainerInfraClusterTemplateV1Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceContainerInfraClusterTemplateV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	containerInfraClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	containerInfraClient, err := config.ContainerInfraV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack container infra client: %s", err)
+		return diag.Errorf("Error creating OpenStack container infra client: %s", err)
 	}
 
 	name := d.Get("name").(string)
 	ct, err := clustertemplates.Get(containerInfraClient, name).Extract()
 	if err != nil {
-		return fmt.Errorf("Error getting openstack_containerinfra_clustertemplate_v1 %s: %s", name, err)
+		return diag.Errorf("Error getting openstack_containerinfra_clustertemplate_v1 %s: %s", name, err)
 	}
 
 	d.SetId(ct.UUID)
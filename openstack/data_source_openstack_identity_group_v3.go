@@ -1,16 +1,17 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/groups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceIdentityGroupV3() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceIdentityGroupV3Read,
+		ReadContext: dataSourceIdentityGroupV3Read,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -40,11 +41,11 @@ func dataSourceIdentityGroupV3() *schema.Resource {
 }
 
 // dataSourceIdentityGroupV3Read performs the group lookup.
-func dataSourceIdentityGroupV3Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceIdentityGroupV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	listOpts := groups.ListOpts{
@@ -57,26 +58,26 @@ func dataSourceIdentityGroupV3Read(d *schema.ResourceData, meta interface{}) err
 	var group groups.Group
 	allPages, err := groups.List(identityClient, listOpts).AllPages()
 	if err != nil {
-		return fmt.Errorf("Unable to query openstack_identity_group_v3: %s", err)
+		return diag.Errorf("Unable to query openstack_identity_group_v3: %s", err)
 	}
 
 	allGroups, err := groups.ExtractGroups(allPages)
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve openstack_identity_group_v3: %s", err)
+		return diag.Errorf("Unable to retrieve openstack_identity_group_v3: %s", err)
 	}
 
 	if len(allGroups) < 1 {
-		return fmt.Errorf("Your openstack_identity_group_v3 query returned no results. " +
+		return diag.Errorf("Your openstack_identity_group_v3 query returned no results. " +
 			"Please change your search criteria and try again.")
 	}
 
 	if len(allGroups) > 1 {
-		return fmt.Errorf("Your openstack_identity_group_v3 query returned more than one result.")
+		return diag.Errorf("Your openstack_identity_group_v3 query returned more than one result.")
 	}
 
 	group = allGroups[0]
 
-	return dataSourceIdentityGroupV3Attributes(d, config, &group)
+	return diag.FromErr(dataSourceIdentityGroupV3Attributes(d, config, &group))
 }
 
 // dataSourceIdentityRoleV3Attributes populates the fields of an Role resource.
@@ -1,17 +1,18 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceIdentityProjectV3() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceIdentityProjectV3Read,
+		ReadContext: dataSourceIdentityProjectV3Read,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -75,12 +76,12 @@ func filterProjects(allProjects []projects.Project, listOpts projects.ListOpts)
 }
 
 // dataSourceIdentityProjectV3Read performs the project lookup.
-func dataSourceIdentityProjectV3Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceIdentityProjectV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	enabled := d.Get("enabled").(bool)
@@ -102,38 +103,38 @@ func dataSourceIdentityProjectV3Read(d *schema.ResourceData, meta interface{}) e
 		if userID == "" {
 			userID, _, err = GetTokenInfo(identityClient)
 			if err != nil {
-				return fmt.Errorf("Error when getting token info: %s", err)
+				return diag.Errorf("Error when getting token info: %s", err)
 			}
 		}
 		// Search for all the projects using the users.ListProjects API call and filter them
 		allPages, err = users.ListProjects(identityClient, userID).AllPages()
 		if err != nil {
-			return fmt.Errorf("Unable to query openstack_identity_project_v3: %s", err)
+			return diag.Errorf("Unable to query openstack_identity_project_v3: %s", err)
 		}
 		allProjects, err = projects.ExtractProjects(allPages)
 		if err != nil {
-			return fmt.Errorf("Unable to retrieve openstack_identity_project_v3: %s", err)
+			return diag.Errorf("Unable to retrieve openstack_identity_project_v3: %s", err)
 		}
 		allProjects = filterProjects(allProjects, listOpts)
 	} else {
 		allProjects, err = projects.ExtractProjects(allPages)
 		if err != nil {
-			return fmt.Errorf("Unable to retrieve openstack_identity_project_v3: %s", err)
+			return diag.Errorf("Unable to retrieve openstack_identity_project_v3: %s", err)
 		}
 	}
 
 	if len(allProjects) < 1 {
-		return fmt.Errorf("Your openstack_identity_project_v3 query returned no results. " +
+		return diag.Errorf("Your openstack_identity_project_v3 query returned no results. " +
 			"Please change your search criteria and try again.")
 	}
 
 	if len(allProjects) > 1 {
-		return fmt.Errorf("Your openstack_identity_project_v3 query returned more than one result.")
+		return diag.Errorf("Your openstack_identity_project_v3 query returned more than one result.")
 	}
 
 	project = allProjects[0]
 
-	return dataSourceIdentityProjectV3Attributes(d, &project)
+	return diag.FromErr(dataSourceIdentityProjectV3Attributes(d, &project))
 }
 
 // dataSourceIdentityProjectV3Attributes populates the fields of an Project resource.
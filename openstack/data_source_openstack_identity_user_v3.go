@@ -1,17 +1,18 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceIdentityUserV3() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceIdentityUserV3Read,
+		ReadContext: dataSourceIdentityUserV3Read,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -73,11 +74,11 @@ func dataSourceIdentityUserV3() *schema.Resource {
 }
 
 // dataSourceIdentityUserV3Read performs the user lookup.
-func dataSourceIdentityUserV3Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceIdentityUserV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	enabled := d.Get("enabled").(bool)
@@ -96,26 +97,26 @@ func dataSourceIdentityUserV3Read(d *schema.ResourceData, meta interface{}) erro
 	var user users.User
 	allPages, err := users.List(identityClient, listOpts).AllPages()
 	if err != nil {
-		return fmt.Errorf("Unable to query openstack_identity_user_v3: %s", err)
+		return diag.Errorf("Unable to query openstack_identity_user_v3: %s", err)
 	}
 
 	allUsers, err := users.ExtractUsers(allPages)
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve openstack_identity_user_v3: %s", err)
+		return diag.Errorf("Unable to retrieve openstack_identity_user_v3: %s", err)
 	}
 
 	if len(allUsers) < 1 {
-		return fmt.Errorf("Your openstack_identity_user_v3 query returned no results. " +
+		return diag.Errorf("Your openstack_identity_user_v3 query returned no results. " +
 			"Please change your search criteria and try again.")
 	}
 
 	if len(allUsers) > 1 {
-		return fmt.Errorf("Your openstack_identity_user_v3 query returned more than one result.")
+		return diag.Errorf("Your openstack_identity_user_v3 query returned more than one result.")
 	}
 
 	user = allUsers[0]
 
-	return dataSourceIdentityUserV3Attributes(d, &user)
+	return diag.FromErr(dataSourceIdentityUserV3Attributes(d, &user))
 }
 
 // dataSourceIdentityUserV3Attributes populates the fields of an User resource.
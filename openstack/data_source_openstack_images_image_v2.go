@@ -1,20 +1,21 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"sort"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceImagesImageV2() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceImagesImageV2Read,
+		ReadContext: dataSourceImagesImageV2Read,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -180,11 +181,11 @@ func dataSourceImagesImageV2() *schema.Resource {
 }
 
 // dataSourceImagesImageV2Read performs the image lookup.
-func dataSourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceImagesImageV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	imageClient, err := config.ImageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack image client: %s", err)
+		return diag.Errorf("Error creating OpenStack image client: %s", err)
 	}
 
 	visibility := resourceImagesImageV2VisibilityFromString(d.Get("visibility").(string))
@@ -214,12 +215,12 @@ func dataSourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error
 	var image images.Image
 	allPages, err := images.List(imageClient, listOpts).AllPages()
 	if err != nil {
-		return fmt.Errorf("Unable to query images: %s", err)
+		return diag.Errorf("Unable to query images: %s", err)
 	}
 
 	allImages, err := images.ExtractImages(allPages)
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve images: %s", err)
+		return diag.Errorf("Unable to retrieve images: %s", err)
 	}
 
 	properties := resourceImagesImageV2ExpandProperties(
@@ -232,7 +233,7 @@ func dataSourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if len(allImages) < 1 {
-		return fmt.Errorf("Your query returned no results. " +
+		return diag.Errorf("Your query returned no results. " +
 			"Please change your search criteria and try again.")
 	}
 
@@ -243,7 +244,7 @@ func dataSourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error
 			image = mostRecentImage(allImages)
 		} else {
 			log.Printf("[DEBUG] Multiple results found: %#v", allImages)
-			return fmt.Errorf("Your query returned more than one result. Please try a more " +
+			return diag.Errorf("Your query returned more than one result. Please try a more " +
 				"specific search criteria, or set `most_recent` attribute to true.")
 		}
 	} else {
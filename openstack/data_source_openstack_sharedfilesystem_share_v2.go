@@ -1,10 +1,12 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/shares"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -15,7 +17,7 @@ const (
 
 func dataSourceSharedFilesystemShareV2() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceSharedFilesystemShareV2Read,
+		ReadContext: dataSourceSharedFilesystemShareV2Read,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -111,11 +113,11 @@ func dataSourceSharedFilesystemShareV2() *schema.Resource {
 	}
 }
 
-func dataSourceSharedFilesystemShareV2Read(d *schema.ResourceData, meta interface{}) error {
+func dataSourceSharedFilesystemShareV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	sfsClient, err := config.SharedfilesystemV2Client(GetRegion(d, config))
+	sfsClient, err := config.SharedfilesystemV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack sharedfilesystem sfsClient: %s", err)
+		return diag.Errorf("Error creating OpenStack sharedfilesystem sfsClient: %s", err)
 	}
 
 	sfsClient.Microversion = minManilaShareMicroversion
@@ -147,23 +149,23 @@ func dataSourceSharedFilesystemShareV2Read(d *schema.ResourceData, meta interfac
 
 	allPages, err := shares.ListDetail(sfsClient, listOpts).AllPages()
 	if err != nil {
-		return fmt.Errorf("Unable to query shares: %s", err)
+		return diag.Errorf("Unable to query shares: %s", err)
 	}
 
 	allShares, err := shares.ExtractShares(allPages)
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve shares: %s", err)
+		return diag.Errorf("Unable to retrieve shares: %s", err)
 	}
 
 	if len(allShares) < 1 {
-		return fmt.Errorf("Your query returned no results. " +
+		return diag.Errorf("Your query returned no results. " +
 			"Please change your search criteria and try again.")
 	}
 
 	var share shares.Share
 	if len(allShares) > 1 {
 		log.Printf("[DEBUG] Multiple results found: %#v", allShares)
-		return fmt.Errorf("Your query returned more than one result. Please try a more " +
+		return diag.Errorf("Your query returned more than one result. Please try a more " +
 			"specific search criteria.")
 	} else {
 		share = allShares[0]
@@ -171,7 +173,7 @@ func dataSourceSharedFilesystemShareV2Read(d *schema.ResourceData, meta interfac
 
 	exportLocationsRaw, err := shares.ListExportLocations(sfsClient, share.ID).Extract()
 	if err != nil {
-		return fmt.Errorf("Failed to retrieve share's export_locations %s: %s", share.ID, err)
+		return diag.Errorf("Failed to retrieve share's export_locations %s: %s", share.ID, err)
 	}
 
 	log.Printf("[DEBUG] Retrieved share's export_locations %s: %#v", share.ID, exportLocationsRaw)
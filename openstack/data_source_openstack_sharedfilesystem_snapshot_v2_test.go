@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -110,7 +111,7 @@ func testAccSFSV2SnapshotCreate(t *testing.T, snapshotName string) (*snapshots.S
 		return nil, err
 	}
 
-	client, err := config.SharedfilesystemV2Client(OS_REGION_NAME)
+	client, err := config.SharedfilesystemV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +166,7 @@ func testAccSFSV2SnapshotDelete(t *testing.T, snapshot *snapshots.Snapshot) erro
 		return err
 	}
 
-	client, err := config.SharedfilesystemV2Client(OS_REGION_NAME)
+	client, err := config.SharedfilesystemV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package openstack
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -38,6 +40,61 @@ func expandDatabaseConfigurationV1Values(rawValues []interface{}) map[string]int
 	return values
 }
 
+func flattenDatabaseConfigurationV1Datastore(cgroup *configurations.Config) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"version": cgroup.DatastoreVersionName,
+			"type":    cgroup.DatastoreName,
+		},
+	}
+}
+
+// flattenDatabaseConfigurationV1Values flattens the values of a configuration
+// group. Values keep the order of rawValues, the values already known, so
+// that reading them back does not reorder the list. Other values follow in
+// the order of their names.
+func flattenDatabaseConfigurationV1Values(rawValues []interface{}, values map[string]interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(values))
+	seen := make(map[string]bool)
+	add := func(name string) {
+		v, ok := values[name]
+		if !ok || seen[name] {
+			return
+		}
+		seen[name] = true
+
+		var value string
+		switch v := v.(type) {
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			value = fmt.Sprint(v)
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":  name,
+			"value": value,
+		})
+	}
+
+	for _, rawValue := range rawValues {
+		if v, ok := rawValue.(map[string]interface{}); ok {
+			add(v["name"].(string))
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name)
+	}
+
+	return result
+}
+
 // databaseConfigurationV1StateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // an cloud database instance.
 func databaseConfigurationV1StateRefreshFunc(client *gophercloud.ServiceClient, cgroupID string) resource.StateRefreshFunc {
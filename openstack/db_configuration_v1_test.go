@@ -50,3 +50,45 @@ func TestExpandDatabaseConfigurationV1Values(t *testing.T) {
 	actual := expandDatabaseConfigurationV1Values(values)
 	assert.Equal(t, expected, actual)
 }
+
+func TestFlattenDatabaseConfigurationV1Values(t *testing.T) {
+	rawValues := []interface{}{
+		map[string]interface{}{
+			"name":  "max_connections",
+			"value": "200",
+		},
+		map[string]interface{}{
+			"name":  "removed",
+			"value": "foo",
+		},
+	}
+
+	values := map[string]interface{}{
+		"collation_server": "latin1_swedish_ci",
+		"autocommit":       float64(1),
+		"max_connections":  float64(200),
+		"local_infile":     false,
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"name":  "max_connections",
+			"value": "200",
+		},
+		{
+			"name":  "autocommit",
+			"value": "1",
+		},
+		{
+			"name":  "collation_server",
+			"value": "latin1_swedish_ci",
+		},
+		{
+			"name":  "local_infile",
+			"value": "false",
+		},
+	}
+
+	actual := flattenDatabaseConfigurationV1Values(rawValues, values)
+	assert.Equal(t, expected, actual)
+}
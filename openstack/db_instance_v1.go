@@ -8,6 +8,7 @@ import (
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/databases"
+	"github.com/gophercloud/gophercloud/openstack/db/v1/datastores"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/instances"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/users"
 )
@@ -66,6 +67,58 @@ func expandDatabaseInstanceV1Users(rawUsers []interface{}) users.BatchCreateOpts
 	return userList
 }
 
+func flattenDatabaseInstanceV1Datastore(datastore datastores.DatastorePartial) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"version": datastore.Version,
+			"type":    datastore.Type,
+		},
+	}
+}
+
+func flattenDatabaseInstanceV1Databases(dbs []databases.Database) []map[string]interface{} {
+	databases := make([]map[string]interface{}, 0, len(dbs))
+	for _, db := range dbs {
+		databases = append(databases, map[string]interface{}{
+			"name":    db.Name,
+			"charset": db.CharSet,
+			"collate": db.Collate,
+		})
+	}
+
+	return databases
+}
+
+// flattenDatabaseInstanceV1Users flattens the users of an instance. Their
+// passwords cannot be retrieved.
+func flattenDatabaseInstanceV1Users(userList []databaseUserV1) []map[string]interface{} {
+	users := make([]map[string]interface{}, 0, len(userList))
+	for _, user := range userList {
+		users = append(users, map[string]interface{}{
+			"name":      user.Name,
+			"host":      flattenDatabaseUserV1Host("", user.Host),
+			"databases": flattenDatabaseUserV1Databases(user.Databases),
+		})
+	}
+
+	return users
+}
+
+// databaseInstanceV1ConfigurationID returns the ID of the configuration group
+// attached to an instance, which instances.Instance lacks.
+func databaseInstanceV1ConfigurationID(r instances.GetResult) (string, error) {
+	var s struct {
+		Instance struct {
+			Configuration struct {
+				ID string `json:"id"`
+			} `json:"configuration"`
+		} `json:"instance"`
+	}
+	err := r.ExtractInto(&s)
+
+	return s.Instance.Configuration.ID, err
+}
+
 // databaseInstanceV1StateRefreshFunc returns a resource.StateRefreshFunc
 // that is used to watch a database instance.
 func databaseInstanceV1StateRefreshFunc(client *gophercloud.ServiceClient, instanceID string) resource.StateRefreshFunc {
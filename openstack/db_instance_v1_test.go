@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/gophercloud/gophercloud/openstack/db/v1/databases"
+	"github.com/gophercloud/gophercloud/openstack/db/v1/datastores"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/instances"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/users"
 )
@@ -106,3 +107,68 @@ func TestExpandDatabaseInstanceV1Users(t *testing.T) {
 	actual := expandDatabaseInstanceV1Users(userList)
 	assert.Equal(t, expected, actual)
 }
+
+func TestFlattenDatabaseInstanceV1Datastore(t *testing.T) {
+	datastore := datastores.DatastorePartial{
+		Version: "5.7",
+		Type:    "mysql",
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"version": "5.7",
+			"type":    "mysql",
+		},
+	}
+
+	actual := flattenDatabaseInstanceV1Datastore(datastore)
+	assert.Equal(t, expected, actual)
+}
+
+func TestFlattenDatabaseInstanceV1Databases(t *testing.T) {
+	dbs := []databases.Database{
+		{
+			Name:    "testdb",
+			CharSet: "utf8",
+			Collate: "utf8_general_ci",
+		},
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"name":    "testdb",
+			"charset": "utf8",
+			"collate": "utf8_general_ci",
+		},
+	}
+
+	actual := flattenDatabaseInstanceV1Databases(dbs)
+	assert.Equal(t, expected, actual)
+}
+
+func TestFlattenDatabaseInstanceV1Users(t *testing.T) {
+	userList := []databaseUserV1{
+		{
+			User: users.User{
+				Name: "testuser",
+				Databases: []databases.Database{
+					{
+						Name: "testdb",
+					},
+				},
+			},
+			Host: "%",
+		},
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"name":      "testuser",
+			"host":      "",
+			"databases": []string{"testdb"},
+		},
+	}
+
+	actual := flattenDatabaseInstanceV1Users(userList)
+	assert.Equal(t, expected, actual)
+}
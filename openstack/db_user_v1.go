@@ -54,18 +54,43 @@ func databaseUserV1StateRefreshFunc(client *gophercloud.ServiceClient, instanceI
 	}
 }
 
-// databaseUserV1Exists is used to check whether user exists on particular database instance
-func databaseUserV1Exists(client *gophercloud.ServiceClient, instanceID string, userName string) (bool, users.User, error) {
-	var exists bool
-	var err error
-	var userObj users.User
+// databaseUserV1 is a database user together with the host it may connect
+// from, which users.User lacks.
+type databaseUserV1 struct {
+	users.User
+	Host string `json:"host"`
+}
 
+// databaseUserV1List returns all users of a database instance.
+func databaseUserV1List(client *gophercloud.ServiceClient, instanceID string) ([]databaseUserV1, error) {
 	pages, err := users.List(client, instanceID).AllPages()
 	if err != nil {
-		return exists, userObj, err
+		return nil, err
+	}
+
+	var allUsers []databaseUserV1
+	err = pages.(users.UserPage).ExtractIntoSlicePtr(&allUsers, "users")
+
+	return allUsers, err
+}
+
+// flattenDatabaseUserV1Host returns the host of a user as configured. "%",
+// which allows connections from any host, is the default, so it is only
+// kept when it is set explicitly.
+func flattenDatabaseUserV1Host(current, host string) string {
+	if host == "%" && current != "%" {
+		return ""
 	}
 
-	allUsers, err := users.ExtractUsers(pages)
+	return host
+}
+
+// databaseUserV1Exists is used to check whether user exists on particular database instance
+func databaseUserV1Exists(client *gophercloud.ServiceClient, instanceID string, userName string) (bool, databaseUserV1, error) {
+	var exists bool
+	var userObj databaseUserV1
+
+	allUsers, err := databaseUserV1List(client, instanceID)
 	if err != nil {
 		return exists, userObj, err
 	}
@@ -39,3 +39,9 @@ func TestFlattenDatabaseUserV1Databases(t *testing.T) {
 	actual := flattenDatabaseUserV1Databases(dbs)
 	assert.Equal(t, expected, actual)
 }
+
+func TestFlattenDatabaseUserV1Host(t *testing.T) {
+	assert.Equal(t, "", flattenDatabaseUserV1Host("", "%"))
+	assert.Equal(t, "%", flattenDatabaseUserV1Host("%", "%"))
+	assert.Equal(t, "10.0.0.1", flattenDatabaseUserV1Host("", "10.0.0.1"))
+}
@@ -0,0 +1,132 @@
+package openstack
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tagKey returns the key of a "key=value" tag. Tags without a value are
+// their own key.
+func tagKey(tag string) string {
+	return strings.SplitN(tag, "=", 2)[0]
+}
+
+// expandTags returns the tags of a tags attribute, which is either a set or
+// a list of strings.
+func expandTags(v interface{}) []string {
+	switch v := v.(type) {
+	case *schema.Set:
+		return expandToStringSlice(v.List())
+	case []interface{}:
+		return expandToStringSlice(v)
+	}
+
+	return nil
+}
+
+// mergeDefaultTags returns the union of the provider default tags and the
+// tags of a resource. The resource tags override default tags with the same
+// key, e.g. "owner=alice" replaces the default "owner=platform".
+func mergeDefaultTags(defaults, tags []string) []string {
+	keys := make(map[string]bool)
+	for _, tag := range tags {
+		keys[tagKey(tag)] = true
+	}
+
+	merged := sliceUnion(nil, tags)
+	for _, tag := range defaults {
+		if !keys[tagKey(tag)] {
+			merged = sliceUnion(merged, []string{tag})
+		}
+	}
+
+	sort.Strings(merged)
+
+	return merged
+}
+
+// desiredAllTags returns the tags a resource should have when its tags
+// change from oldTags to newTags. Tags set outside of Terraform are kept,
+// unless their key is managed by the merged default and resource tags.
+func desiredAllTags(defaults, allTags, oldTags, newTags []string) []string {
+	merged := mergeDefaultTags(defaults, newTags)
+
+	keys := make(map[string]bool)
+	for _, tag := range merged {
+		keys[tagKey(tag)] = true
+	}
+
+	desired := merged
+	for _, tag := range allTags {
+		if !strSliceContains(oldTags, tag) && !keys[tagKey(tag)] {
+			desired = sliceUnion(desired, []string{tag})
+		}
+	}
+
+	sort.Strings(desired)
+
+	return desired
+}
+
+// expandObjectReadAllTags sets all_tags to the tags of a resource and tags to
+// the same tags without the provider default tags, unless the resource sets
+// a default tag itself. Unlike expandObjectReadTags, tags set outside of
+// Terraform show up in tags, also after an import.
+func expandObjectReadAllTags(d *schema.ResourceData, tags, defaults []string) {
+	d.Set("all_tags", tags)
+
+	resourceTags := expandTags(d.Get("tags"))
+	var ownTags []string
+	for _, tag := range tags {
+		if strSliceContains(defaults, tag) && !strSliceContains(resourceTags, tag) {
+			continue
+		}
+		ownTags = append(ownTags, tag)
+	}
+	d.Set("tags", ownTags)
+}
+
+// expandObjectCreateTags returns the tags to create a resource with, which
+// are its tags merged with the provider default tags.
+func expandObjectCreateTags(d *schema.ResourceData, defaults []string) []string {
+	return mergeDefaultTags(defaults, expandTags(d.Get("tags")))
+}
+
+// expandObjectUpdateTags returns the tags to replace the tags of a resource
+// with, keeping the tags set outside of Terraform.
+func expandObjectUpdateTags(d *schema.ResourceData, defaults []string) []string {
+	allTags, _ := d.GetChange("all_tags")
+	oldTags, newTags := d.GetChange("tags")
+
+	return desiredAllTags(defaults, expandTags(allTags), expandTags(oldTags), expandTags(newTags))
+}
+
+// customizeDiffDefaultTags plans all_tags from the tags of a resource and the
+// provider default tags. all_tags only changes if the tags the resource
+// should have differ from its current tags, so that default tags overridden
+// by a resource do not cause perpetual diffs.
+func customizeDiffDefaultTags(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("all_tags")
+	}
+
+	config := meta.(*Config)
+	oldTags, newTags := diff.GetChange("tags")
+
+	if diff.Id() == "" {
+		return diff.SetNew("all_tags", mergeDefaultTags(config.DefaultTags, expandTags(newTags)))
+	}
+
+	allTags := expandTags(diff.Get("all_tags"))
+	desired := desiredAllTags(config.DefaultTags, allTags, expandTags(oldTags), expandTags(newTags))
+
+	sort.Strings(allTags)
+	if strings.Join(allTags, "\n") == strings.Join(desired, "\n") {
+		return nil
+	}
+
+	return diff.SetNew("all_tags", desired)
+}
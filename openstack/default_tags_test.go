@@ -0,0 +1,147 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	defaults := []string{"owner=platform", "cost-center=1234", "managed"}
+
+	assert.Equal(t, []string{"cost-center=1234", "managed", "owner=platform"}, mergeDefaultTags(defaults, nil))
+	assert.Equal(t, []string{"cost-center=1234", "managed", "owner=alice", "web"},
+		mergeDefaultTags(defaults, []string{"web", "owner=alice"}))
+	assert.Equal(t, []string{"web"}, mergeDefaultTags(nil, []string{"web"}))
+}
+
+func TestDesiredAllTags(t *testing.T) {
+	defaults := []string{"owner=platform"}
+
+	// Tags set outside of Terraform are kept, removed tags are dropped.
+	assert.Equal(t, []string{"external", "owner=platform", "web"},
+		desiredAllTags(defaults, []string{"external", "owner=platform", "db"}, []string{"db"}, []string{"web"}))
+
+	// A changed default replaces the previous value of its key.
+	assert.Equal(t, []string{"owner=ops"},
+		desiredAllTags([]string{"owner=ops"}, []string{"owner=platform"}, nil, nil))
+}
+
+func TestCustomizeDiffDefaultTags(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"default_tags": []interface{}{
+			map[string]interface{}{
+				"tags": []interface{}{"owner=platform", "cost-center=1234"},
+			},
+		},
+	})
+
+	raw := map[string]interface{}{
+		"name": "network_1",
+		"tags": []interface{}{"owner=alice"},
+	}
+
+	network := resourceNetworkingNetworkV2()
+	diff, err := network.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", diff.Attributes["all_tags.#"].New)
+
+	// The default overridden by the resource does not cause a diff.
+	d := testFakeResourceData(t, network, raw)
+	d.SetId("network_1")
+	expandObjectReadTags(d, []string{"cost-center=1234", "owner=alice", "external"})
+
+	diff, err = network.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+	if diff != nil {
+		assert.NotContains(t, diff.Attributes, "all_tags.#")
+		assert.NotContains(t, diff.Attributes, "tags.#")
+	}
+
+	// A new default is added to the existing resource.
+	config.DefaultTags = append(config.DefaultTags, "managed")
+	diff, err = network.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+	if assert.NotNil(t, diff) {
+		assert.Equal(t, "4", diff.Attributes["all_tags.#"].New)
+	}
+}
+
+func TestFakeImagesImageV2ReadTags(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"default_tags": []interface{}{
+			map[string]interface{}{
+				"tags": []interface{}{"owner=platform"},
+			},
+		},
+	})
+
+	image := srv.Objects(fakeopenstack.ImageImages)[0]
+	image["id"] = ""
+	image["tags"] = []interface{}{"owner=platform", "web", "external"}
+	id := srv.Seed(fakeopenstack.ImageImages, image)
+
+	// An imported image has all its tags except the default tags.
+	r := resourceImagesImageV2()
+	d := testFakeResourceData(t, r, map[string]interface{}{})
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading image: %v", diags)
+	}
+	assert.ElementsMatch(t, []interface{}{"web", "external"}, d.Get("tags").(*schema.Set).List())
+	assert.ElementsMatch(t, []interface{}{"owner=platform", "web", "external"}, d.Get("all_tags").(*schema.Set).List())
+
+	// A default tag set by the resource itself stays in its tags.
+	d = testFakeResourceData(t, r, map[string]interface{}{
+		"tags": []interface{}{"owner=platform", "web"},
+	})
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading image: %v", diags)
+	}
+	assert.ElementsMatch(t, []interface{}{"owner=platform", "web", "external"}, d.Get("tags").(*schema.Set).List())
+}
+
+// testAccCheckImportedTags checks that an imported resource has the given
+// tags.
+func testAccCheckImportedTags(expected ...string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("Expected 1 imported resource, got %d", len(states))
+		}
+
+		attributes := states[0].Attributes
+		if attributes["tags.#"] != strconv.Itoa(len(expected)) {
+			return fmt.Errorf("Expected %d imported tags, got %s", len(expected), attributes["tags.#"])
+		}
+
+		var tags []string
+		for k, v := range attributes {
+			if strings.HasPrefix(k, "tags.") && k != "tags.#" {
+				tags = append(tags, v)
+			}
+		}
+		for _, tag := range expected {
+			if !strSliceContains(tags, tag) {
+				return fmt.Errorf("Imported tags %v do not contain %s", tags, tag)
+			}
+		}
+
+		return nil
+	}
+}
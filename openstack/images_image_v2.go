@@ -84,7 +84,7 @@ func resourceImagesImageV2FileProps(filename string) (int64, string, error) {
 	return filesize, filechecksum, nil
 }
 
-func resourceImagesImageV2File(d *schema.ResourceData) (string, error) {
+func resourceImagesImageV2File(client *http.Client, d *schema.ResourceData) (string, error) {
 	if filename := d.Get("local_file_path").(string); filename != "" {
 		return filename, nil
 	} else if furl := d.Get("image_source_url").(string); furl != "" {
@@ -102,7 +102,7 @@ func resourceImagesImageV2File(d *schema.ResourceData) (string, error) {
 				return "", fmt.Errorf("Error creating file %q: %s", filename, err)
 			}
 			defer file.Close()
-			resp, err := http.Get(furl)
+			resp, err := client.Get(furl)
 			if err != nil {
 				return "", fmt.Errorf("Error downloading image from %q", furl)
 			}
@@ -133,15 +133,6 @@ func resourceImagesImageV2RefreshFunc(client *gophercloud.ServiceClient, id stri
 	}
 }
 
-func resourceImagesImageV2BuildTags(v []interface{}) []string {
-	tags := make([]string, len(v))
-	for i, tag := range v {
-		tags[i] = tag.(string)
-	}
-
-	return tags
-}
-
 func resourceImagesImageV2ExpandProperties(v map[string]interface{}) map[string]string {
 	properties := map[string]string{}
 	for key, value := range v {
@@ -248,6 +239,7 @@ func imagesFilterByRegex(imageArr []images.Image, name_regex string) []images.Im
 
 // v - slice of images to filter
 // p - field "properties" of schema.Resource from dataSourceImagesImageIDsV2
+//
 //	or dataSourceImagesImageV2. If p is empty no filtering applies and the
 //	function returns the v.
 func imagesFilterByProperties(v []images.Image, p map[string]string) []images.Image {
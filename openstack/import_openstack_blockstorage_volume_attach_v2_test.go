@@ -0,0 +1,43 @@
+package openstack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBlockStorageVolumeAttachV2_importBasic(t *testing.T) {
+	resourceName := "openstack_blockstorage_volume_attach_v2.va_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageVolumeAttachV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageVolumeAttachV2_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"attach_mode",
+					"data",
+					"device",
+					"driver_volume_type",
+					"initiator",
+					"ip_address",
+					"mount_point_base",
+					"multipath",
+					"os_type",
+					"platform",
+					"region",
+					"wwnn",
+					"wwpn",
+				},
+			},
+		},
+	})
+}
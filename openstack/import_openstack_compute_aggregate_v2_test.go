@@ -0,0 +1,33 @@
+package openstack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccComputeV2Aggregate_importBasic(t *testing.T) {
+	resourceName := "openstack_compute_aggregate_v2.aggregate_1"
+	var aggregateName = acctest.RandomWithPrefix("tf-acc-aggregate")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAdminOnly(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2AggregateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Aggregate_basic(aggregateName),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
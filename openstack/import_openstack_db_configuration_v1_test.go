@@ -0,0 +1,32 @@
+package openstack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatabaseV1Configuration_importBasic(t *testing.T) {
+	resourceName := "openstack_db_configuration_v1.basic"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDatabase(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabaseV1ConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseV1ConfigurationBasic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"configuration",
+					"region",
+				},
+			},
+		},
+	})
+}
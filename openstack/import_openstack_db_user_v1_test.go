@@ -0,0 +1,46 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatabaseV1User_importBasic(t *testing.T) {
+	resourceName := "openstack_db_user_v1.basic"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDatabase(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatabaseV1UserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseV1UserBasic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccDatabaseV1UserImportID(resourceName),
+				ImportStateVerifyIgnore: []string{
+					"password",
+					"region",
+				},
+			},
+		},
+	})
+}
+
+func testAccDatabaseV1UserImportID(userResource string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		user, ok := s.RootModule().Resources[userResource]
+		if !ok {
+			return "", fmt.Errorf("User not found: %s", userResource)
+		}
+
+		return fmt.Sprintf("%s/%s", user.Primary.Attributes["instance_id"], user.Primary.Attributes["name"]), nil
+	}
+}
@@ -32,3 +32,29 @@ func TestAccIdentityV3Project_importBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccIdentityV3Project_importTags(t *testing.T) {
+	resourceName := "openstack_identity_project_v3.project_1"
+	var projectName = fmt.Sprintf("ACCPTTEST-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAdminOnly(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIdentityV3ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityV3Project_update(projectName),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateCheck:  testAccCheckImportedTags("tag1", "tag2"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -33,3 +33,32 @@ func TestAccImagesImageV2_importBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccImagesImageV2_importTags(t *testing.T) {
+	resourceName := "openstack_images_image_v2.image_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImagesImageV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImagesImageV2_tags_1,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateCheck:  testAccCheckImportedTags("foo", "bar"),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"region",
+					"local_file_path",
+					"image_cache_path",
+					"image_source_url",
+					"verify_checksum",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package openstack
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetworkingV2PortSecGroupAssociate_importBasic(t *testing.T) {
+	resourceName := "openstack_networking_port_secgroup_associate_v2.port_1"
+
+	if os.Getenv("TF_ACC") != "" {
+		hiddenPort, err := testAccCheckNetworkingV2PortSecGroupCreatePort(t, "hidden_port", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer testAccCheckNetworkingV2PortSecGroupDeletePort(t, hiddenPort)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2PortSecGroupAssociateManifest_update3,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"enforce",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package openstack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccObjectStorageV1Object_importBasic(t *testing.T) {
+	resourceName := "openstack_objectstorage_object_v1.myfile"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckSwift(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckObjectStorageV1ObjectDestroy(s, "terraform/test/myfile.txt")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectStorageV1Object_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"content",
+					"region",
+				},
+			},
+		},
+	})
+}
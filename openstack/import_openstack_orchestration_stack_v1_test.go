@@ -29,3 +29,28 @@ func TestAccOrchestrationStackV1_importBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccOrchestrationStackV1_importTags(t *testing.T) {
+	resourceName := "openstack_orchestration_stack_v1.stack_4"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOrchestrationV1StackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrchestrationV1Stack_tags,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateCheck:  testAccCheckImportedTags("foo", "bar"),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"environment_opts",
+					"template_opts",
+				},
+			},
+		},
+	})
+}
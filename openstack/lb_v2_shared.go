@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -36,22 +37,22 @@ var lbSkipLBStatuses = []string{"ERROR", "ACTIVE"}
 
 // chooseLBV2Client will determine which load balacing client to use:
 // either the Octavia/LBaaS client or the Neutron/Networking v2 client.
-func chooseLBV2Client(d *schema.ResourceData, config *Config) (*gophercloud.ServiceClient, error) {
+func chooseLBV2Client(ctx context.Context, d *schema.ResourceData, config *Config) (*gophercloud.ServiceClient, error) {
 	if config.UseOctavia {
-		return config.LoadBalancerV2Client(GetRegion(d, config))
+		return config.LoadBalancerV2Client(ctx, GetRegion(d, config))
 	}
-	return config.NetworkingV2Client(GetRegion(d, config))
+	return config.NetworkingV2Client(ctx, GetRegion(d, config))
 }
 
 // chooseLBV2AccTestClient will determine which load balacing client to use:
 // either the Octavia/LBaaS client or the Neutron/Networking v2 client.
 // This is similar to the chooseLBV2Client function but specific for acceptance
 // tests.
-func chooseLBV2AccTestClient(config *Config, region string) (*gophercloud.ServiceClient, error) {
+func chooseLBV2AccTestClient(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, error) {
 	if config.UseOctavia {
-		return config.LoadBalancerV2Client(region)
+		return config.LoadBalancerV2Client(ctx, region)
 	}
-	return config.NetworkingV2Client(region)
+	return config.NetworkingV2Client(ctx, region)
 }
 
 // chooseLBV2ListenerCreateOpts will determine which load balancer listener Create options to use:
@@ -332,7 +333,7 @@ func expandLBV2ListenerHeadersMap(raw map[string]interface{}) (map[string]string
 	return m, nil
 }
 
-func waitForLBV2Listener(lbClient *gophercloud.ServiceClient, listener *neutronlisteners.Listener, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2Listener(ctx context.Context, lbClient *gophercloud.ServiceClient, listener *neutronlisteners.Listener, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for openstack_lb_listener_v2 %s to become %s.", listener.ID, target)
 
 	if len(listener.Loadbalancers) == 0 {
@@ -350,7 +351,7 @@ func waitForLBV2Listener(lbClient *gophercloud.ServiceClient, listener *neutronl
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			if target == "DELETED" {
@@ -532,7 +533,7 @@ func chooseLBV2MonitorUpdateOpts(d *schema.ResourceData, config *Config) neutron
 	return nil
 }
 
-func waitForLBV2LoadBalancer(lbClient *gophercloud.ServiceClient, lbID string, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2LoadBalancer(ctx context.Context, lbClient *gophercloud.ServiceClient, lbID string, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for loadbalancer %s to become %s.", lbID, target)
 
 	stateConf := &resource.StateChangeConf{
@@ -544,7 +545,7 @@ func waitForLBV2LoadBalancer(lbClient *gophercloud.ServiceClient, lbID string, t
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			switch target {
@@ -571,7 +572,7 @@ func resourceLBV2LoadBalancerRefreshFunc(lbClient *gophercloud.ServiceClient, id
 	}
 }
 
-func waitForLBV2Member(lbClient *gophercloud.ServiceClient, parentPool *neutronpools.Pool, member *neutronpools.Member, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2Member(ctx context.Context, lbClient *gophercloud.ServiceClient, parentPool *neutronpools.Pool, member *neutronpools.Member, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for member %s to become %s.", member.ID, target)
 
 	lbID, err := lbV2FindLBIDviaPool(lbClient, parentPool)
@@ -588,7 +589,7 @@ func waitForLBV2Member(lbClient *gophercloud.ServiceClient, parentPool *neutronp
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			if target == "DELETED" {
@@ -625,7 +626,7 @@ func resourceLBV2MemberRefreshFunc(lbClient *gophercloud.ServiceClient, lbID str
 	return resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient, lbID, "member", member.ID, poolID)
 }
 
-func waitForLBV2Monitor(lbClient *gophercloud.ServiceClient, parentPool *neutronpools.Pool, monitor *neutronmonitors.Monitor, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2Monitor(ctx context.Context, lbClient *gophercloud.ServiceClient, parentPool *neutronpools.Pool, monitor *neutronmonitors.Monitor, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for openstack_lb_monitor_v2 %s to become %s.", monitor.ID, target)
 
 	lbID, err := lbV2FindLBIDviaPool(lbClient, parentPool)
@@ -642,7 +643,7 @@ func waitForLBV2Monitor(lbClient *gophercloud.ServiceClient, parentPool *neutron
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			if target == "DELETED" {
@@ -678,7 +679,7 @@ func resourceLBV2MonitorRefreshFunc(lbClient *gophercloud.ServiceClient, lbID st
 	return resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient, lbID, "monitor", monitor.ID, "")
 }
 
-func waitForLBV2Pool(lbClient *gophercloud.ServiceClient, pool *neutronpools.Pool, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2Pool(ctx context.Context, lbClient *gophercloud.ServiceClient, pool *neutronpools.Pool, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for pool %s to become %s.", pool.ID, target)
 
 	lbID, err := lbV2FindLBIDviaPool(lbClient, pool)
@@ -695,7 +696,7 @@ func waitForLBV2Pool(lbClient *gophercloud.ServiceClient, pool *neutronpools.Poo
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			if target == "DELETED" {
@@ -879,7 +880,7 @@ func resourceLBV2L7PolicyRefreshFunc(lbClient *gophercloud.ServiceClient, lbID s
 	return resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient, lbID, "l7policy", l7policy.ID, "")
 }
 
-func waitForLBV2L7Policy(lbClient *gophercloud.ServiceClient, parentListener *neutronlisteners.Listener, l7policy *neutronl7policies.L7Policy, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2L7Policy(ctx context.Context, lbClient *gophercloud.ServiceClient, parentListener *neutronlisteners.Listener, l7policy *neutronl7policies.L7Policy, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for l7policy %s to become %s.", l7policy.ID, target)
 
 	if len(parentListener.Loadbalancers) == 0 {
@@ -897,7 +898,7 @@ func waitForLBV2L7Policy(lbClient *gophercloud.ServiceClient, parentListener *ne
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			if target == "DELETED" {
@@ -963,7 +964,7 @@ func resourceLBV2L7RuleRefreshFunc(lbClient *gophercloud.ServiceClient, lbID str
 	return resourceLBV2LoadBalancerStatusRefreshFuncNeutron(lbClient, lbID, "l7rule", l7rule.ID, l7policyID)
 }
 
-func waitForLBV2L7Rule(lbClient *gophercloud.ServiceClient, parentListener *neutronlisteners.Listener, parentL7policy *neutronl7policies.L7Policy, l7rule *neutronl7policies.Rule, target string, pending []string, timeout time.Duration) error {
+func waitForLBV2L7Rule(ctx context.Context, lbClient *gophercloud.ServiceClient, parentListener *neutronlisteners.Listener, parentL7policy *neutronl7policies.L7Policy, l7rule *neutronl7policies.Rule, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for l7rule %s to become %s.", l7rule.ID, target)
 
 	if len(parentListener.Loadbalancers) == 0 {
@@ -981,7 +982,7 @@ func waitForLBV2L7Rule(lbClient *gophercloud.ServiceClient, parentListener *neut
 		MinTimeout: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault404); ok {
 			if target == "DELETED" {
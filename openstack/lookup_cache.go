@@ -0,0 +1,162 @@
+package openstack
+
+import (
+	"log"
+	"sync"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/secgroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	flavors_utils "github.com/gophercloud/utils/openstack/compute/v2/flavors"
+	images_utils "github.com/gophercloud/utils/openstack/compute/v2/images"
+)
+
+// Kinds of objects whose lookups are cached.
+const (
+	lookupFlavor   = "flavor"
+	lookupImage    = "image"
+	lookupNetwork  = "network"
+	lookupQuota    = "quota"
+	lookupSecGroup = "secgroup"
+)
+
+type lookupKey struct {
+	kind     string
+	endpoint string
+	query    string
+}
+
+type lookupEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// lookupCache memoizes lookups of flavors, images, networks and security
+// groups by name or ID for the lifetime of the provider, which is a single
+// Terraform operation. Concurrent lookups of the same object share a single
+// request. Failed lookups are not cached.
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[lookupKey]*lookupEntry
+}
+
+// get returns the cached result of a lookup, or the result of fetch if the
+// lookup is not cached yet.
+func (c *lookupCache) get(key lookupKey, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-e.done
+		if e.err == nil {
+			return e.value, nil
+		}
+
+		// The lookup failed, try again.
+		return c.get(key, fetch)
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[lookupKey]*lookupEntry)
+	}
+	e := &lookupEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.value, e.err = fetch()
+
+	c.mu.Lock()
+	if e.err != nil && c.entries[key] == e {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(e.done)
+
+	return e.value, e.err
+}
+
+// invalidate drops the cached lookups of a kind of object. It is called when
+// the provider creates, renames or deletes an object of that kind.
+func (c *lookupCache) invalidate(kind string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.kind == kind {
+			log.Printf("[DEBUG] Invalidating cached %s lookup %q", kind, key.query)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// flavorIDFromName returns the ID of the flavor with the given name.
+func (c *Config) flavorIDFromName(client *gophercloud.ServiceClient, name string) (string, error) {
+	v, err := c.lookups.get(lookupKey{lookupFlavor, client.Endpoint, name}, func() (interface{}, error) {
+		return flavors_utils.IDFromName(client, name)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// imageIDFromName returns the ID of the image with the given name.
+func (c *Config) imageIDFromName(client *gophercloud.ServiceClient, name string) (string, error) {
+	v, err := c.lookups.get(lookupKey{lookupImage, client.Endpoint, "name:" + name}, func() (interface{}, error) {
+		return images_utils.IDFromName(client, name)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// image returns the image with the given ID.
+func (c *Config) image(client *gophercloud.ServiceClient, id string) (*images.Image, error) {
+	v, err := c.lookups.get(lookupKey{lookupImage, client.Endpoint, "id:" + id}, func() (interface{}, error) {
+		return images.Get(client, id).Extract()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*images.Image), nil
+}
+
+// instanceNetworkInfo returns the network information of an instance
+// network queried by port, network ID or network name.
+func (c *Config) instanceNetworkInfo(client *gophercloud.ServiceClient, queryType, queryTerm string, lookup func(*gophercloud.ServiceClient, string, string) (map[string]interface{}, error)) (map[string]interface{}, error) {
+	v, err := c.lookups.get(lookupKey{lookupNetwork, client.Endpoint, queryType + ":" + queryTerm}, func() (interface{}, error) {
+		return lookup(client, queryType, queryTerm)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers must not modify the cached map.
+	networkInfo := make(map[string]interface{})
+	for k, v := range v.(map[string]interface{}) {
+		networkInfo[k] = v
+	}
+
+	return networkInfo, nil
+}
+
+// computeSecGroups returns all security groups of the compute API.
+func (c *Config) computeSecGroups(client *gophercloud.ServiceClient) ([]secgroups.SecurityGroup, error) {
+	v, err := c.lookups.get(lookupKey{lookupSecGroup, client.Endpoint, ""}, func() (interface{}, error) {
+		allPages, err := secgroups.List(client).AllPages()
+		if err != nil {
+			return nil, err
+		}
+
+		return secgroups.ExtractSecurityGroups(allPages)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]secgroups.SecurityGroup), nil
+}
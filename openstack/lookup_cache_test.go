@@ -0,0 +1,108 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestLookupCache(t *testing.T) {
+	var cache lookupCache
+	var fetches int32
+
+	key := lookupKey{lookupFlavor, "https://nova/v2.1/", "m1.small"}
+	release := make(chan struct{})
+	fetch := func() (interface{}, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return "2", nil
+	}
+
+	// Concurrent lookups share a single fetch.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := cache.get(key, fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, "2", v)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	cache.invalidate(lookupImage)
+	cache.get(key, fetch)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	cache.invalidate(lookupFlavor)
+	cache.get(key, fetch)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	// Failed lookups are not cached.
+	failures := 0
+	failing := func() (interface{}, error) {
+		failures++
+		return nil, fmt.Errorf("unavailable")
+	}
+	key.query = "m1.large"
+	_, err := cache.get(key, failing)
+	assert.Error(t, err)
+	_, err = cache.get(key, failing)
+	assert.Error(t, err)
+	assert.Equal(t, 2, failures)
+}
+
+func TestConfigFlavorIDFromName(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	computeClient, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+
+	flavorLists := func() int {
+		var n int
+		for _, r := range srv.Requests() {
+			if r.Method == "GET" && r.Path == "/compute/v2.1/flavors/detail" {
+				n++
+			}
+		}
+		return n
+	}
+
+	for i := 0; i < 3; i++ {
+		id, err := config.flavorIDFromName(computeClient, fakeopenstack.FlavorName)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", id)
+	}
+	assert.Equal(t, 1, flavorLists())
+
+	// Creating a flavor invalidates the cached lookups.
+	flavor := resourceComputeFlavorV2()
+	d := testFakeResourceData(t, flavor, map[string]interface{}{
+		"name":  "m1.custom",
+		"ram":   1024,
+		"vcpus": 1,
+		"disk":  10,
+	})
+	if diags := flavor.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error creating flavor: %v", diags)
+	}
+
+	id, err := config.flavorIDFromName(computeClient, "m1.custom")
+	assert.NoError(t, err)
+	assert.Equal(t, d.Id(), id)
+	_, err = config.flavorIDFromName(computeClient, fakeopenstack.FlavorName)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flavorLists())
+}
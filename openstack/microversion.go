@@ -0,0 +1,281 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// microversionRange is the range of microversions supported by a service.
+// An empty max means that the range is unknown, e.g. because the service
+// does not support version discovery.
+type microversionRange struct {
+	min string
+	max string
+}
+
+// supports returns whether microversion v is within the range. Everything is
+// assumed to be supported if the range is unknown.
+func (r microversionRange) supports(v string) bool {
+	if r.max == "" {
+		return true
+	}
+
+	return compareMicroversions(v, r.min) >= 0 && compareMicroversions(v, r.max) <= 0
+}
+
+// microversionRequirement is a resource attribute which needs at least the
+// given microversion.
+type microversionRequirement struct {
+	attribute    string
+	microversion string
+}
+
+// resourceGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff, so that the microversion requirements of a resource
+// are computed the same way at plan and apply time.
+type resourceGetter interface {
+	Get(string) interface{}
+}
+
+// parseMicroversion parses a "major.minor" microversion.
+func parseMicroversion(v string) (int, int, error) {
+	parts := strings.SplitN(v, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("Invalid microversion: %q", v)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid microversion: %q", v)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid microversion: %q", v)
+	}
+
+	return major, minor, nil
+}
+
+// compareMicroversions returns -1, 0 or 1 if a is lower than, equal to or
+// higher than b. Invalid microversions are lower than valid ones.
+func compareMicroversions(a, b string) int {
+	aMajor, aMinor, aErr := parseMicroversion(a)
+	bMajor, bMinor, bErr := parseMicroversion(b)
+
+	switch {
+	case aErr != nil && bErr != nil:
+		return 0
+	case aErr != nil:
+		return -1
+	case bErr != nil:
+		return 1
+	case aMajor != bMajor:
+		if aMajor < bMajor {
+			return -1
+		}
+		return 1
+	case aMinor != bMinor:
+		if aMinor < bMinor {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// highestMicroversion returns the requirement with the highest microversion.
+func highestMicroversion(requirements []microversionRequirement) microversionRequirement {
+	var highest microversionRequirement
+	for _, r := range requirements {
+		if compareMicroversions(r.microversion, highest.microversion) > 0 {
+			highest = r
+		}
+	}
+
+	return highest
+}
+
+var versionSegment = regexp.MustCompile(`^v(\d+)(\.\d+)?$`)
+
+// microversionDiscoveryURL returns the URL of the version document of a
+// service endpoint, which is the endpoint up to its version, e.g.
+// https://cinder/v3/ for https://cinder/v3/<project_id>/.
+func microversionDiscoveryURL(endpoint string) (string, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if m := versionSegment.FindStringSubmatch(segments[i]); m != nil {
+			u.Path = "/" + strings.Join(segments[:i+1], "/") + "/"
+			return u.String(), m[1], nil
+		}
+	}
+
+	return "", "", fmt.Errorf("No API version in endpoint %s", endpoint)
+}
+
+type microversionDocument struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	MinVersion string `json:"min_version"`
+	Version    string `json:"version"`
+}
+
+// discoverMicroversions reads the microversions supported by the service of
+// client from its version document.
+func discoverMicroversions(client *gophercloud.ServiceClient) (microversionRange, error) {
+	discoveryURL, major, err := microversionDiscoveryURL(client.Endpoint)
+	if err != nil {
+		return microversionRange{}, err
+	}
+
+	// The version document does not depend on the requested microversion.
+	c := *client
+	c.Microversion = ""
+
+	var body struct {
+		Version  *microversionDocument  `json:"version"`
+		Versions []microversionDocument `json:"versions"`
+	}
+	_, err = c.Get(discoveryURL, &body, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 300},
+	})
+	if err != nil {
+		return microversionRange{}, err
+	}
+
+	doc := body.Version
+	for i, v := range body.Versions {
+		if m := versionSegment.FindStringSubmatch(v.ID); m != nil && m[1] == major {
+			doc = &body.Versions[i]
+			break
+		}
+	}
+	if doc == nil || doc.Version == "" {
+		return microversionRange{}, fmt.Errorf("No microversions advertised at %s", discoveryURL)
+	}
+
+	return microversionRange{min: doc.MinVersion, max: doc.Version}, nil
+}
+
+// serviceMicroversions returns the microversions supported by the service of
+// client. They are discovered once per endpoint and run. The range is
+// unknown if the discovery fails.
+func (c *Config) serviceMicroversions(client *gophercloud.ServiceClient) microversionRange {
+	c.microversionMutex.Lock()
+	defer c.microversionMutex.Unlock()
+
+	key, _, err := microversionDiscoveryURL(client.Endpoint)
+	if err != nil {
+		key = client.Endpoint
+	}
+
+	if r, ok := c.microversions[key]; ok {
+		return r
+	}
+
+	r, err := discoverMicroversions(client)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to discover the microversions of %s: %s", key, err)
+	} else {
+		log.Printf("[DEBUG] %s supports microversions %s to %s", key, r.min, r.max)
+	}
+
+	if c.microversions == nil {
+		c.microversions = make(map[string]microversionRange)
+	}
+	c.microversions[key] = r
+
+	return r
+}
+
+// checkMicroversions returns an error if the service of client does not
+// support the microversions needed by the requirements.
+func (c *Config) checkMicroversions(client *gophercloud.ServiceClient, requirements ...microversionRequirement) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	r := c.serviceMicroversions(client)
+	for _, req := range requirements {
+		if !r.supports(req.microversion) {
+			return fmt.Errorf("%s requires %s API microversion %s, but the cloud supports microversions %s to %s",
+				req.attribute, client.Type, req.microversion, r.min, r.max)
+		}
+	}
+
+	return nil
+}
+
+// setMicroversion sets the microversion of client to the highest microversion
+// needed by the requirements, after checking that the service supports it.
+// The microversion of client is not changed if there are no requirements.
+func (c *Config) setMicroversion(client *gophercloud.ServiceClient, requirements ...microversionRequirement) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	if err := c.checkMicroversions(client, requirements...); err != nil {
+		return err
+	}
+
+	highest := highestMicroversion(requirements)
+	if compareMicroversions(highest.microversion, client.Microversion) > 0 {
+		client.Microversion = highest.microversion
+	}
+
+	return nil
+}
+
+// microversionClient returns a shallow copy of client with the microversion
+// set like setMicroversion does. Calls which need a microversion use the
+// copy, so that the other calls of an operation keep the microversion of
+// client.
+func (c *Config) microversionClient(client *gophercloud.ServiceClient, requirements ...microversionRequirement) (*gophercloud.ServiceClient, error) {
+	mc := *client
+	if err := c.setMicroversion(&mc, requirements...); err != nil {
+		return nil, err
+	}
+
+	return &mc, nil
+}
+
+// customizeDiffMicroversions returns a CustomizeDiffFunc which fails the plan
+// if the cloud does not support the microversions needed by the configured
+// attributes. The cloud is only contacted if there are any requirements.
+func customizeDiffMicroversions(
+	newClient func(*Config, context.Context, string) (*gophercloud.ServiceClient, error),
+	requirements func(*schema.ResourceDiff) []microversionRequirement,
+) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		required := requirements(diff)
+		if len(required) == 0 {
+			return nil
+		}
+
+		config := meta.(*Config)
+		region := config.Region
+		if v, ok := diff.GetOk("region"); ok {
+			region = v.(string)
+		}
+
+		client, err := newClient(config, ctx, region)
+		if err != nil {
+			return fmt.Errorf("Error creating OpenStack client: %s", err)
+		}
+
+		return config.checkMicroversions(client, required...)
+	}
+}
@@ -0,0 +1,116 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestCompareMicroversions(t *testing.T) {
+	assert.Equal(t, 0, compareMicroversions("2.52", "2.52"))
+	assert.Equal(t, -1, compareMicroversions("2.9", "2.10"))
+	assert.Equal(t, 1, compareMicroversions("3.0", "2.99"))
+	assert.Equal(t, 1, compareMicroversions("2.1", ""))
+
+	highest := highestMicroversion([]microversionRequirement{
+		{"tags", "2.52"},
+		{"block_device.volume_type", "2.67"},
+		{"network_mode auto", "2.37"},
+	})
+	assert.Equal(t, "block_device.volume_type", highest.attribute)
+}
+
+func TestMicroversionRangeSupports(t *testing.T) {
+	r := microversionRange{min: "2.1", max: "2.60"}
+	assert.True(t, r.supports("2.60"))
+	assert.False(t, r.supports("2.67"))
+	assert.False(t, r.supports("1.0"))
+
+	assert.True(t, microversionRange{}.supports("2.67"))
+}
+
+func TestMicroversionDiscoveryURL(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"https://nova:8774/v2.1/":                 "https://nova:8774/v2.1/",
+		"https://cloud/volume/v3/a1b2c3/":         "https://cloud/volume/v3/",
+		"https://manila:8786/v2/a1b2c3":           "https://manila:8786/v2/",
+		"https://cloud/compute/v2.1/a1b2c3/extra": "https://cloud/compute/v2.1/",
+	} {
+		u, _, err := microversionDiscoveryURL(endpoint)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, u)
+	}
+
+	_, _, err := microversionDiscoveryURL("https://cloud/compute/")
+	assert.Error(t, err)
+}
+
+func TestConfigMicroversions(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+	srv.VolumeMicroversion = "3.40"
+
+	computeClient, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	r := config.serviceMicroversions(computeClient)
+	assert.Equal(t, microversionRange{min: "2.1", max: fakeopenstack.DefaultComputeMicroversion}, r)
+
+	// The discovery is cached.
+	config.serviceMicroversions(computeClient)
+	var discoveries int
+	for _, r := range srv.Requests() {
+		if r.Method == "GET" && r.Path == "/compute/v2.1/" {
+			discoveries++
+		}
+	}
+	assert.Equal(t, 1, discoveries)
+
+	// The highest required microversion is used.
+	err = config.setMicroversion(computeClient,
+		microversionRequirement{"network_mode none", "2.37"},
+		microversionRequirement{"tags", "2.52"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.52", computeClient.Microversion)
+
+	// A microversion client leaves the microversion of its client alone.
+	computeClient.Microversion = ""
+	rebuildClient, err := config.microversionClient(computeClient, microversionRequirement{"key_pair", "2.54"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.54", rebuildClient.Microversion)
+	assert.Equal(t, "", computeClient.Microversion)
+
+	blockStorageClient, err := config.BlockStorageV3Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack block storage client: %s", err)
+	}
+	err = config.setMicroversion(blockStorageClient, microversionRequirement{"enable_online_resize", "3.42"})
+	assert.EqualError(t, err, "enable_online_resize requires volumev3 API microversion 3.42, but the cloud supports microversions 3.0 to 3.40")
+	assert.Equal(t, "", blockStorageClient.Microversion)
+}
+
+func TestComputeInstanceV2CustomizeDiffMicroversions(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+	srv.ComputeMicroversion = "2.50"
+
+	raw := map[string]interface{}{
+		"name":         "instance_1",
+		"image_name":   fakeopenstack.ImageName,
+		"flavor_id":    "2",
+		"network_mode": "none",
+	}
+
+	instance := resourceComputeInstanceV2()
+	_, err := instance.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+
+	raw["tags"] = []interface{}{"foo"}
+	_, err = instance.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "tags requires compute API microversion 2.52, but the cloud supports microversions 2.1 to 2.50")
+}
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gophercloud/gophercloud"
@@ -30,9 +31,9 @@ type networkExtended struct {
 }
 
 // networkingNetworkV2ID retrieves network ID by the provided name.
-func networkingNetworkV2ID(d *schema.ResourceData, meta interface{}, networkName string) (string, error) {
+func networkingNetworkV2ID(ctx context.Context, d *schema.ResourceData, meta interface{}, networkName string) (string, error) {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
 		return "", fmt.Errorf("Error creating OpenStack network client: %s", err)
 	}
@@ -61,9 +62,9 @@ func networkingNetworkV2ID(d *schema.ResourceData, meta interface{}, networkName
 }
 
 // networkingNetworkV2Name retrieves network name by the provided ID.
-func networkingNetworkV2Name(d *schema.ResourceData, meta interface{}, networkID string) (string, error) {
+func networkingNetworkV2Name(ctx context.Context, d *schema.ResourceData, meta interface{}, networkID string) (string, error) {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
 		return "", fmt.Errorf("Error creating OpenStack network client: %s", err)
 	}
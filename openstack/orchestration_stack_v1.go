@@ -61,6 +61,9 @@ func orchestrationStackV1StateRefreshFunc(client *gophercloud.ServiceClient, sta
 		}
 
 		if strings.Contains(stack.Status, "FAILED") {
+			if stack.StatusReason != "" {
+				return stack, stack.Status, fmt.Errorf("The stack is in error status: %s", stack.StatusReason)
+			}
 			return stack, stack.Status, fmt.Errorf("The stack is in error status. " +
 				"Please check with your cloud admin or check the orchestration " +
 				"API logs to see why this error occurred.")
@@ -2,9 +2,12 @@ package openstack
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 
 	"github.com/gophercloud/utils/terraform/auth"
@@ -15,12 +18,6 @@ import (
 // This is a global MutexKV for use within this plugin.
 var osMutexKV = mutexkv.NewMutexKV()
 
-// Use openstackbase.Config as the base/foundation of this provider's
-// Config struct.
-type Config struct {
-	auth.Config
-}
-
 // Provider returns a schema.Provider for OpenStack.
 func Provider() *schema.Provider {
 	provider := &schema.Provider{
@@ -249,6 +246,322 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["disable_no_cache_header"],
 			},
+
+			"token_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_TOKEN_CACHE", false),
+				Description: descriptions["token_cache"],
+			},
+
+			"token_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_TOKEN_CACHE_DIR", ""),
+				Description: descriptions["token_cache_dir"],
+			},
+
+			"token_cache_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_TOKEN_CACHE_KEY", ""),
+				Description: descriptions["token_cache_key"],
+			},
+
+			"system_scope": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_SYSTEM_SCOPE", false),
+				Description: descriptions["system_scope"],
+			},
+
+			"passcode": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("OS_PASSCODE", ""),
+				Description:   descriptions["passcode"],
+				ConflictsWith: []string{"totp_secret"},
+			},
+
+			"totp_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("OS_TOTP_SECRET", ""),
+				Description:   descriptions["totp_secret"],
+				ConflictsWith: []string{"passcode"},
+			},
+
+			"auth_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("OS_AUTH_TYPE", ""),
+				ValidateFunc: validation.StringInSlice(authTypes, false),
+				Description:  descriptions["auth_type"],
+			},
+
+			"identity_provider": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_IDENTITY_PROVIDER", ""),
+				Description: descriptions["identity_provider"],
+			},
+
+			"protocol": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_PROTOCOL", ""),
+				Description: descriptions["protocol"],
+			},
+
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_CLIENT_ID", ""),
+				Description: descriptions["client_id"],
+			},
+
+			"client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_CLIENT_SECRET", ""),
+				Description: descriptions["client_secret"],
+			},
+
+			"discovery_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_DISCOVERY_ENDPOINT", ""),
+				Description: descriptions["discovery_endpoint"],
+			},
+
+			"access_token_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_ACCESS_TOKEN_ENDPOINT", ""),
+				Description: descriptions["access_token_endpoint"],
+			},
+
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_ACCESS_TOKEN", ""),
+				Description: descriptions["access_token"],
+			},
+
+			"openid_scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_OPENID_SCOPE", ""),
+				Description: descriptions["openid_scope"],
+			},
+
+			"identity_provider_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_IDENTITY_PROVIDER_URL", ""),
+				Description: descriptions["identity_provider_url"],
+			},
+
+			"auth_command": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["auth_command"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "1m",
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
+			},
+
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_HTTP_PROXY", ""),
+				Description: descriptions["http_proxy"],
+			},
+
+			"https_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_HTTPS_PROXY", ""),
+				Description: descriptions["https_proxy"],
+			},
+
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_NO_PROXY", ""),
+				Description: descriptions["no_proxy"],
+			},
+
+			"quota_preflight": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("OS_QUOTA_PREFLIGHT", ""),
+				ValidateFunc: validation.StringInSlice([]string{quotaPreflightWarn, quotaPreflightError}, false),
+				Description:  descriptions["quota_preflight"],
+			},
+
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  descriptions["max_requests_per_second"],
+			},
+
+			"max_in_flight_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_in_flight_requests"],
+			},
+
+			"service_request_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["service_request_limit"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(serviceNames, false),
+						},
+
+						"max_requests_per_second": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+						},
+
+						"max_in_flight_requests": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+
+			"api_log": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["api_log"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"services": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(serviceNames, false),
+							},
+						},
+
+						"file": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"log_bodies": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["default_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status_codes": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(400, 599),
+							},
+						},
+
+						"min_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "1s",
+							ValidateFunc: validateDuration,
+						},
+
+						"max_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "30s",
+							ValidateFunc: validateDuration,
+						},
+
+						"max_elapsed_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "1m",
+							ValidateFunc: validateDuration,
+						},
+
+						"retry_post": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -257,6 +570,7 @@ func Provider() *schema.Provider {
 			"openstack_blockstorage_snapshot_v3":                 dataSourceBlockStorageSnapshotV3(),
 			"openstack_blockstorage_volume_v2":                   dataSourceBlockStorageVolumeV2(),
 			"openstack_blockstorage_volume_v3":                   dataSourceBlockStorageVolumeV3(),
+			"openstack_compute_aggregate_v2":                     systemScoped(dataSourceComputeAggregateV2()),
 			"openstack_compute_availability_zones_v2":            dataSourceComputeAvailabilityZonesV2(),
 			"openstack_compute_instance_v2":                      dataSourceComputeInstanceV2(),
 			"openstack_compute_flavor_v2":                        dataSourceComputeFlavorV2(),
@@ -269,8 +583,8 @@ func Provider() *schema.Provider {
 			"openstack_identity_project_v3":                      dataSourceIdentityProjectV3(),
 			"openstack_identity_user_v3":                         dataSourceIdentityUserV3(),
 			"openstack_identity_auth_scope_v3":                   dataSourceIdentityAuthScopeV3(),
-			"openstack_identity_endpoint_v3":                     dataSourceIdentityEndpointV3(),
-			"openstack_identity_service_v3":                      dataSourceIdentityServiceV3(),
+			"openstack_identity_endpoint_v3":                     systemScoped(dataSourceIdentityEndpointV3()),
+			"openstack_identity_service_v3":                      systemScoped(dataSourceIdentityServiceV3()),
 			"openstack_identity_group_v3":                        dataSourceIdentityGroupV3(),
 			"openstack_images_image_v2":                          dataSourceImagesImageV2(),
 			"openstack_images_image_ids_v2":                      dataSourceImagesImageIDsV2(),
@@ -297,21 +611,22 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"openstack_blockstorage_quotaset_v2":                 resourceBlockStorageQuotasetV2(),
-			"openstack_blockstorage_quotaset_v3":                 resourceBlockStorageQuotasetV3(),
+			"openstack_blockstorage_quotaset_v2":                 systemScoped(resourceBlockStorageQuotasetV2()),
+			"openstack_blockstorage_quotaset_v3":                 systemScoped(resourceBlockStorageQuotasetV3()),
 			"openstack_blockstorage_volume_v1":                   resourceBlockStorageVolumeV1(),
 			"openstack_blockstorage_volume_v2":                   resourceBlockStorageVolumeV2(),
 			"openstack_blockstorage_volume_v3":                   resourceBlockStorageVolumeV3(),
 			"openstack_blockstorage_volume_attach_v2":            resourceBlockStorageVolumeAttachV2(),
 			"openstack_blockstorage_volume_attach_v3":            resourceBlockStorageVolumeAttachV3(),
-			"openstack_compute_flavor_v2":                        resourceComputeFlavorV2(),
-			"openstack_compute_flavor_access_v2":                 resourceComputeFlavorAccessV2(),
+			"openstack_compute_aggregate_v2":                     systemScoped(resourceComputeAggregateV2()),
+			"openstack_compute_flavor_v2":                        systemScoped(resourceComputeFlavorV2()),
+			"openstack_compute_flavor_access_v2":                 systemScoped(resourceComputeFlavorAccessV2()),
 			"openstack_compute_instance_v2":                      resourceComputeInstanceV2(),
 			"openstack_compute_interface_attach_v2":              resourceComputeInterfaceAttachV2(),
 			"openstack_compute_keypair_v2":                       resourceComputeKeypairV2(),
 			"openstack_compute_secgroup_v2":                      resourceComputeSecGroupV2(),
 			"openstack_compute_servergroup_v2":                   resourceComputeServerGroupV2(),
-			"openstack_compute_quotaset_v2":                      resourceComputeQuotasetV2(),
+			"openstack_compute_quotaset_v2":                      systemScoped(resourceComputeQuotasetV2()),
 			"openstack_compute_floatingip_v2":                    resourceComputeFloatingIPV2(),
 			"openstack_compute_floatingip_associate_v2":          resourceComputeFloatingIPAssociateV2(),
 			"openstack_compute_volume_attach_v2":                 resourceComputeVolumeAttachV2(),
@@ -326,11 +641,11 @@ func Provider() *schema.Provider {
 			"openstack_fw_firewall_v1":                           resourceFWFirewallV1(),
 			"openstack_fw_policy_v1":                             resourceFWPolicyV1(),
 			"openstack_fw_rule_v1":                               resourceFWRuleV1(),
-			"openstack_identity_endpoint_v3":                     resourceIdentityEndpointV3(),
+			"openstack_identity_endpoint_v3":                     systemScoped(resourceIdentityEndpointV3()),
 			"openstack_identity_project_v3":                      resourceIdentityProjectV3(),
 			"openstack_identity_role_v3":                         resourceIdentityRoleV3(),
 			"openstack_identity_role_assignment_v3":              resourceIdentityRoleAssignmentV3(),
-			"openstack_identity_service_v3":                      resourceIdentityServiceV3(),
+			"openstack_identity_service_v3":                      systemScoped(resourceIdentityServiceV3()),
 			"openstack_identity_user_v3":                         resourceIdentityUserV3(),
 			"openstack_identity_group_v3":                        resourceIdentityGroupV3(),
 			"openstack_identity_application_credential_v3":       resourceIdentityApplicationCredentialV3(),
@@ -360,7 +675,7 @@ func Provider() *schema.Provider {
 			"openstack_networking_qos_dscp_marking_rule_v2":      resourceNetworkingQoSDSCPMarkingRuleV2(),
 			"openstack_networking_qos_minimum_bandwidth_rule_v2": resourceNetworkingQoSMinimumBandwidthRuleV2(),
 			"openstack_networking_qos_policy_v2":                 resourceNetworkingQoSPolicyV2(),
-			"openstack_networking_quota_v2":                      resourceNetworkingQuotaV2(),
+			"openstack_networking_quota_v2":                      systemScoped(resourceNetworkingQuotaV2()),
 			"openstack_networking_router_v2":                     resourceNetworkingRouterV2(),
 			"openstack_networking_router_interface_v2":           resourceNetworkingRouterInterfaceV2(),
 			"openstack_networking_router_route_v2":               resourceNetworkingRouterRouteV2(),
@@ -390,6 +705,13 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	for _, r := range provider.DataSourcesMap {
+		apiErrorDiagnostics(r)
+	}
+	for _, r := range provider.ResourcesMap {
+		apiErrorDiagnostics(r)
+	}
+
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -475,12 +797,70 @@ func init() {
 			"from the Keystone catalog",
 
 		"disable_no_cache_header": "If set to `true`, the HTTP `Cache-Control: no-cache` header will not be added by default to all API requests.",
+
+		"token_cache": "If set to `true`, Keystone tokens are cached on disk and reused by later runs until shortly before they expire.",
+
+		"token_cache_dir": "The directory of the token cache.",
+
+		"token_cache_key": "A key to encrypt the cached tokens with.",
+
+		"system_scope": "If set to `true`, cloud-admin resources such as endpoints, flavors and quotas\n" +
+			"use a system-scoped token, while the other resources stay project-scoped.",
+
+		"passcode": "A TOTP passcode for multi-factor authentication along with the password.",
+
+		"totp_secret": "The base32 TOTP secret to generate passcodes for multi-factor authentication from.",
+
+		"auth_type": "The authentication type. The federated types `v3oidcpassword`, `v3oidcclientcredentials`,\n" +
+			"`v3oidcaccesstoken` and `v3samlpassword` authenticate at an identity provider.",
+
+		"identity_provider": "The name of the identity provider in Keystone for federated authentication.",
+
+		"protocol": "The federation protocol of the identity provider in Keystone, e.g. `openid` or `saml2`.",
+
+		"client_id": "The OpenID Connect client ID.",
+
+		"client_secret": "The OpenID Connect client secret.",
+
+		"discovery_endpoint": "The OpenID Connect discovery endpoint of the identity provider.",
+
+		"access_token_endpoint": "The OpenID Connect token endpoint of the identity provider, if it is not discovered.",
+
+		"access_token": "An OpenID Connect access token for the `v3oidcaccesstoken` auth type.",
+
+		"openid_scope": "The OpenID Connect scope requested from the identity provider. Defaults to `openid`.",
+
+		"identity_provider_url": "The SAML2 ECP endpoint of the identity provider for the `v3samlpassword` auth type.",
+
+		"auth_command": "An external program printing the credentials to authenticate with as JSON.\n" +
+			"It runs again whenever the provider re-authenticates.",
+
+		"http_proxy": "The proxy for HTTP requests, e.g. `http://proxy.example.com:3128` or `socks5://proxy.example.com:1080`.",
+
+		"https_proxy": "The proxy for HTTPS requests, e.g. `http://proxy.example.com:3128` or `socks5://proxy.example.com:1080`.",
+
+		"no_proxy": "A comma-separated list of hosts, domains, IP addresses and CIDR ranges which are not proxied.",
+
+		"quota_preflight": "Check while planning whether the planned instances, ports, floating IPs and volumes fit into\n" +
+			"the quotas of the project. One of `warn` or `error`. `warn` does not show in the plan, it only writes to the log.",
+
+		"max_requests_per_second": "The maximum number of API requests sent per second.",
+
+		"max_in_flight_requests": "The maximum number of API requests waiting for a response at the same time.",
+
+		"service_request_limit": "Request limits for the API requests sent to a single OpenStack service.",
+
+		"retry": "The policy to retry API requests failing with a transient error.",
+
+		"api_log": "Log API requests as JSON lines with redacted bodies.",
+
+		"default_tags": "Tags merged into the tags of all resources supporting tags.",
 	}
 }
 
 func configureProvider(d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	config := Config{
-		auth.Config{
+		Config: auth.Config{
 			CACertFile:                  d.Get("cacert_file").(string),
 			ClientCertFile:              d.Get("cert").(string),
 			ClientKeyFile:               d.Get("key").(string),
@@ -514,6 +894,28 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 			TerraformVersion:            terraformVersion,
 			SDKVersion:                  meta.SDKVersionString(),
 		},
+		TokenCache:          d.Get("token_cache").(bool),
+		TokenCacheDir:       d.Get("token_cache_dir").(string),
+		TokenCacheKey:       d.Get("token_cache_key").(string),
+		SystemScope:         d.Get("system_scope").(bool),
+		Passcode:            d.Get("passcode").(string),
+		TOTPSecret:          d.Get("totp_secret").(string),
+		AuthType:            d.Get("auth_type").(string),
+		IdentityProvider:    d.Get("identity_provider").(string),
+		Protocol:            d.Get("protocol").(string),
+		ClientID:            d.Get("client_id").(string),
+		ClientSecret:        d.Get("client_secret").(string),
+		DiscoveryEndpoint:   d.Get("discovery_endpoint").(string),
+		AccessTokenEndpoint: d.Get("access_token_endpoint").(string),
+		AccessToken:         d.Get("access_token").(string),
+		OpenIDScope:         d.Get("openid_scope").(string),
+		IdentityProviderURL: d.Get("identity_provider_url").(string),
+		Proxy: ProxyConfig{
+			HTTPProxy:  d.Get("http_proxy").(string),
+			HTTPSProxy: d.Get("https_proxy").(string),
+			NoProxy:    d.Get("no_proxy").(string),
+		},
+		QuotaPreflight: d.Get("quota_preflight").(string),
 	}
 
 	v, ok := d.GetOkExists("insecure")
@@ -522,9 +924,95 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 		config.Insecure = &insecure
 	}
 
+	if v, ok := d.GetOk("auth_command"); ok {
+		authCommand := v.([]interface{})[0].(map[string]interface{})
+		config.AuthCommand = expandProviderAuthCommand(authCommand)
+	}
+
+	config.RequestLimit = RequestLimit{
+		RequestsPerSecond: d.Get("max_requests_per_second").(float64),
+		MaxInFlight:       d.Get("max_in_flight_requests").(int),
+	}
+
+	config.ServiceRequestLimits = make(map[string]RequestLimit)
+	for _, v := range d.Get("service_request_limit").([]interface{}) {
+		limit := v.(map[string]interface{})
+		service := limit["service"].(string)
+		if _, ok := config.ServiceRequestLimits[service]; ok {
+			return nil, diag.Errorf("Duplicate service_request_limit for service %s", service)
+		}
+
+		config.ServiceRequestLimits[service] = RequestLimit{
+			RequestsPerSecond: limit["max_requests_per_second"].(float64),
+			MaxInFlight:       limit["max_in_flight_requests"].(int),
+		}
+	}
+
+	// Requests are only retried when a retry block is configured.
+	if v, ok := d.GetOk("retry"); ok {
+		config.RetryPolicy = defaultRetryPolicy()
+		retry := v.([]interface{})[0].(map[string]interface{})
+		if err := expandProviderRetryPolicy(retry, &config.RetryPolicy); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
+	if v, ok := d.GetOk("api_log"); ok {
+		config.APILog.Enabled = true
+		if apiLog, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			config.APILog.Services = expandToStringSlice(apiLog["services"].(*schema.Set).List())
+			config.APILog.File = apiLog["file"].(string)
+			config.APILog.Bodies = apiLog["log_bodies"].(bool)
+		}
+	}
+
+	if v, ok := d.GetOk("default_tags"); ok {
+		if defaultTags, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			config.DefaultTags = expandToStringSlice(defaultTags["tags"].(*schema.Set).List())
+		}
+	}
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, diag.FromErr(err)
 	}
 
 	return &config, nil
 }
+
+func expandProviderAuthCommand(authCommand map[string]interface{}) AuthCommand {
+	env := make(map[string]string)
+	for k, v := range authCommand["env"].(map[string]interface{}) {
+		env[k] = v.(string)
+	}
+
+	// The timeout has been validated by the schema.
+	timeout, _ := time.ParseDuration(authCommand["timeout"].(string))
+
+	return AuthCommand{
+		Command: authCommand["command"].(string),
+		Args:    expandToStringSlice(authCommand["args"].([]interface{})),
+		Env:     env,
+		Timeout: timeout,
+	}
+}
+
+func expandProviderRetryPolicy(retry map[string]interface{}, policy *RetryPolicy) error {
+	if codes := retry["status_codes"].([]interface{}); len(codes) > 0 {
+		policy.StatusCodes = make([]int, len(codes))
+		for i, code := range codes {
+			policy.StatusCodes[i] = code.(int)
+		}
+	}
+
+	// The durations have been validated by the schema.
+	policy.MinBackoff, _ = time.ParseDuration(retry["min_backoff"].(string))
+	policy.MaxBackoff, _ = time.ParseDuration(retry["max_backoff"].(string))
+	policy.MaxElapsedTime, _ = time.ParseDuration(retry["max_elapsed_time"].(string))
+	policy.RetryPOST = retry["retry_post"].(bool)
+
+	if policy.MinBackoff > policy.MaxBackoff {
+		return fmt.Errorf("retry min_backoff must not be greater than max_backoff")
+	}
+
+	return nil
+}
@@ -0,0 +1,678 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/aggregates"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/bootfromvolume"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+// testFakeProvider configures the provider against an in-process fake cloud.
+func testFakeProvider(t *testing.T) (*fakeopenstack.Server, *Config) {
+	srv := fakeopenstack.New()
+
+	return srv, testFakeProviderConfig(t, srv, nil)
+}
+
+// testFakeProviderConfig configures the provider against srv with additional
+// provider arguments.
+func testFakeProviderConfig(t *testing.T, srv *fakeopenstack.Server, raw map[string]interface{}) *Config {
+	c := map[string]interface{}{
+		"auth_url":    srv.AuthURL(),
+		"user_name":   srv.Username,
+		"password":    srv.Password,
+		"tenant_name": srv.ProjectName,
+		"region":      srv.Region,
+	}
+	for k, v := range raw {
+		c[k] = v
+	}
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(c))
+	if diags.HasError() {
+		srv.Close()
+		t.Fatalf("Error configuring provider: %v", diags)
+	}
+
+	return p.Meta().(*Config)
+}
+
+// testFakeResourceData builds resource data for a resource from raw values.
+func testFakeResourceData(t *testing.T, r *schema.Resource, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, r.Schema, raw)
+}
+
+// testFakeProviderFactories returns the provider factories for a
+// resource.UnitTest which runs Terraform against srv. The provider defaults
+// to the credentials of srv instead of the OS_* environment variables.
+func testFakeProviderFactories(srv *fakeopenstack.Server) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"openstack": func() (*schema.Provider, error) {
+			p := Provider()
+			for k, v := range map[string]string{
+				"auth_url":    srv.AuthURL(),
+				"user_name":   srv.Username,
+				"password":    srv.Password,
+				"tenant_name": srv.ProjectName,
+				"region":      srv.Region,
+			} {
+				p.Schema[k].DefaultFunc = schema.EnvDefaultFunc("", v)
+			}
+
+			return p, nil
+		},
+	}
+}
+
+// testFakePreCheck skips a resource.UnitTest against the fake cloud unless
+// a Terraform binary is available, which the test would download otherwise.
+// Unlike testAccPreCheck it needs no OS_* environment variables.
+func testFakePreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Terraform is not installed, set TF_ACC_TERRAFORM_PATH to run this test")
+	}
+}
+
+// testFakeCheckAttr checks an attribute of the object of a resource in the
+// fake cloud.
+func testFakeCheckAttr(srv *fakeopenstack.Server, kind fakeopenstack.Kind, name, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		for _, obj := range srv.Objects(kind) {
+			if obj["id"] != rs.Primary.ID {
+				continue
+			}
+			var v interface{} = obj
+			for _, part := range strings.Split(key, ".") {
+				m, _ := v.(map[string]interface{})
+				v = m[part]
+			}
+			if fmt.Sprint(v) != value {
+				return fmt.Errorf("%s: %s of %s is %v, expected %s", name, key, rs.Primary.ID, v, value)
+			}
+			return nil
+		}
+
+		return fmt.Errorf("%s: %s not found in the fake cloud", name, rs.Primary.ID)
+	}
+}
+
+// testFakeCheckDestroy checks that the objects of the resources of a type
+// are gone from the fake cloud.
+func testFakeCheckDestroy(srv *fakeopenstack.Server, resourceType string, kind fakeopenstack.Kind) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			for _, obj := range srv.Objects(kind) {
+				if obj["id"] == rs.Primary.ID {
+					return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestFakeProviderConfigure(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	assert.Equal(t, srv.Region, config.Region)
+
+	computeClient, err := config.ComputeV2Client(context.TODO(), srv.Region)
+	assert.NoError(t, err)
+	assert.Equal(t, srv.Endpoint("compute")+"/", computeClient.Endpoint)
+
+	networkClient, err := config.NetworkingV2Client(context.TODO(), srv.Region)
+	assert.NoError(t, err)
+	assert.Equal(t, srv.Endpoint("network")+"/v2.0/", networkClient.ResourceBase)
+}
+
+func TestFakeNetworkingV2Network_basic(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakePreCheck(t) },
+		ProviderFactories: testFakeProviderFactories(srv),
+		CheckDestroy:      testFakeCheckDestroy(srv, "openstack_networking_network_v2", fakeopenstack.NetworkNetworks),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2Network_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"openstack_networking_network_v2.network_1", "name", "network_1"),
+					testFakeCheckAttr(srv, fakeopenstack.NetworkNetworks,
+						"openstack_networking_network_v2.network_1", "description", "my network description"),
+				),
+			},
+			{
+				Config: testAccNetworkingV2Network_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"openstack_networking_network_v2.network_1", "description", ""),
+					testFakeCheckAttr(srv, fakeopenstack.NetworkNetworks,
+						"openstack_networking_network_v2.network_1", "name", "network_2"),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeComputeV2Instance_basic(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakePreCheck(t) },
+		ProviderFactories: testFakeProviderFactories(srv),
+		CheckDestroy:      testFakeCheckDestroy(srv, "openstack_compute_instance_v2", fakeopenstack.ComputeServers),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeComputeV2InstanceBasic("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"openstack_compute_instance_v2.instance_1", "power_state", "active"),
+					resource.TestCheckResourceAttrSet(
+						"openstack_compute_instance_v2.instance_1", "network.0.fixed_ip_v4"),
+					testFakeCheckAttr(srv, fakeopenstack.ComputeServers,
+						"openstack_compute_instance_v2.instance_1", "metadata.foo", "bar"),
+				),
+			},
+			{
+				Config: testFakeComputeV2InstanceBasic("baz"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"openstack_compute_instance_v2.instance_1", "all_metadata.foo", "baz"),
+					testFakeCheckAttr(srv, fakeopenstack.ComputeServers,
+						"openstack_compute_instance_v2.instance_1", "metadata.foo", "baz"),
+				),
+			},
+		},
+	})
+}
+
+func testFakeComputeV2InstanceBasic(foo string) string {
+	return fmt.Sprintf(`
+resource "openstack_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "openstack_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${openstack_networking_network_v2.network_1.id}"
+}
+
+resource "openstack_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  image_name = "%s"
+  flavor_id = "2"
+  metadata = {
+    foo = "%s"
+  }
+  network {
+    uuid = "${openstack_networking_subnet_v2.subnet_1.network_id}"
+  }
+}
+`, fakeopenstack.ImageName, foo)
+}
+
+func TestFakeComputeKeypairV2(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	keypair := resourceComputeKeypairV2()
+	d := testFakeResourceData(t, keypair, map[string]interface{}{
+		"name":       "keypair_1",
+		"public_key": "ssh-rsa AAAAB3NzaC1yc2E fake",
+	})
+	if diags := keypair.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error creating keypair: %v", diags)
+	}
+
+	assert.Equal(t, "keypair_1", d.Id())
+	assert.NotEmpty(t, d.Get("fingerprint"))
+
+	if diags := keypair.DeleteContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error deleting keypair: %v", diags)
+	}
+	assert.Empty(t, srv.Objects(fakeopenstack.ComputeKeypairs))
+}
+
+func TestFakeComputeAggregateV2(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	aggregate := resourceComputeAggregateV2()
+	d := testFakeResourceData(t, aggregate, map[string]interface{}{
+		"name":              "aggregate_1",
+		"availability_zone": "az_1",
+		"hosts":             []interface{}{"host_1", "host_2"},
+		"metadata": map[string]interface{}{
+			"ssd":  "true",
+			"rack": "r1",
+		},
+	})
+	if diags := aggregate.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error creating aggregate: %v", diags)
+	}
+
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, "az_1", d.Get("availability_zone"))
+	assert.ElementsMatch(t, []interface{}{"host_1", "host_2"}, d.Get("hosts").(*schema.Set).List())
+	// The availability zone is not reported as metadata.
+	assert.Equal(t, map[string]interface{}{"ssd": "true", "rack": "r1"}, d.Get("metadata"))
+
+	computeClient, err := config.ComputeV2Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	if err := computeAggregateV2UpdateHosts(computeClient, 1, []string{"host_1", "host_2"}, []string{"host_2", "host_3"}); err != nil {
+		t.Fatalf("Error updating aggregate hosts: %s", err)
+	}
+	metadata := computeAggregateV2MetadataChanges(d.Get("metadata").(map[string]interface{}), map[string]interface{}{"ssd": "false"})
+	assert.Equal(t, map[string]interface{}{"ssd": "false", "rack": nil}, metadata)
+	if _, err := aggregates.SetMetadata(computeClient, 1, aggregates.SetMetadataOpts{Metadata: metadata}).Extract(); err != nil {
+		t.Fatalf("Error updating aggregate metadata: %s", err)
+	}
+	if err := computeAggregateV2Update(computeClient, 1, "aggregate_1", ""); err != nil {
+		t.Fatalf("Error updating aggregate: %s", err)
+	}
+
+	dataSource := dataSourceComputeAggregateV2()
+	ds := testFakeResourceData(t, dataSource, map[string]interface{}{
+		"name": "aggregate_1",
+	})
+	if diags := dataSource.ReadContext(context.Background(), ds, config); diags.HasError() {
+		t.Fatalf("Error reading aggregate data source: %v", diags)
+	}
+
+	assert.Equal(t, d.Id(), ds.Id())
+	assert.Equal(t, "", ds.Get("availability_zone"))
+	assert.ElementsMatch(t, []interface{}{"host_2", "host_3"}, ds.Get("hosts").(*schema.Set).List())
+	assert.Equal(t, map[string]interface{}{"ssd": "false"}, ds.Get("metadata"))
+
+	// Aggregates with hosts are emptied before they are deleted.
+	if diags := aggregate.DeleteContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error deleting aggregate: %v", diags)
+	}
+	assert.Empty(t, srv.Objects(fakeopenstack.ComputeAggregates))
+}
+
+func TestFakeComputeInstanceV2Read(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	networkClient, err := config.NetworkingV2Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack networking client: %s", err)
+	}
+	computeClient, err := config.ComputeV2Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+
+	network, err := networks.Create(networkClient, networks.CreateOpts{Name: "network_1"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating network: %s", err)
+	}
+	_, err = subnets.Create(networkClient, subnets.CreateOpts{
+		NetworkID: network.ID,
+		CIDR:      "192.168.199.0/24",
+		IPVersion: 4,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating subnet: %s", err)
+	}
+
+	image := srv.Objects(fakeopenstack.ImageImages)[0]
+	server, err := servers.Create(computeClient, servers.CreateOpts{
+		Name:      "instance_1",
+		ImageRef:  image["id"].(string),
+		FlavorRef: "2",
+		Networks:  []servers.Network{{UUID: network.ID}},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating server: %s", err)
+	}
+	if err := srv.SetStatus(fakeopenstack.ComputeServers, server.ID, "ACTIVE"); err != nil {
+		t.Fatal(err)
+	}
+
+	instance := resourceComputeInstanceV2()
+	d := testFakeResourceData(t, instance, map[string]interface{}{
+		"network": []interface{}{
+			map[string]interface{}{"uuid": network.ID},
+		},
+	})
+	d.SetId(server.ID)
+
+	if diags := instance.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading instance: %v", diags)
+	}
+
+	assert.Equal(t, "instance_1", d.Get("name"))
+	assert.Equal(t, "active", d.Get("power_state"))
+	assert.Equal(t, image["id"], d.Get("image_id"))
+	assert.Equal(t, fakeopenstack.FlavorName, d.Get("flavor_name"))
+	assert.Equal(t, "192.168.199.2", d.Get("access_ip_v4"))
+	assert.Equal(t, false, d.Get("locked"))
+
+	// The refresh reflects the state changes made outside of Terraform.
+	if err := computeV2InstanceAction(computeClient, d, "lock"); err != nil {
+		t.Fatalf("Error locking server: %s", err)
+	}
+	if err := srv.SetStatus(fakeopenstack.ComputeServers, server.ID, "RESCUE"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := instance.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading instance: %v", diags)
+	}
+	assert.Equal(t, "rescued", d.Get("power_state"))
+	assert.Equal(t, true, d.Get("locked"))
+}
+
+func TestFakeComputeInstanceV2UpdateLocked(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	networkClient, err := config.NetworkingV2Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack networking client: %s", err)
+	}
+	computeClient, err := config.ComputeV2Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+
+	network, err := networks.Create(networkClient, networks.CreateOpts{Name: "network_1"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating network: %s", err)
+	}
+	_, err = subnets.Create(networkClient, subnets.CreateOpts{
+		NetworkID: network.ID,
+		CIDR:      "192.168.199.0/24",
+		IPVersion: 4,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating subnet: %s", err)
+	}
+
+	image := srv.Objects(fakeopenstack.ImageImages)[0]
+	server, err := servers.Create(computeClient, servers.CreateOpts{
+		Name:      "instance_1",
+		ImageRef:  image["id"].(string),
+		FlavorRef: "2",
+		Networks:  []servers.Network{{UUID: network.ID}},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating server: %s", err)
+	}
+	if err := srv.SetStatus(fakeopenstack.ComputeServers, server.ID, "ACTIVE"); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":   name,
+			"locked": true,
+			"network": []interface{}{
+				map[string]interface{}{"uuid": network.ID},
+			},
+		}
+	}
+	instance := resourceComputeInstanceV2()
+	d := testFakeResourceData(t, instance, raw("instance_1"))
+	d.SetId(server.ID)
+	if err := computeV2InstanceAction(computeClient, d, "lock"); err != nil {
+		t.Fatalf("Error locking server: %s", err)
+	}
+	if diags := instance.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading instance: %v", diags)
+	}
+	state := d.State()
+
+	lockActions := func() []string {
+		var actions []string
+		for _, r := range srv.Requests() {
+			if r.Method == "POST" && r.Path == "/compute/v2.1/servers/"+server.ID+"/action" {
+				for _, action := range []string{"unlock", "lock"} {
+					if strings.Contains(string(r.Body), `"`+action+`"`) {
+						actions = append(actions, action)
+						break
+					}
+				}
+			}
+		}
+		return actions
+	}
+	locked := func() bool {
+		for _, obj := range srv.Objects(fakeopenstack.ComputeServers) {
+			if obj["id"] == server.ID {
+				return obj["locked"] == true
+			}
+		}
+		return false
+	}
+	apply := func(raw map[string]interface{}) diag.Diagnostics {
+		diff, err := instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("Error diffing instance: %s", err)
+		}
+		_, diags := instance.Apply(context.Background(), state, diff, config)
+		return diags
+	}
+
+	// An update not changing the server leaves it locked.
+	noop := raw("instance_1")
+	noop["stop_before_destroy"] = true
+	assert.False(t, apply(noop).HasError())
+	assert.Equal(t, []string{"lock"}, lockActions())
+
+	// A failed update locks the instance again.
+	srv.AddFault(fakeopenstack.Fault{Method: "PUT", Path: "/servers/" + server.ID, Status: 500, Times: 1})
+	assert.True(t, apply(raw("instance_2")).HasError())
+	assert.Equal(t, []string{"lock", "unlock", "lock"}, lockActions())
+	assert.True(t, locked())
+
+	assert.False(t, apply(raw("instance_2")).HasError())
+	assert.Equal(t, []string{"lock", "unlock", "lock", "unlock", "lock"}, lockActions())
+	assert.True(t, locked())
+}
+
+func TestFakeComputeInstanceV2BlockDevices(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	computeClient, err := config.ComputeV2Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(context.TODO(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	data, err := volumes.Create(blockStorageClient, volumes.CreateOpts{Name: "data", Size: 1}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating volume: %s", err)
+	}
+	extra, err := volumes.Create(blockStorageClient, volumes.CreateOpts{Name: "extra", Size: 1}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating volume: %s", err)
+	}
+	for _, id := range []string{data.ID, extra.ID} {
+		if err := srv.SetStatus(fakeopenstack.VolumeVolumes, id, "available"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	image := srv.Objects(fakeopenstack.ImageImages)[0]
+	blockDevice := func(sourceType, uuid string, size, bootIndex int, destinationType string) map[string]interface{} {
+		return map[string]interface{}{
+			"source_type":      sourceType,
+			"uuid":             uuid,
+			"volume_size":      size,
+			"boot_index":       bootIndex,
+			"destination_type": destinationType,
+		}
+	}
+	blockDevices := []interface{}{
+		blockDevice("image", image["id"].(string), 0, 0, "local"),
+		blockDevice("blank", "", 1, -1, "volume"),
+		blockDevice("blank", "", 1, -1, "volume"),
+		blockDevice("blank", "", 2, -1, "volume"),
+		blockDevice("volume", data.ID, 0, -1, "volume"),
+	}
+	raw := map[string]interface{}{
+		"name":         "instance_1",
+		"flavor_id":    "2",
+		"network_mode": "none",
+		"block_device": blockDevices,
+	}
+
+	instance := resourceComputeInstanceV2()
+	d := testFakeResourceData(t, instance, raw)
+	createOpts, err := resourceInstanceBlockDevicesV2(d, d.Get("block_device").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := bootfromvolume.Create(computeClient, bootfromvolume.CreateOptsExt{
+		CreateOptsBuilder: servers.CreateOpts{
+			Name:      "instance_1",
+			ImageRef:  image["id"].(string),
+			FlavorRef: "2",
+			Networks:  "none",
+		},
+		BlockDevice: createOpts,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating server: %s", err)
+	}
+	if err := srv.SetStatus(fakeopenstack.ComputeServers, server.ID, "ACTIVE"); err != nil {
+		t.Fatal(err)
+	}
+
+	var blank string
+	for _, v := range srv.Objects(fakeopenstack.VolumeVolumes) {
+		if fmt.Sprint(v["size"]) == "2" {
+			blank = v["id"].(string)
+		}
+	}
+
+	// Only the volumes which can be told apart are recorded.
+	d.SetId(server.ID)
+	if err := computeV2InstanceSetBlockDeviceVolumeIDs(context.Background(), computeClient, d, config); err != nil {
+		t.Fatalf("Error recording volumes: %s", err)
+	}
+	volumeIDs := func() []string {
+		var volumeIDs []string
+		for _, bd := range d.Get("block_device").([]interface{}) {
+			volumeIDs = append(volumeIDs, bd.(map[string]interface{})["volume_id"].(string))
+		}
+		return volumeIDs
+	}
+	assert.Equal(t, []string{"", "", "", blank, data.ID}, volumeIDs())
+
+	// Volumes which are not recorded yet are looked up when the instance is
+	// read.
+	if err := d.Set("block_device", blockDevices); err != nil {
+		t.Fatal(err)
+	}
+	if diags := instance.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Error reading instance: %v", diags)
+	}
+	assert.Equal(t, []string{"", "", "", blank, data.ID}, volumeIDs())
+	state := d.State()
+
+	apply := func(blockDevices ...interface{}) {
+		raw["block_device"] = blockDevices
+		diff, err := instance.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("Error diffing instance: %s", err)
+		}
+		assert.False(t, diff.RequiresNew())
+		var diags diag.Diagnostics
+		if state, diags = instance.Apply(context.Background(), state, diff, config); diags.HasError() {
+			t.Fatalf("Error updating instance: %v", diags)
+		}
+	}
+
+	// A volume without a boot index is attached.
+	dataVolume := blockDevice("volume", extra.ID, 0, 0, "volume")
+	delete(dataVolume, "boot_index")
+	apply(append(blockDevices, dataVolume)...)
+	assert.Equal(t, extra.ID, state.Attributes["block_device.5.volume_id"])
+
+	// A block device is detached by its volume ID and the volume is kept.
+	apply(blockDevices[0], blockDevices[1], blockDevices[2], blockDevices[4], dataVolume)
+	var detached []string
+	for _, r := range srv.Requests() {
+		if r.Method == "DELETE" && strings.Contains(r.Path, "/os-volume_attachments/") {
+			detached = append(detached, r.Path[strings.LastIndex(r.Path, "/")+1:])
+		}
+	}
+	assert.Equal(t, []string{blank}, detached)
+	_, err = volumes.Get(blockStorageClient, blank).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, data.ID, state.Attributes["block_device.3.volume_id"])
+	assert.Equal(t, extra.ID, state.Attributes["block_device.4.volume_id"])
+}
+
+func TestFakeComputeInstanceV2CreateCancel(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	// The instance never leaves BUILD, so only the cancellation can end
+	// the wait.
+	srv.SetLifecycle(fakeopenstack.ComputeServers, fakeopenstack.Lifecycle{
+		Create: []string{"BUILD"},
+	})
+
+	instance := resourceComputeInstanceV2()
+	d := testFakeResourceData(t, instance, map[string]interface{}{
+		"name":         "instance_1",
+		"image_name":   fakeopenstack.ImageName,
+		"flavor_id":    "2",
+		"network_mode": "none",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	diags := instance.CreateContext(ctx, d, config)
+	assert.True(t, diags.HasError())
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
@@ -0,0 +1,242 @@
+package openstack
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	osClient "github.com/gophercloud/utils/client"
+	"gopkg.in/yaml.v2"
+)
+
+// ProxyConfig selects the proxies of the requests sent by the provider,
+// independent of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables of the process. The environment is used if none is set.
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+
+	// NoProxy is a comma-separated list of hosts, domains, IP addresses
+	// and CIDR ranges which are connected to directly.
+	NoProxy string
+}
+
+func (p ProxyConfig) isSet() bool {
+	return p.HTTPProxy != "" || p.HTTPSProxy != "" || p.NoProxy != ""
+}
+
+// loadCloudProxy sets the proxies from the clouds.yaml and secure.yaml
+// entries of the cloud, unless they have been set in the provider
+// configuration.
+func (c *Config) loadCloudProxy() error {
+	for _, content := range readCloudsYAML() {
+		var clouds struct {
+			Clouds map[string]struct {
+				HTTPProxy  string `yaml:"http_proxy"`
+				HTTPSProxy string `yaml:"https_proxy"`
+				NoProxy    string `yaml:"no_proxy"`
+			} `yaml:"clouds"`
+		}
+		if err := yaml.Unmarshal(content, &clouds); err != nil {
+			return fmt.Errorf("Error parsing clouds.yaml: %s", err)
+		}
+
+		cloud, ok := clouds.Clouds[c.Cloud]
+		if !ok {
+			continue
+		}
+		if c.Proxy.HTTPProxy == "" {
+			c.Proxy.HTTPProxy = cloud.HTTPProxy
+		}
+		if c.Proxy.HTTPSProxy == "" {
+			c.Proxy.HTTPSProxy = cloud.HTTPSProxy
+		}
+		if c.Proxy.NoProxy == "" {
+			c.Proxy.NoProxy = cloud.NoProxy
+		}
+	}
+
+	return nil
+}
+
+// setProxy installs the proxies in the HTTP transport of the base Config
+// and keeps them for the other HTTP clients of the provider, see
+// httpClient.
+func (c *Config) setProxy() error {
+	proxy, err := newProxyFunc(c.Proxy)
+	if err != nil {
+		return err
+	}
+	c.proxy = proxy
+
+	if rt, ok := c.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok {
+		if transport, ok := rt.Rt.(*http.Transport); ok {
+			transport.Proxy = proxy
+		}
+	}
+
+	return nil
+}
+
+// httpClient returns a client for HTTP requests which are no API requests,
+// such as image downloads. It uses the proxies of the provider.
+func (c *Config) httpClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.proxy != nil {
+		transport.Proxy = c.proxy
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// proxyURLSchemes are the proxy schemes supported by net/http.
+var proxyURLSchemes = []string{"http", "https", "socks5"}
+
+// parseProxyURL parses a proxy URL. A URL without a scheme is an HTTP
+// proxy, as with the environment variables.
+func parseProxyURL(proxy string) (*url.URL, error) {
+	rawURL := proxy
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid proxy URL %q: %s", proxy, err)
+	}
+
+	if !strSliceContains(proxyURLSchemes, u.Scheme) {
+		return nil, fmt.Errorf("Invalid proxy URL %q: the scheme must be one of %s", proxy, strings.Join(proxyURLSchemes, ", "))
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("Invalid proxy URL %q: the host is missing", proxy)
+	}
+
+	return u, nil
+}
+
+// newProxyFunc returns the proxy function of an HTTP transport. Unlike
+// http.ProxyFromEnvironment, it also proxies requests to localhost unless
+// no_proxy says otherwise.
+func newProxyFunc(p ProxyConfig) (func(*http.Request) (*url.URL, error), error) {
+	if !p.isSet() {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxies := make(map[string]*url.URL)
+	for scheme, proxy := range map[string]string{"http": p.HTTPProxy, "https": p.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		u, err := parseProxyURL(proxy)
+		if err != nil {
+			return nil, err
+		}
+		proxies[scheme] = u
+	}
+
+	noProxy, err := parseNoProxy(p.NoProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		proxy := proxies[req.URL.Scheme]
+		if proxy == nil || noProxy.match(req.URL) {
+			return nil, nil
+		}
+
+		return proxy, nil
+	}, nil
+}
+
+// noProxyList are the exceptions of no_proxy.
+type noProxyList struct {
+	all      bool
+	networks []*net.IPNet
+	ips      []net.IP
+	domains  []noProxyDomain
+}
+
+// noProxyDomain matches a host and its subdomains, on any port if port is
+// empty.
+type noProxyDomain struct {
+	name string
+	port string
+}
+
+// parseNoProxy parses a comma-separated no_proxy list. Entries are host
+// names, which match their subdomains as well and may have a port, IP
+// addresses, CIDR ranges or *.
+func parseNoProxy(noProxy string) (*noProxyList, error) {
+	l := &noProxyList{}
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			l.all = true
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			l.networks = append(l.networks, network)
+			continue
+		}
+
+		host, port := entry, ""
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			host, port = h, p
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			l.ips = append(l.ips, ip)
+			continue
+		}
+
+		host = strings.TrimPrefix(strings.TrimPrefix(host, "*"), ".")
+		if host == "" {
+			return nil, fmt.Errorf("Invalid no_proxy entry %q", entry)
+		}
+		l.domains = append(l.domains, noProxyDomain{name: host, port: port})
+	}
+
+	return l, nil
+}
+
+// match returns true if u is connected to directly.
+func (l *noProxyList) match(u *url.URL) bool {
+	if l.all {
+		return true
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range l.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		for _, i := range l.ips {
+			if i.Equal(ip) {
+				return true
+			}
+		}
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	for _, d := range l.domains {
+		if d.port != "" && d.port != port {
+			continue
+		}
+		if host == d.name || strings.HasSuffix(host, "."+d.name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,230 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+// testProxy is a forward HTTP proxy recording the hosts it is asked for.
+type testProxy struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	hosts map[string]int
+}
+
+func newTestProxy() *testProxy {
+	p := &testProxy{hosts: make(map[string]int)}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.hosts[r.URL.Host]++
+		p.mu.Unlock()
+
+		r.RequestURI = ""
+		resp, err := http.DefaultTransport.RoundTrip(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+
+	return p
+}
+
+func (p *testProxy) requests(host string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.hosts[host]
+}
+
+func TestParseProxyURL(t *testing.T) {
+	for proxy, expected := range map[string]string{
+		"proxy.example.com:3128":          "http://proxy.example.com:3128",
+		"http://proxy.example.com:3128":   "http://proxy.example.com:3128",
+		"https://proxy.example.com":       "https://proxy.example.com",
+		"socks5://user:pw@proxy:1080":     "socks5://user:pw@proxy:1080",
+		"ftp://proxy.example.com:21":      "Invalid proxy URL \"ftp://proxy.example.com:21\": the scheme must be one of http, https, socks5",
+		"http://":                         "Invalid proxy URL \"http://\": the host is missing",
+		"socks5://proxy.example.com:1080": "socks5://proxy.example.com:1080",
+	} {
+		u, err := parseProxyURL(proxy)
+		if err != nil {
+			assert.EqualError(t, err, expected)
+			continue
+		}
+		assert.Equal(t, expected, u.String())
+	}
+}
+
+func TestNoProxyMatch(t *testing.T) {
+	l, err := parseNoProxy("example.com, .internal, *.svc.local, 10.0.0.0/8, 192.168.1.1, keystone.cloud:5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for rawURL, expected := range map[string]bool{
+		"https://example.com/v3":             true,
+		"https://nova.example.com:8774/v2.1": true,
+		"https://notexample.com":             false,
+		"http://glance.internal:9292":        true,
+		"https://api.svc.local":              true,
+		"http://10.1.2.3:9696":               true,
+		"http://11.1.2.3:9696":               false,
+		"http://192.168.1.1":                 true,
+		"https://keystone.cloud:5000/v3":     true,
+		"https://keystone.cloud/v3":          false,
+	} {
+		u, _ := url.Parse(rawURL)
+		assert.Equal(t, expected, l.match(u), rawURL)
+	}
+
+	l, _ = parseNoProxy("*")
+	u, _ := url.Parse("https://anything.example.com")
+	assert.True(t, l.match(u))
+
+	_, err = parseNoProxy("*.")
+	assert.EqualError(t, err, "Invalid no_proxy entry \"*.\"")
+}
+
+func TestNewProxyFunc(t *testing.T) {
+	proxy, err := newProxyFunc(ProxyConfig{
+		HTTPSProxy: "socks5://proxy.example.com:1080",
+		NoProxy:    "internal",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for rawURL, expected := range map[string]string{
+		"https://keystone.example.com:5000": "socks5://proxy.example.com:1080",
+		"http://keystone.example.com:5000":  "",
+		"https://keystone.internal:5000":    "",
+	} {
+		req, _ := http.NewRequest("GET", rawURL, nil)
+		u, err := proxy(req)
+		assert.NoError(t, err)
+		if expected == "" {
+			assert.Nil(t, u, rawURL)
+		} else if assert.NotNil(t, u, rawURL) {
+			assert.Equal(t, expected, u.String(), rawURL)
+		}
+	}
+}
+
+func TestConfigProxy(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+
+	proxy := newTestProxy()
+	defer proxy.Close()
+
+	image := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "image")
+	}))
+	defer image.Close()
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"http_proxy": proxy.URL,
+	})
+
+	client, err := config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+
+	cloudURL, _ := url.Parse(srv.URL)
+	cloudHost := cloudURL.Host
+	assert.NotZero(t, proxy.requests(cloudHost))
+
+	// The image downloader uses the proxy as well.
+	dir, err := ioutil.TempDir("", "image-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := testFakeResourceData(t, resourceImagesImageV2(), map[string]interface{}{
+		"name":             "image",
+		"image_source_url": image.URL + "/image.img",
+		"image_cache_path": dir,
+	})
+	filename, err := resourceImagesImageV2File(config.httpClient(), d)
+	if assert.NoError(t, err) {
+		assert.Equal(t, dir, filepath.Dir(filename))
+	}
+	assert.Equal(t, 1, proxy.requests(image.Listener.Addr().String()))
+
+	// no_proxy connects to the cloud directly.
+	direct := newTestProxy()
+	defer direct.Close()
+
+	config = testFakeProviderConfig(t, srv, map[string]interface{}{
+		"http_proxy": direct.URL,
+		"no_proxy":   "127.0.0.1",
+	})
+	client, err = config.ComputeV2Client(context.Background(), srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	_, err = flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+	assert.Zero(t, direct.requests(cloudHost))
+}
+
+func TestConfigCloudProxy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clouds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	clouds := `clouds:
+  proxied:
+    https_proxy: socks5://proxy.example.com:1080
+    no_proxy: internal
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+`
+	path := filepath.Join(dir, "clouds.yaml")
+	if err := ioutil.WriteFile(path, []byte(clouds), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("OS_CLIENT_CONFIG_FILE", path)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+
+	config := &Config{Proxy: ProxyConfig{NoProxy: "example.com"}}
+	config.Cloud = "proxied"
+	if err := config.loadCloudProxy(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Provider arguments take precedence.
+	assert.Equal(t, ProxyConfig{
+		HTTPSProxy: "socks5://proxy.example.com:1080",
+		NoProxy:    "example.com",
+	}, config.Proxy)
+}
@@ -0,0 +1,489 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	computequotasets "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Quota preflight modes. The plan fails if it would exceed a quota in
+// quotaPreflightError mode. In quotaPreflightWarn mode a warning is only
+// written to the log, since the SDK cannot return warnings from a diff.
+const (
+	quotaPreflightWarn  = "warn"
+	quotaPreflightError = "error"
+)
+
+// quotaResource is a quota checked before apply. Its name is the one used
+// by the quota API of its service.
+type quotaResource struct {
+	service string
+	name    string
+}
+
+var (
+	quotaInstances   = quotaResource{serviceCompute, "instances"}
+	quotaCores       = quotaResource{serviceCompute, "cores"}
+	quotaRAM         = quotaResource{serviceCompute, "ram"}
+	quotaPorts       = quotaResource{serviceNetwork, "port"}
+	quotaFloatingIPs = quotaResource{serviceNetwork, "floatingip"}
+	quotaVolumes     = quotaResource{serviceBlockStorage, "volumes"}
+	quotaGigabytes   = quotaResource{serviceBlockStorage, "gigabytes"}
+)
+
+// quotaRequest holds the amounts of quotas a planned change requests.
+type quotaRequest map[quotaResource]int
+
+// quotaUsage is the usage of a quota. A negative limit is unlimited.
+type quotaUsage struct {
+	limit int
+	used  int
+}
+
+// quotaPlan holds the quotas requested by the changes planned in a run, per
+// region and change.
+//
+// Terraform plans each resource instance once per run of a provider, so
+// the diff of every new resource is a change of its own, even if it is
+// identical to another one, like the instances of a count. Changes of
+// existing resources are identified by their ID, so a repeated diff of the
+// same resource replaces its previous request.
+type quotaPlan struct {
+	mu        sync.Mutex
+	requested map[string]map[string]quotaRequest
+	created   int
+}
+
+// set records the request of a change and returns the total requested by
+// all changes in the region.
+func (p *quotaPlan) set(region, change string, request quotaRequest) quotaRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.requested == nil {
+		p.requested = make(map[string]map[string]quotaRequest)
+	}
+	if p.requested[region] == nil {
+		p.requested[region] = make(map[string]quotaRequest)
+	}
+	p.requested[region][change] = request
+
+	total := make(quotaRequest)
+	for _, r := range p.requested[region] {
+		for quota, amount := range r {
+			total[quota] += amount
+		}
+	}
+
+	return total
+}
+
+// change returns the key of a planned change of a resource. The SDK does
+// not pass the address of the resource, so existing resources are
+// identified by their ID and every new resource gets a key of its own.
+func (p *quotaPlan) change(typeName string, diff *schema.ResourceDiff) string {
+	if id := diff.Id(); id != "" {
+		return typeName + " " + id
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.created++
+
+	return fmt.Sprintf("%s new %d", typeName, p.created)
+}
+
+// customizeDiffQuotas returns a CustomizeDiffFunc which checks that the
+// project has enough quota left for the change and the other changes
+// planned so far. It does nothing unless quota_preflight is set.
+//
+// request returns the quotas requested by the change, or by a new resource
+// if create is true. typeName is the type of the resource, whose schema is
+// returned by resource.
+// forcesNew returns true if a CustomizeDiffFunc of the resource forces a new
+// resource, in addition to the attributes of its schema.
+func customizeDiffQuotas(
+	typeName string,
+	resource func() *schema.Resource,
+	request func(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string, create bool) (quotaRequest, error),
+	forcesNew ...func(*schema.ResourceDiff) bool,
+) schema.CustomizeDiffFunc {
+	var once sync.Once
+	var resourceSchema map[string]*schema.Schema
+
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		config := meta.(*Config)
+		if config.QuotaPreflight == "" {
+			return nil
+		}
+
+		region := config.Region
+		if v, ok := diff.GetOk("region"); ok {
+			region = v.(string)
+		}
+
+		once.Do(func() { resourceSchema = resource().Schema })
+		create := diff.Id() == "" || quotaDiffForcesNew(resourceSchema, diff, forcesNew...)
+
+		r, err := request(ctx, diff, config, region, create)
+		if err != nil {
+			return err
+		}
+		for quota, amount := range r {
+			if amount <= 0 {
+				delete(r, quota)
+			}
+		}
+
+		return config.checkQuotas(ctx, region, config.quotaPlan.change(typeName, diff), r)
+	}
+}
+
+// quotaDiffForcesNew returns true if an attribute of the diff forces a new
+// resource.
+func quotaDiffForcesNew(schemaMap map[string]*schema.Schema, diff *schema.ResourceDiff, forcesNew ...func(*schema.ResourceDiff) bool) bool {
+	for _, f := range forcesNew {
+		if f(diff) {
+			return true
+		}
+	}
+
+	for _, key := range diff.GetChangedKeysPrefix("") {
+		m := schemaMap
+		for _, part := range strings.Split(key, ".") {
+			s, ok := m[part]
+			if !ok {
+				// Skip list and set indexes and counts.
+				continue
+			}
+			if s.ForceNew {
+				return true
+			}
+			r, ok := s.Elem.(*schema.Resource)
+			if !ok {
+				break
+			}
+			m = r.Schema
+		}
+	}
+
+	return false
+}
+
+// checkQuotas records the request of a change in the plan and fails if the
+// plan exceeds a quota of the project.
+func (c *Config) checkQuotas(ctx context.Context, region, change string, request quotaRequest) error {
+	total := c.quotaPlan.set(region, change, request)
+	if len(request) == 0 {
+		return nil
+	}
+
+	usage, projectID, err := c.quotaUsage(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	var exceeded []string
+	for quota, requested := range total {
+		u, ok := usage[quota]
+		if !ok || u.limit < 0 || u.used+requested <= u.limit {
+			continue
+		}
+		exceeded = append(exceeded, fmt.Sprintf("%s %s: %d requested by the plan, %d of %d used",
+			quota.service, quota.name, requested, u.used, u.limit))
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	sort.Strings(exceeded)
+
+	msg := fmt.Sprintf("The plan exceeds the quota of project %s in region %s: %s", projectID, region, strings.Join(exceeded, "; "))
+	if c.QuotaPreflight == quotaPreflightWarn {
+		log.Printf("[WARN] %s", msg)
+		return nil
+	}
+
+	return fmt.Errorf("%s", msg)
+}
+
+// quotaUsage returns the usage of the quotas of the project in a region.
+//
+// The usage is read once per run from all services at the same time, so
+// that objects created during the run are not counted twice, once as usage
+// and once as part of the plan. Quotas of services which cannot be read
+// are not checked.
+func (c *Config) quotaUsage(ctx context.Context, region string) (map[quotaResource]quotaUsage, string, error) {
+	identityClient, err := c.IdentityV3Client(ctx, region)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error creating OpenStack identity client: %s", err)
+	}
+	_, projectID, err := GetTokenInfo(identityClient)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error determining the project of the token: %s", err)
+	}
+
+	v, err := c.lookups.get(lookupKey{lookupQuota, region, projectID}, func() (interface{}, error) {
+		usage := make(map[quotaResource]quotaUsage)
+		for service, read := range map[string]func(context.Context, string, string, map[quotaResource]quotaUsage) error{
+			serviceCompute:      c.computeQuotaUsage,
+			serviceNetwork:      c.networkQuotaUsage,
+			serviceBlockStorage: c.blockStorageQuotaUsage,
+		} {
+			if err := read(ctx, region, projectID, usage); err != nil {
+				log.Printf("[WARN] Unable to check the %s quotas of project %s: %s", service, projectID, err)
+			}
+		}
+
+		return usage, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return v.(map[quotaResource]quotaUsage), projectID, nil
+}
+
+func (c *Config) computeQuotaUsage(ctx context.Context, region, projectID string, usage map[quotaResource]quotaUsage) error {
+	client, err := c.ComputeV2Client(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	q, err := computequotasets.GetDetail(client, projectID).Extract()
+	if err != nil {
+		return err
+	}
+
+	for quota, detail := range map[quotaResource]computequotasets.QuotaDetail{
+		quotaInstances: q.Instances,
+		quotaCores:     q.Cores,
+		quotaRAM:       q.RAM,
+	} {
+		usage[quota] = quotaUsage{limit: detail.Limit, used: detail.InUse + detail.Reserved}
+	}
+
+	return nil
+}
+
+func (c *Config) networkQuotaUsage(ctx context.Context, region, projectID string, usage map[quotaResource]quotaUsage) error {
+	client, err := c.NetworkingV2Client(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	// The quota details extension is not part of gophercloud yet.
+	var body struct {
+		Quota map[string]struct {
+			Used     int `json:"used"`
+			Reserved int `json:"reserved"`
+			Limit    int `json:"limit"`
+		} `json:"quota"`
+	}
+	if _, err := client.Get(client.ServiceURL("quotas", projectID, "details.json"), &body, nil); err != nil {
+		return err
+	}
+
+	for _, quota := range []quotaResource{quotaPorts, quotaFloatingIPs} {
+		if detail, ok := body.Quota[quota.name]; ok {
+			usage[quota] = quotaUsage{limit: detail.Limit, used: detail.Used + detail.Reserved}
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) blockStorageQuotaUsage(ctx context.Context, region, projectID string, usage map[quotaResource]quotaUsage) error {
+	client, err := c.BlockStorageV3Client(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	q, err := quotasets.GetUsage(client, projectID).Extract()
+	if err != nil {
+		return err
+	}
+
+	for quota, detail := range map[quotaResource]quotasets.QuotaUsage{
+		quotaVolumes:   q.Volumes,
+		quotaGigabytes: q.Gigabytes,
+	} {
+		usage[quota] = quotaUsage{limit: detail.Limit, used: detail.InUse + detail.Reserved + detail.Allocated}
+	}
+
+	return nil
+}
+
+// flavor returns the flavor with the given ID.
+func (c *Config) flavor(client *gophercloud.ServiceClient, id string) (*flavors.Flavor, error) {
+	v, err := c.lookups.get(lookupKey{lookupFlavor, client.Endpoint, "id:" + id}, func() (interface{}, error) {
+		return flavors.Get(client, id).Extract()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*flavors.Flavor), nil
+}
+
+// quotaProjectMismatch returns true if a resource is created in another
+// project than the one of the token, whose quotas are not checked.
+func quotaProjectMismatch(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string) (bool, error) {
+	tenantID := diff.Get("tenant_id").(string)
+	if tenantID == "" {
+		return false, nil
+	}
+
+	identityClient, err := config.IdentityV3Client(ctx, region)
+	if err != nil {
+		return false, fmt.Errorf("Error creating OpenStack identity client: %s", err)
+	}
+	_, projectID, err := GetTokenInfo(identityClient)
+	if err != nil {
+		return false, fmt.Errorf("Error determining the project of the token: %s", err)
+	}
+
+	return tenantID != projectID, nil
+}
+
+// computeInstanceV2QuotaRequest requests an instance with the cores and
+// RAM of its flavor and a port per network without a given port, or the
+// additional cores and RAM of a larger flavor.
+func computeInstanceV2QuotaRequest(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string, create bool) (quotaRequest, error) {
+	request := make(quotaRequest)
+
+	// Networks added to an existing instance get a new port, unless the
+	// port is given.
+	if !create && diff.HasChange("network") {
+		oldNetworks, newNetworks := diff.GetChange("network")
+		for i, j := range computeV2InstanceMatchNetworks(oldNetworks.([]interface{}), newNetworks.([]interface{})) {
+			port := fmt.Sprintf("network.%d.port", i)
+			if j < 0 && diff.NewValueKnown(port) && diff.Get(port).(string) == "" {
+				request[quotaPorts]++
+			}
+		}
+	}
+
+	if !create && !diff.HasChange("flavor_id") && !diff.HasChange("flavor_name") {
+		return request, nil
+	}
+
+	client, err := config.ComputeV2Client(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	newFlavor, err := computeInstanceV2DiffFlavor(client, diff, config, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if create {
+		request[quotaInstances] = 1
+		for i := range diff.Get("network").([]interface{}) {
+			port := fmt.Sprintf("network.%d.port", i)
+			if diff.NewValueKnown(port) && diff.Get(port).(string) == "" {
+				request[quotaPorts]++
+			}
+		}
+
+		if newFlavor != nil {
+			request[quotaCores] = newFlavor.VCPUs
+			request[quotaRAM] = newFlavor.RAM
+		}
+
+		return request, nil
+	}
+
+	oldFlavorID, _ := diff.GetChange("flavor_id")
+	if newFlavor == nil || oldFlavorID.(string) == "" {
+		return request, nil
+	}
+	oldFlavor, err := config.flavor(client, oldFlavorID.(string))
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return request, nil
+		}
+		return nil, fmt.Errorf("Error retrieving OpenStack flavor %s: %s", oldFlavorID, err)
+	}
+
+	request[quotaCores] = newFlavor.VCPUs - oldFlavor.VCPUs
+	request[quotaRAM] = newFlavor.RAM - oldFlavor.RAM
+
+	return request, nil
+}
+
+// computeInstanceV2DiffFlavor returns the planned flavor of an instance, or
+// nil if it is not known yet.
+func computeInstanceV2DiffFlavor(client *gophercloud.ServiceClient, diff *schema.ResourceDiff, config *Config, create bool) (*flavors.Flavor, error) {
+	flavorID := ""
+	if name := diff.Get("flavor_name").(string); name != "" && (create || diff.HasChange("flavor_name")) {
+		if !diff.NewValueKnown("flavor_name") {
+			return nil, nil
+		}
+		id, err := config.flavorIDFromName(client, name)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving OpenStack flavor %s: %s", name, err)
+		}
+		flavorID = id
+	} else if diff.NewValueKnown("flavor_id") {
+		flavorID = diff.Get("flavor_id").(string)
+	}
+	if flavorID == "" {
+		return nil, nil
+	}
+
+	flavor, err := config.flavor(client, flavorID)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving OpenStack flavor %s: %s", flavorID, err)
+	}
+
+	return flavor, nil
+}
+
+// networkingPortV2QuotaRequest requests a port for a new port.
+func networkingPortV2QuotaRequest(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string, create bool) (quotaRequest, error) {
+	return newObjectQuotaRequest(ctx, diff, config, region, create, quotaPorts)
+}
+
+// networkingFloatingIPV2QuotaRequest requests a floating IP for a new
+// floating IP.
+func networkingFloatingIPV2QuotaRequest(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string, create bool) (quotaRequest, error) {
+	return newObjectQuotaRequest(ctx, diff, config, region, create, quotaFloatingIPs)
+}
+
+func newObjectQuotaRequest(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string, create bool, quota quotaResource) (quotaRequest, error) {
+	if !create {
+		return nil, nil
+	}
+
+	mismatch, err := quotaProjectMismatch(ctx, diff, config, region)
+	if err != nil || mismatch {
+		return nil, err
+	}
+
+	return quotaRequest{quota: 1}, nil
+}
+
+// blockStorageVolumeV3QuotaRequest requests a volume and its size for a new
+// volume, or the additional size of an extended volume.
+func blockStorageVolumeV3QuotaRequest(ctx context.Context, diff *schema.ResourceDiff, config *Config, region string, create bool) (quotaRequest, error) {
+	if !diff.NewValueKnown("size") {
+		return nil, nil
+	}
+
+	oldSize, newSize := diff.GetChange("size")
+	if create {
+		return quotaRequest{quotaVolumes: 1, quotaGigabytes: newSize.(int)}, nil
+	}
+
+	return quotaRequest{quotaGigabytes: newSize.(int) - oldSize.(int)}, nil
+}
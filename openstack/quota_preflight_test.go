@@ -0,0 +1,180 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+func TestQuotaPreflightComputeInstanceV2(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+	srv.SetQuota(fakeopenstack.QuotaCores, 3)
+
+	raw := map[string]interface{}{
+		"name":         "instance_1",
+		"image_name":   fakeopenstack.ImageName,
+		"flavor_id":    "3",
+		"network_mode": "none",
+	}
+	instance := resourceComputeInstanceV2()
+
+	// Quotas are not checked by default.
+	config := testFakeProviderConfig(t, srv, nil)
+	for i := 0; i < 2; i++ {
+		_, err := instance.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+		assert.NoError(t, err)
+	}
+
+	config = testFakeProviderConfig(t, srv, map[string]interface{}{
+		"quota_preflight": "error",
+	})
+	_, err := instance.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+
+	// The second instance exceeds the cores of the project.
+	raw2 := map[string]interface{}{}
+	for k, v := range raw {
+		raw2[k] = v
+	}
+	raw2["name"] = "instance_2"
+	_, err = instance.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(raw2), config)
+	assert.EqualError(t, err, "The plan exceeds the quota of project "+srv.ProjectID+
+		" in region "+srv.Region+": compute cores: 4 requested by the plan, 0 of 3 used")
+
+	config = testFakeProviderConfig(t, srv, map[string]interface{}{
+		"quota_preflight": "warn",
+	})
+	for _, c := range []map[string]interface{}{raw, raw2} {
+		_, err := instance.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(c), config)
+		assert.NoError(t, err)
+	}
+}
+
+func TestQuotaPreflightCount(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+	srv.SetQuota(fakeopenstack.QuotaInstances, 2)
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"quota_preflight": "error",
+	})
+	instance := resourceComputeInstanceV2()
+
+	// The instances of a count have identical arguments, but each of them
+	// is a new instance.
+	raw := map[string]interface{}{
+		"name":         "instance",
+		"image_name":   fakeopenstack.ImageName,
+		"flavor_id":    "1",
+		"network_mode": "none",
+	}
+	for i := 0; i < 2; i++ {
+		_, err := instance.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+		assert.NoError(t, err)
+	}
+	_, err := instance.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "The plan exceeds the quota of project "+srv.ProjectID+
+		" in region "+srv.Region+": compute instances: 3 requested by the plan, 0 of 2 used")
+}
+
+func TestQuotaPreflightBlockStorageVolumeV3(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+	srv.SetQuota(fakeopenstack.QuotaGigabytes, 10)
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"quota_preflight": "error",
+	})
+	volume := resourceBlockStorageVolumeV3()
+
+	_, err := volume.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"size": 8,
+	}), config)
+	assert.NoError(t, err)
+
+	// Extending an existing volume only requests the additional size.
+	state := &terraform.InstanceState{
+		ID: "volume_1",
+		Attributes: map[string]string{
+			"id":   "volume_1",
+			"size": "1",
+		},
+	}
+	_, err = volume.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"size": 4,
+	}), config)
+	assert.EqualError(t, err, "The plan exceeds the quota of project "+srv.ProjectID+
+		" in region "+srv.Region+": block-storage gigabytes: 11 requested by the plan, 0 of 10 used")
+
+	// A smaller extension of the same volume replaces the request.
+	_, err = volume.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"size": 3,
+	}), config)
+	assert.NoError(t, err)
+}
+
+func TestQuotaPreflightNetworkingPortV2(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+	srv.SetQuota(fakeopenstack.QuotaPorts, 0)
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"quota_preflight": "error",
+	})
+	port := resourceNetworkingPortV2()
+
+	// Ports of other projects are not checked.
+	_, err := port.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"network_id": "network_1",
+		"tenant_id":  "other",
+	}), config)
+	assert.NoError(t, err)
+
+	_, err = port.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"network_id": "network_1",
+	}), config)
+	assert.EqualError(t, err, "The plan exceeds the quota of project "+srv.ProjectID+
+		" in region "+srv.Region+": network port: 1 requested by the plan, 0 of 0 used")
+}
+
+func TestQuotaPreflightReplacement(t *testing.T) {
+	srv := fakeopenstack.New()
+	defer srv.Close()
+	srv.SetQuota(fakeopenstack.QuotaGigabytes, 10)
+
+	config := testFakeProviderConfig(t, srv, map[string]interface{}{
+		"quota_preflight": "error",
+	})
+	volume := resourceBlockStorageVolumeV3()
+
+	// A replacement requests the full new volume, keyed by the ID of the
+	// replaced one, so planning it again replaces its request. Unlike Diff,
+	// SimpleDiff plans a replacement with a single diff, as Terraform does.
+	state := &terraform.InstanceState{
+		ID: "volume_1",
+		Attributes: map[string]string{
+			"id":       "volume_1",
+			"size":     "6",
+			"image_id": "image_1",
+		},
+	}
+	raw := map[string]interface{}{
+		"size":     6,
+		"image_id": "image_2",
+	}
+	for i := 0; i < 2; i++ {
+		diff, err := volume.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(raw), config)
+		assert.NoError(t, err)
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// A new volume next to it exceeds the gigabytes of the project.
+	_, err := volume.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), config)
+	assert.EqualError(t, err, "The plan exceeds the quota of project "+srv.ProjectID+
+		" in region "+srv.Region+": block-storage gigabytes: 12 requested by the plan, 0 of 10 used")
+}
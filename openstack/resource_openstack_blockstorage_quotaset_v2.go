@@ -1,22 +1,23 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBlockStorageQuotasetV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockStorageQuotasetV2Create,
-		Read:   resourceBlockStorageQuotasetV2Read,
-		Update: resourceBlockStorageQuotasetV2Update,
-		Delete: resourceBlockStorageQuotasetV2Delete,
+		CreateContext: resourceBlockStorageQuotasetV2Create,
+		ReadContext:   resourceBlockStorageQuotasetV2Read,
+		UpdateContext: resourceBlockStorageQuotasetV2Update,
+		DeleteContext: resourceBlockStorageQuotasetV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -84,11 +85,11 @@ func resourceBlockStorageQuotasetV2() *schema.Resource {
 	}
 }
 
-func resourceBlockStorageQuotasetV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageQuotasetV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	projectID := d.Get("project_id").(string)
@@ -112,26 +113,26 @@ func resourceBlockStorageQuotasetV2Create(d *schema.ResourceData, meta interface
 
 	q, err := quotasets.Update(blockStorageClient, projectID, updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_blockstorage_quotaset_v2: %s", err)
+		return diag.Errorf("Error creating openstack_blockstorage_quotaset_v2: %s", err)
 	}
 
 	d.SetId(projectID)
 
 	log.Printf("[DEBUG] Created openstack_blockstorage_quotaset_v2 %#v", q)
 
-	return resourceBlockStorageQuotasetV2Read(d, meta)
+	return resourceBlockStorageQuotasetV2Read(ctx, d, meta)
 }
 
-func resourceBlockStorageQuotasetV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageQuotasetV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	q, err := quotasets.Get(blockStorageClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_blockstorage_quotaset_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_blockstorage_quotaset_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_blockstorage_quotaset_v2 %s: %#v", d.Id(), q)
@@ -148,11 +149,11 @@ func resourceBlockStorageQuotasetV2Read(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceBlockStorageQuotasetV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageQuotasetV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	var (
@@ -206,14 +207,14 @@ func resourceBlockStorageQuotasetV2Update(d *schema.ResourceData, meta interface
 		log.Printf("[DEBUG] openstack_blockstorage_quotaset_v2 %s update options: %#v", d.Id(), updateOpts)
 		_, err := quotasets.Update(blockStorageClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_blockstorage_quotaset_v2: %s", err)
+			return diag.Errorf("Error updating openstack_blockstorage_quotaset_v2: %s", err)
 		}
 	}
 
-	return resourceBlockStorageQuotasetV2Read(d, meta)
+	return resourceBlockStorageQuotasetV2Read(ctx, d, meta)
 }
 
-func resourceBlockStorageQuotasetV2Delete(_ *schema.ResourceData, _ interface{}) error {
+func resourceBlockStorageQuotasetV2Delete(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] openstack_blockstorage_quotaset_v2 deletion is a no-op operation")
 
 	return nil
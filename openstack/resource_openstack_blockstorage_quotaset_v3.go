@@ -1,22 +1,23 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBlockStorageQuotasetV3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockStorageQuotasetV3Create,
-		Read:   resourceBlockStorageQuotasetV3Read,
-		Update: resourceBlockStorageQuotasetV3Update,
-		Delete: resourceBlockStorageQuotasetV3Delete,
+		CreateContext: resourceBlockStorageQuotasetV3Create,
+		ReadContext:   resourceBlockStorageQuotasetV3Read,
+		UpdateContext: resourceBlockStorageQuotasetV3Update,
+		DeleteContext: resourceBlockStorageQuotasetV3Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -84,11 +85,11 @@ func resourceBlockStorageQuotasetV3() *schema.Resource {
 	}
 }
 
-func resourceBlockStorageQuotasetV3Create(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageQuotasetV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	projectID := d.Get("project_id").(string)
@@ -112,26 +113,26 @@ func resourceBlockStorageQuotasetV3Create(d *schema.ResourceData, meta interface
 
 	q, err := quotasets.Update(blockStorageClient, projectID, updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_blockstorage_quotaset_v3: %s", err)
+		return diag.Errorf("Error creating openstack_blockstorage_quotaset_v3: %s", err)
 	}
 
 	d.SetId(projectID)
 
 	log.Printf("[DEBUG] Created openstack_blockstorage_quotaset_v3 %#v", q)
 
-	return resourceBlockStorageQuotasetV3Read(d, meta)
+	return resourceBlockStorageQuotasetV3Read(ctx, d, meta)
 }
 
-func resourceBlockStorageQuotasetV3Read(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageQuotasetV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	q, err := quotasets.Get(blockStorageClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_blockstorage_quotaset_v3")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_blockstorage_quotaset_v3"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_blockstorage_quotaset_v3 %s: %#v", d.Id(), q)
@@ -148,11 +149,11 @@ func resourceBlockStorageQuotasetV3Read(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceBlockStorageQuotasetV3Update(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageQuotasetV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	var (
@@ -206,14 +207,14 @@ func resourceBlockStorageQuotasetV3Update(d *schema.ResourceData, meta interface
 		log.Printf("[DEBUG] openstack_blockstorage_quotaset_v3 %s update options: %#v", d.Id(), updateOpts)
 		_, err := quotasets.Update(blockStorageClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_blockstorage_quotaset_v3: %s", err)
+			return diag.Errorf("Error updating openstack_blockstorage_quotaset_v3: %s", err)
 		}
 	}
 
-	return resourceBlockStorageQuotasetV3Read(d, meta)
+	return resourceBlockStorageQuotasetV3Read(ctx, d, meta)
 }
 
-func resourceBlockStorageQuotasetV3Delete(_ *schema.ResourceData, _ interface{}) error {
+func resourceBlockStorageQuotasetV3Delete(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] openstack_blockstorage_quotaset_v3 deletion is a no-op operation")
 
 	return nil
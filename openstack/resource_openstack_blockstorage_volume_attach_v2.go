@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumeactions"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v2/volumes"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,9 +17,12 @@ import (
 
 func resourceBlockStorageVolumeAttachV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockStorageVolumeAttachV2Create,
-		Read:   resourceBlockStorageVolumeAttachV2Read,
-		Delete: resourceBlockStorageVolumeAttachV2Delete,
+		CreateContext: resourceBlockStorageVolumeAttachV2Create,
+		ReadContext:   resourceBlockStorageVolumeAttachV2Read,
+		DeleteContext: resourceBlockStorageVolumeAttachV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBlockStorageVolumeAttachV2Import,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -129,11 +134,11 @@ func resourceBlockStorageVolumeAttachV2() *schema.Resource {
 	}
 }
 
-func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeAttachV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV2Client(GetRegion(d, config))
+	client, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	// initialize the connection
@@ -179,7 +184,7 @@ func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta inter
 
 	connInfo, err := volumeactions.InitializeConnection(client, volumeId, connOpts).Extract()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to initialize connection for openstack_blockstorage_volume_attach_v2: %s", err)
 	}
 
@@ -222,7 +227,7 @@ func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta inter
 	log.Printf("[DEBUG] openstack_blockstorage_volume_attach_v2 attach options: %#v", attachOpts)
 
 	if err := volumeactions.Attach(client, volumeId, attachOpts).ExtractErr(); err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error attaching openstack_blockstorage_volume_attach_v2 for volume %s: %s", volumeId, err)
 	}
 
@@ -239,9 +244,9 @@ func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta inter
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for openstack_blockstorage_volume_attach_v2 volume %s to become in-use: %s", volumeId, err)
 	}
 
@@ -249,7 +254,7 @@ func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta inter
 	// retrieve a fresh copy of it with all information now available.
 	volume, err := volumes.Get(client, volumeId).Extract()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to retrieve openstack_blockstorage_volume_attach_v2 volume %s: %s", volumeId, err)
 	}
 
@@ -263,7 +268,7 @@ func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta inter
 	}
 
 	if attachmentId == "" {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to determine attachment ID for openstack_blockstorage_volume_attach_v2 volume %s.", volumeId)
 	}
 
@@ -272,24 +277,24 @@ func resourceBlockStorageVolumeAttachV2Create(d *schema.ResourceData, meta inter
 	id := fmt.Sprintf("%s/%s", volumeId, attachmentId)
 	d.SetId(id)
 
-	return resourceBlockStorageVolumeAttachV2Read(d, meta)
+	return resourceBlockStorageVolumeAttachV2Read(ctx, d, meta)
 }
 
-func resourceBlockStorageVolumeAttachV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeAttachV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV2Client(GetRegion(d, config))
+	client, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	volumeId, attachmentId, err := blockStorageVolumeAttachV2ParseID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	volume, err := volumes.Get(client, volumeId).Extract()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to retrieve openstack_blockstorage_volume_attach_v2 volume %s: %s", volumeId, err)
 	}
 
@@ -302,22 +307,65 @@ func resourceBlockStorageVolumeAttachV2Read(d *schema.ResourceData, meta interfa
 		}
 	}
 
+	if attachment.AttachmentID == "" {
+		log.Printf("[DEBUG] openstack_blockstorage_volume_attach_v2 attachment %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf(
 		"[DEBUG] Retrieved openstack_blockstorage_volume_attach_v2 attachment %s: %#v", d.Id(), attachment)
 
+	d.Set("volume_id", volumeId)
+	if attachment.HostName != "" {
+		d.Set("host_name", attachment.HostName)
+	}
+	d.Set("region", GetRegion(d, config))
+
 	return nil
 }
 
-func resourceBlockStorageVolumeAttachV2Delete(d *schema.ResourceData, meta interface{}) error {
+// resourceBlockStorageVolumeAttachV2Import imports an attachment by its
+// <volume id>/<attachment id> ID. The connector options and the connection
+// information cannot be retrieved from an existing attachment.
+func resourceBlockStorageVolumeAttachV2Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	volumeId, attachmentId, err := blockStorageVolumeAttachV2ParseID(d.Id())
+	if err != nil || volumeId == "" || attachmentId == "" {
+		return nil, fmt.Errorf("Invalid format specified for openstack_blockstorage_volume_attach_v2. Format must be <volume id>/<attachment id>")
+	}
+
+	config := meta.(*Config)
+	client, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	volume, err := volumes.Get(client, volumeId).Extract()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Unable to retrieve openstack_blockstorage_volume_attach_v2 volume %s: %s", volumeId, err)
+	}
+
+	for _, attachment := range volume.Attachments {
+		if attachment.AttachmentID == attachmentId {
+			d.Set("device", attachment.Device)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Unable to find openstack_blockstorage_volume_attach_v2 attachment %s", d.Id())
+}
+
+func resourceBlockStorageVolumeAttachV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV2Client(GetRegion(d, config))
+	client, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	volumeId, attachmentId, err := blockStorageVolumeAttachV2ParseID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Terminate the connection
@@ -362,7 +410,7 @@ func resourceBlockStorageVolumeAttachV2Delete(d *schema.ResourceData, meta inter
 
 	err = volumeactions.TerminateConnection(client, volumeId, termOpts).ExtractErr()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error terminating openstack_blockstorage_volume_attach_v2 connection %s: %s", d.Id(), err)
 	}
 
@@ -375,7 +423,7 @@ func resourceBlockStorageVolumeAttachV2Delete(d *schema.ResourceData, meta inter
 		"[DEBUG] openstack_blockstorage_volume_attach_v2 detachment options %s: %#v", d.Id(), detachOpts)
 
 	if err := volumeactions.Detach(client, volumeId, detachOpts).ExtractErr(); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -387,9 +435,9 @@ func resourceBlockStorageVolumeAttachV2Delete(d *schema.ResourceData, meta inter
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for openstack_blockstorage_volume_attach_v2 volume %s to become available: %s", volumeId, err)
 	}
 
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumeactions"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,9 +17,12 @@ import (
 
 func resourceBlockStorageVolumeAttachV3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockStorageVolumeAttachV3Create,
-		Read:   resourceBlockStorageVolumeAttachV3Read,
-		Delete: resourceBlockStorageVolumeAttachV3Delete,
+		CreateContext: resourceBlockStorageVolumeAttachV3Create,
+		ReadContext:   resourceBlockStorageVolumeAttachV3Read,
+		DeleteContext: resourceBlockStorageVolumeAttachV3Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBlockStorageVolumeAttachV3Import,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -122,11 +127,11 @@ func resourceBlockStorageVolumeAttachV3() *schema.Resource {
 	}
 }
 
-func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeAttachV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV3Client(GetRegion(d, config))
+	client, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	// initialize the connection
@@ -172,7 +177,7 @@ func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta inter
 
 	connInfo, err := volumeactions.InitializeConnection(client, volumeId, connOpts).Extract()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to initialize connection for openstack_blockstorage_volume_attach_v3: %s", err)
 	}
 
@@ -215,7 +220,7 @@ func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta inter
 	log.Printf("[DEBUG] openstack_blockstorage_volume_attach_v3 attach options: %#v", attachOpts)
 
 	if err := volumeactions.Attach(client, volumeId, attachOpts).ExtractErr(); err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error attaching openstack_blockstorage_volume_attach_v3 for volume %s: %s", volumeId, err)
 	}
 
@@ -232,9 +237,9 @@ func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta inter
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for openstack_blockstorage_volume_attach_v3 volume %s to become in-use: %s", volumeId, err)
 	}
 
@@ -242,7 +247,7 @@ func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta inter
 	// retrieve a fresh copy of it with all information now available.
 	volume, err := volumes.Get(client, volumeId).Extract()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to retrieve openstack_blockstorage_volume_attach_v3 volume %s: %s", volumeId, err)
 	}
 
@@ -256,7 +261,7 @@ func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta inter
 	}
 
 	if attachmentId == "" {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to determine attachment ID for openstack_blockstorage_volume_attach_v3 volume %s.", volumeId)
 	}
 
@@ -265,24 +270,24 @@ func resourceBlockStorageVolumeAttachV3Create(d *schema.ResourceData, meta inter
 	id := fmt.Sprintf("%s/%s", volumeId, attachmentId)
 	d.SetId(id)
 
-	return resourceBlockStorageVolumeAttachV3Read(d, meta)
+	return resourceBlockStorageVolumeAttachV3Read(ctx, d, meta)
 }
 
-func resourceBlockStorageVolumeAttachV3Read(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeAttachV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV3Client(GetRegion(d, config))
+	client, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	volumeId, attachmentId, err := blockStorageVolumeAttachV3ParseID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	volume, err := volumes.Get(client, volumeId).Extract()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Unable to retrieve openstack_blockstorage_volume_attach_v3 volume %s: %s", volumeId, err)
 	}
 
@@ -295,17 +300,60 @@ func resourceBlockStorageVolumeAttachV3Read(d *schema.ResourceData, meta interfa
 		}
 	}
 
+	if attachment.AttachmentID == "" {
+		log.Printf("[DEBUG] openstack_blockstorage_volume_attach_v3 attachment %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf(
 		"[DEBUG] Retrieved openstack_blockstorage_volume_attach_v3 attachment %s: %#v", d.Id(), attachment)
 
+	d.Set("volume_id", volumeId)
+	if attachment.HostName != "" {
+		d.Set("host_name", attachment.HostName)
+	}
+	d.Set("region", GetRegion(d, config))
+
 	return nil
 }
 
-func resourceBlockStorageVolumeAttachV3Delete(d *schema.ResourceData, meta interface{}) error {
+// resourceBlockStorageVolumeAttachV3Import imports an attachment by its
+// <volume id>/<attachment id> ID. The connector options and the connection
+// information cannot be retrieved from an existing attachment.
+func resourceBlockStorageVolumeAttachV3Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	volumeId, attachmentId, err := blockStorageVolumeAttachV3ParseID(d.Id())
+	if err != nil || volumeId == "" || attachmentId == "" {
+		return nil, fmt.Errorf("Invalid format specified for openstack_blockstorage_volume_attach_v3. Format must be <volume id>/<attachment id>")
+	}
+
+	config := meta.(*Config)
+	client, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	volume, err := volumes.Get(client, volumeId).Extract()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Unable to retrieve openstack_blockstorage_volume_attach_v3 volume %s: %s", volumeId, err)
+	}
+
+	for _, attachment := range volume.Attachments {
+		if attachment.AttachmentID == attachmentId {
+			d.Set("device", attachment.Device)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Unable to find openstack_blockstorage_volume_attach_v3 attachment %s", d.Id())
+}
+
+func resourceBlockStorageVolumeAttachV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	client, err := config.BlockStorageV3Client(GetRegion(d, config))
+	client, err := config.BlockStorageV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	volumeId, attachmentId, err := blockStorageVolumeAttachV3ParseID(d.Id())
@@ -352,7 +400,7 @@ func resourceBlockStorageVolumeAttachV3Delete(d *schema.ResourceData, meta inter
 
 	err = volumeactions.TerminateConnection(client, volumeId, termOpts).ExtractErr()
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error terminating openstack_blockstorage_volume_attach_v3 connection %s: %s", d.Id(), err)
 	}
 
@@ -365,7 +413,7 @@ func resourceBlockStorageVolumeAttachV3Delete(d *schema.ResourceData, meta inter
 		"[DEBUG] openstack_blockstorage_volume_attach_v3 detachment options %s: %#v", d.Id(), detachOpts)
 
 	if err := volumeactions.Detach(client, volumeId, detachOpts).ExtractErr(); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -377,9 +425,9 @@ func resourceBlockStorageVolumeAttachV3Delete(d *schema.ResourceData, meta inter
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for openstack_blockstorage_volume_attach_v3 volume %s to become available: %s", volumeId, err)
 	}
 
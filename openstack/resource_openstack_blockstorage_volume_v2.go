@@ -1,7 +1,7 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
@@ -10,18 +10,19 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v2/volumes"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceBlockStorageVolumeV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockStorageVolumeV2Create,
-		Read:   resourceBlockStorageVolumeV2Read,
-		Update: resourceBlockStorageVolumeV2Update,
-		Delete: resourceBlockStorageVolumeV2Delete,
+		CreateContext: resourceBlockStorageVolumeV2Create,
+		ReadContext:   resourceBlockStorageVolumeV2Read,
+		UpdateContext: resourceBlockStorageVolumeV2Update,
+		DeleteContext: resourceBlockStorageVolumeV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -168,11 +169,11 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 	}
 }
 
-func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	metadata := d.Get("metadata").(map[string]interface{})
@@ -207,7 +208,7 @@ func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}
 
 	v, err := volumes.Create(blockStorageClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_blockstorage_volume_v2: %s", err)
+		return diag.Errorf("Error creating openstack_blockstorage_volume_v2: %s", err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -219,27 +220,27 @@ func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for openstack_blockstorage_volume_v2 %s to become ready: %s", v.ID, err)
 	}
 
 	d.SetId(v.ID)
 
-	return resourceBlockStorageVolumeV2Read(d, meta)
+	return resourceBlockStorageVolumeV2Read(ctx, d, meta)
 }
 
-func resourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	v, err := volumes.Get(blockStorageClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_blockstorage_volume_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_blockstorage_volume_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_blockstorage_volume_v2 %s: %#v", d.Id(), v)
@@ -264,11 +265,11 @@ func resourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceBlockStorageVolumeV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	name := d.Get("name").(string)
@@ -285,29 +286,29 @@ func resourceBlockStorageVolumeV2Update(d *schema.ResourceData, meta interface{}
 
 	_, err = volumes.Update(blockStorageClient, d.Id(), updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error updating openstack_blockstorage_volume_v2 %s: %s", d.Id(), err)
+		return diag.Errorf("Error updating openstack_blockstorage_volume_v2 %s: %s", d.Id(), err)
 	}
 
-	return resourceBlockStorageVolumeV2Read(d, meta)
+	return resourceBlockStorageVolumeV2Read(ctx, d, meta)
 }
 
-func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceBlockStorageVolumeV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack block storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack block storage client: %s", err)
 	}
 
 	v, err := volumes.Get(blockStorageClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_blockstorage_volume_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_blockstorage_volume_v2"))
 	}
 
 	// Make sure this volume is detached from all instances before deleting.
 	if len(v.Attachments) > 0 {
-		computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+		computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 		if err != nil {
-			return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+			return diag.Errorf("Error creating OpenStack compute client: %s", err)
 		}
 
 		for _, volumeAttachment := range v.Attachments {
@@ -329,7 +330,7 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 					continue
 				}
 
-				return fmt.Errorf(
+				return diag.Errorf(
 					"Error detaching openstack_blockstorage_volume_v2 %s from %s: %s", d.Id(), serverID, err)
 			}
 		}
@@ -343,9 +344,9 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 			MinTimeout: 3 * time.Second,
 		}
 
-		_, err = stateConf.WaitForState()
+		_, err = stateConf.WaitForStateContext(ctx)
 		if err != nil {
-			return fmt.Errorf(
+			return diag.Errorf(
 				"Error waiting for openstack_blockstorage_volume_v2 %s to become available: %s", d.Id(), err)
 		}
 	}
@@ -355,7 +356,7 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 	// If this is true, just move on. It'll eventually delete.
 	if v.Status != "deleting" {
 		if err := volumes.Delete(blockStorageClient, d.Id(), nil).ExtractErr(); err != nil {
-			return CheckDeleted(d, err, "Error deleting openstack_blockstorage_volume_v2")
+			return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_blockstorage_volume_v2"))
 		}
 	}
 
@@ -368,9 +369,9 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_blockstorage_volume_v2 %s to delete: %s", d.Id(), err)
+		return diag.Errorf("Error waiting for openstack_blockstorage_volume_v2 %s to delete: %s", d.Id(), err)
 	}
 
 	return nil
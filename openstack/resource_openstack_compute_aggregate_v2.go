@@ -0,0 +1,198 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/aggregates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceComputeAggregateV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceComputeAggregateV2Create,
+		ReadContext:   resourceComputeAggregateV2Read,
+		UpdateContext: resourceComputeAggregateV2Update,
+		DeleteContext: resourceComputeAggregateV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateComputeAggregateV2Metadata,
+			},
+
+			"hosts": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceComputeAggregateV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	createOpts := aggregates.CreateOpts{
+		Name:             d.Get("name").(string),
+		AvailabilityZone: d.Get("availability_zone").(string),
+	}
+
+	log.Printf("[DEBUG] openstack_compute_aggregate_v2 create options: %#v", createOpts)
+	aggregate, err := aggregates.Create(computeClient, createOpts).Extract()
+	if err != nil {
+		return diag.Errorf("Error creating openstack_compute_aggregate_v2 %s: %s", createOpts.Name, err)
+	}
+
+	d.SetId(strconv.Itoa(aggregate.ID))
+
+	hosts := expandToStringSlice(d.Get("hosts").(*schema.Set).List())
+	if err := computeAggregateV2UpdateHosts(computeClient, aggregate.ID, nil, hosts); err != nil {
+		return diag.Errorf("Error adding hosts to openstack_compute_aggregate_v2 %s: %s", d.Id(), err)
+	}
+
+	if metadata := d.Get("metadata").(map[string]interface{}); len(metadata) > 0 {
+		setMetadataOpts := aggregates.SetMetadataOpts{Metadata: metadata}
+		if _, err := aggregates.SetMetadata(computeClient, aggregate.ID, setMetadataOpts).Extract(); err != nil {
+			return diag.Errorf("Error setting metadata of openstack_compute_aggregate_v2 %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceComputeAggregateV2Read(ctx, d, meta)
+}
+
+func resourceComputeAggregateV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	id, err := computeAggregateV2ID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	aggregate, err := aggregates.Get(computeClient, id).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_compute_aggregate_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved openstack_compute_aggregate_v2 %s: %#v", d.Id(), aggregate)
+
+	d.Set("name", aggregate.Name)
+	d.Set("availability_zone", aggregate.AvailabilityZone)
+	d.Set("metadata", computeAggregateV2Metadata(aggregate))
+	d.Set("hosts", aggregate.Hosts)
+
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceComputeAggregateV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	id, err := computeAggregateV2ID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "availability_zone") {
+		err := computeAggregateV2Update(computeClient, id, d.Get("name").(string), d.Get("availability_zone").(string))
+		if err != nil {
+			return diag.Errorf("Error updating openstack_compute_aggregate_v2 %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("hosts") {
+		o, n := d.GetChange("hosts")
+		oldHosts := expandToStringSlice(o.(*schema.Set).List())
+		newHosts := expandToStringSlice(n.(*schema.Set).List())
+		if err := computeAggregateV2UpdateHosts(computeClient, id, oldHosts, newHosts); err != nil {
+			return diag.Errorf("Error updating hosts of openstack_compute_aggregate_v2 %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("metadata") {
+		o, n := d.GetChange("metadata")
+		setMetadataOpts := aggregates.SetMetadataOpts{
+			Metadata: computeAggregateV2MetadataChanges(o.(map[string]interface{}), n.(map[string]interface{})),
+		}
+		if _, err := aggregates.SetMetadata(computeClient, id, setMetadataOpts).Extract(); err != nil {
+			return diag.Errorf("Error updating metadata of openstack_compute_aggregate_v2 %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceComputeAggregateV2Read(ctx, d, meta)
+}
+
+func resourceComputeAggregateV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	id, err := computeAggregateV2ID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Nova only deletes aggregates without hosts.
+	aggregate, err := aggregates.Get(computeClient, id).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_compute_aggregate_v2"))
+	}
+	if err := computeAggregateV2UpdateHosts(computeClient, id, aggregate.Hosts, nil); err != nil {
+		return diag.Errorf("Error removing hosts from openstack_compute_aggregate_v2 %s: %s", d.Id(), err)
+	}
+
+	if err := aggregates.Delete(computeClient, id).ExtractErr(); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_compute_aggregate_v2"))
+	}
+
+	return nil
+}
+
+func validateComputeAggregateV2Metadata(v interface{}, k string) ([]string, []error) {
+	if _, ok := v.(map[string]interface{})[computeAggregateV2AvailabilityZoneKey]; ok {
+		return nil, []error{fmt.Errorf("%q must not contain %q, use the availability_zone argument instead", k, computeAggregateV2AvailabilityZoneKey)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/aggregates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccComputeV2Aggregate_basic(t *testing.T) {
+	var aggregate aggregates.Aggregate
+	var aggregateName = acctest.RandomWithPrefix("tf-acc-aggregate")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAdminOnly(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2AggregateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Aggregate_basic(aggregateName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2AggregateExists("openstack_compute_aggregate_v2.aggregate_1", &aggregate),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "name", aggregateName),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "availability_zone", "tf-acc-az"),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "metadata.%", "2"),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "metadata.ssd", "true"),
+				),
+			},
+			{
+				Config: testAccComputeV2Aggregate_update(aggregateName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2AggregateExists("openstack_compute_aggregate_v2.aggregate_1", &aggregate),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "name", aggregateName+"-updated"),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "availability_zone", ""),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "metadata.%", "1"),
+					resource.TestCheckResourceAttr(
+						"openstack_compute_aggregate_v2.aggregate_1", "metadata.ssd", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeV2AggregateDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	computeClient, err := config.ComputeV2Client(context.TODO(), OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_compute_aggregate_v2" {
+			continue
+		}
+
+		id, err := computeAggregateV2ID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = aggregates.Get(computeClient, id).Extract()
+		if err == nil {
+			return fmt.Errorf("Aggregate still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeV2AggregateExists(n string, aggregate *aggregates.Aggregate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		computeClient, err := config.ComputeV2Client(context.TODO(), OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		}
+
+		id, err := computeAggregateV2ID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := aggregates.Get(computeClient, id).Extract()
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(found.ID) != rs.Primary.ID {
+			return fmt.Errorf("Aggregate not found")
+		}
+
+		*aggregate = *found
+
+		return nil
+	}
+}
+
+func testAccComputeV2Aggregate_basic(aggregateName string) string {
+	return fmt.Sprintf(`
+resource "openstack_compute_aggregate_v2" "aggregate_1" {
+  name = "%s"
+  availability_zone = "tf-acc-az"
+  metadata = {
+    ssd = "true"
+    rack = "r1"
+  }
+}
+`, aggregateName)
+}
+
+func testAccComputeV2Aggregate_update(aggregateName string) string {
+	return fmt.Sprintf(`
+resource "openstack_compute_aggregate_v2" "aggregate_1" {
+  name = "%s-updated"
+  metadata = {
+    ssd = "false"
+  }
+}
+`, aggregateName)
+}
@@ -2,6 +2,7 @@ package openstack
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
@@ -17,14 +18,12 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/schedulerhints"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/secgroups"
-	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/shelveunshelve"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/tags"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
-	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
-	flavors_utils "github.com/gophercloud/utils/openstack/compute/v2/flavors"
-	images_utils "github.com/gophercloud/utils/openstack/compute/v2/images"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -33,13 +32,13 @@ import (
 
 func resourceComputeInstanceV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceComputeInstanceV2Create,
-		Read:   resourceComputeInstanceV2Read,
-		Update: resourceComputeInstanceV2Update,
-		Delete: resourceComputeInstanceV2Delete,
+		CreateContext: resourceComputeInstanceV2Create,
+		ReadContext:   resourceComputeInstanceV2Read,
+		UpdateContext: resourceComputeInstanceV2Update,
+		DeleteContext: resourceComputeInstanceV2Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceOpenStackComputeInstanceV2ImportState,
+			StateContext: resourceOpenStackComputeInstanceV2ImportState,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -47,6 +46,15 @@ func resourceComputeInstanceV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffDefaultTags,
+			customizeDiffComputeInstanceV2Rebuild,
+			customizeDiffComputeInstanceV2Networks,
+			customizeDiffComputeInstanceV2BlockDevices,
+			customizeDiffMicroversions((*Config).ComputeV2Client, computeV2InstanceDiffMicroversions),
+			customizeDiffQuotas("openstack_compute_instance_v2", resourceComputeInstanceV2, computeInstanceV2QuotaRequest, computeV2InstanceReplaced),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -60,18 +68,23 @@ func resourceComputeInstanceV2() *schema.Resource {
 				Required: true,
 				ForceNew: false,
 			},
+			// The image, user_data and key_pair force a new instance unless
+			// the instance is rebuilt, see customizeDiffComputeInstanceV2Rebuild.
 			"image_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"image_name": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
+			"rebuild_on_image_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"flavor_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -93,7 +106,6 @@ func resourceComputeInstanceV2() *schema.Resource {
 			"user_data": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				// just stash the hash for state & diff comparisons
 				StateFunc: func(v interface{}) string {
 					switch v.(type) {
@@ -140,38 +152,32 @@ func resourceComputeInstanceV2() *schema.Resource {
 			"network": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"uuid": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"name": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"port": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"fixed_ip_v4": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"fixed_ip_v6": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"floating_ip": {
@@ -223,7 +229,6 @@ func resourceComputeInstanceV2() *schema.Resource {
 			"key_pair": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"block_device": {
 				Type:     schema.TypeList,
@@ -233,53 +238,47 @@ func resourceComputeInstanceV2() *schema.Resource {
 						"source_type": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"uuid": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"volume_size": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true,
 						},
 						"destination_type": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"boot_index": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true,
 						},
 						"delete_on_termination": {
 							Type:     schema.TypeBool,
 							Optional: true,
 							Default:  false,
-							ForceNew: true,
 						},
 						"guest_format": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"volume_type": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"device_type": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 						"disk_bus": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
+						},
+						"volume_id": {
+							Type:     schema.TypeString,
+							Computed: true,
 						},
 					},
 				},
@@ -392,10 +391,15 @@ func resourceComputeInstanceV2() *schema.Resource {
 				ForceNew: false,
 				Default:  "active",
 				ValidateFunc: validation.StringInSlice([]string{
-					"active", "shutoff",
+					"active", "shutoff", "paused", "suspended", "shelved", "shelved_offloaded", "rescued",
 				}, true),
 				DiffSuppressFunc: suppressPowerStateDiffs,
 			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -430,11 +434,11 @@ func resourceComputeInstanceV2() *schema.Resource {
 	}
 }
 
-func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeInstanceV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	var createOpts servers.CreateOptsBuilder
@@ -445,37 +449,36 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 	// If a bootable block_device was specified, ignore the image altogether.
 	// If an image_id was specified, use it.
 	// If an image_name was specified, look up the image ID, report if error.
-	imageId, err := getImageIDFromConfig(computeClient, d)
+	imageId, err := getImageIDFromConfig(config, computeClient, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Determines the Flavor ID using the following rules:
 	// If a flavor_id was specified, use it.
 	// If a flavor_name was specified, lookup the flavor ID, report if error.
-	flavorId, err := getFlavorID(computeClient, d)
+	flavorId, err := getFlavorID(config, computeClient, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// determine if block_device configuration is correct
 	// this includes valid combinations and required attributes
 	if err := checkBlockDeviceConfig(d); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if networkMode := d.Get("network_mode").(string); networkMode == "auto" || networkMode == "none" {
 		// Use special string for network option
-		computeClient.Microversion = computeV2InstanceCreateServerWithNetworkModeMicroversion
 		networks = networkMode
 		log.Printf("[DEBUG] Create with network options %s", networks)
 	} else {
 		log.Printf("[DEBUG] Create with specified network options")
 		// Build a list of networks with the information given upon creation.
 		// Error out if an invalid network configuration was used.
-		allInstanceNetworks, err := getAllInstanceNetworks(d, meta)
+		allInstanceNetworks, err := getAllInstanceNetworks(ctx, d, meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		// Build a []servers.Network to pass into the create options.
@@ -484,11 +487,7 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 
 	configDrive := d.Get("config_drive").(bool)
 
-	// Retrieve tags and set microversion if they're provided.
-	instanceTags := computeV2InstanceTags(d)
-	if len(instanceTags) > 0 {
-		computeClient.Microversion = computeV2InstanceCreateServerWithTagsMicroversion
-	}
+	instanceTags := computeV2InstanceTags(d, config.DefaultTags)
 
 	if v, ok := d.GetOkExists("availability_zone"); ok {
 		availabilityZone = v.(string)
@@ -521,15 +520,7 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 	if vL, ok := d.GetOk("block_device"); ok {
 		blockDevices, err := resourceInstanceBlockDevicesV2(d, vL.([]interface{}))
 		if err != nil {
-			return err
-		}
-
-		// Check if VolumeType was set in any of the Block Devices.
-		// If so, set the client's microversion appropriately.
-		for _, bd := range blockDevices {
-			if bd.VolumeType != "" {
-				computeClient.Microversion = computeV2InstanceBlockDeviceVolumeTypeMicroversion
-			}
+			return diag.FromErr(err)
 		}
 
 		createOpts = &bootfromvolume.CreateOptsExt{
@@ -548,6 +539,11 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	// Use the highest microversion required by the configured attributes.
+	if err := config.setMicroversion(computeClient, computeV2InstanceCreateMicroversions(d)...); err != nil {
+		return diag.FromErr(err)
+	}
+
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 
 	// If a block_device is used, use the bootfromvolume.Create function as it allows an empty ImageRef.
@@ -560,7 +556,7 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack server: %s", err)
+		return diag.Errorf("Error creating OpenStack server: %s", err)
 	}
 	log.Printf("[INFO] Instance ID: %s", server.ID)
 
@@ -582,58 +578,69 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for instance (%s) to become ready: %s",
 			server.ID, err)
 	}
 
-	vmState := d.Get("power_state").(string)
-	if strings.ToLower(vmState) == "shutoff" {
-		err = startstop.Stop(computeClient, d.Id()).ExtractErr()
-		if err != nil {
-			return fmt.Errorf("Error stopping OpenStack instance: %s", err)
-		}
-		stopStateConf := &resource.StateChangeConf{
-			//Pending:    []string{"ACTIVE"},
-			Target:     []string{"SHUTOFF"},
-			Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+	// Volumes whose ID is not known can't be detached in place later, so a
+	// failure to find them doesn't fail the creation.
+	if _, ok := d.GetOk("block_device"); ok {
+		if err := computeV2InstanceSetBlockDeviceVolumeIDs(ctx, computeClient, d, meta); err != nil {
+			log.Printf("[WARN] Unable to record the volumes of the block devices of instance %s: %s", d.Id(), err)
 		}
+	}
 
-		log.Printf("[DEBUG] Waiting for instance (%s) to stop", d.Id())
-		_, err = stopStateConf.WaitForState()
-		if err != nil {
-			return fmt.Errorf("Error waiting for instance (%s) to become inactive(shutoff): %s", d.Id(), err)
+	vmState := d.Get("power_state").(string)
+	if err := computeV2InstanceSetPowerState(ctx, computeClient, d, "active", vmState, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("locked").(bool) {
+		if err := computeV2InstanceAction(computeClient, d, "lock"); err != nil {
+			return diag.Errorf("Error locking OpenStack instance %s: %s", d.Id(), err)
 		}
 	}
 
-	return resourceComputeInstanceV2Read(d, meta)
+	return resourceComputeInstanceV2Read(ctx, d, meta)
 }
 
-func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeInstanceV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	// Servers only report whether they are locked since microversion 2.9.
+	r := config.serviceMicroversions(computeClient)
+	if r.max != "" && r.supports(computeV2InstanceLockedMicroversion) {
+		computeClient.Microversion = computeV2InstanceLockedMicroversion
 	}
 
-	server, err := servers.Get(computeClient, d.Id()).Extract()
+	getResult := servers.Get(computeClient, d.Id())
+	server, err := getResult.Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "server")
+		return diag.FromErr(CheckDeleted(d, err, "server"))
 	}
 
 	log.Printf("[DEBUG] Retrieved Server %s: %+v", d.Id(), server)
 
 	d.Set("name", server.Name)
 
+	var serverLock struct {
+		Locked *bool `json:"locked"`
+	}
+	if err := getResult.ExtractInto(&serverLock); err == nil && serverLock.Locked != nil {
+		d.Set("locked", *serverLock.Locked)
+	}
+
 	// Get the instance network and address information
-	networks, err := flattenInstanceNetworks(d, meta)
+	networks, err := flattenInstanceNetworks(ctx, d, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Determine the best IPv4 and IPv6 addresses to access the instance with
@@ -680,20 +687,20 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 
 	flavorId, ok := server.Flavor["id"].(string)
 	if !ok {
-		return fmt.Errorf("Error setting OpenStack server's flavor: %v", server.Flavor)
+		return diag.Errorf("Error setting OpenStack server's flavor: %v", server.Flavor)
 	}
 	d.Set("flavor_id", flavorId)
 
 	d.Set("key_pair", server.KeyName)
 	flavor, err := flavors.Get(computeClient, flavorId).Extract()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("flavor_name", flavor.Name)
 
 	// Set the instance's image information appropriately
-	if err := setImageInformation(computeClient, server, d); err != nil {
-		return err
+	if err := setImageInformation(config, computeClient, server, d); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// Build a custom struct for the availability zone extension
@@ -705,7 +712,7 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	// Do another Get so the above work is not disturbed.
 	err = servers.Get(computeClient, d.Id()).ExtractInto(&serverWithAZ)
 	if err != nil {
-		return CheckDeleted(d, err, "server")
+		return diag.FromErr(CheckDeleted(d, err, "server"))
 	}
 	// Set the availability zone
 	d.Set("availability_zone", serverWithAZ.AvailabilityZone)
@@ -714,12 +721,19 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	d.Set("region", GetRegion(d, config))
 
 	// Set the current power_state
-	currentStatus := strings.ToLower(server.Status)
-	switch currentStatus {
-	case "active", "shutoff", "error", "migrating", "shelved_offloaded", "shelved":
-		d.Set("power_state", currentStatus)
-	default:
-		return fmt.Errorf("Invalid power_state for instance %s: %s", d.Id(), server.Status)
+	powerState, ok := computeV2InstancePowerStates[server.Status]
+	if !ok {
+		return diag.Errorf("Invalid power_state for instance %s: %s", d.Id(), server.Status)
+	}
+	d.Set("power_state", powerState)
+
+	// Volumes of block devices which are not recorded yet, e.g. because the
+	// instance was created by an earlier version of the provider, are looked
+	// up so that they can be detached in place.
+	if computeV2InstanceBlockDeviceVolumeIDsMissing(d.Get("block_device").([]interface{})) {
+		if err := computeV2InstanceSetBlockDeviceVolumeIDs(ctx, computeClient, d, meta); err != nil {
+			log.Printf("[WARN] Unable to record the volumes of the block devices of instance %s: %s", d.Id(), err)
+		}
 	}
 
 	// Populate tags.
@@ -734,13 +748,46 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	if diags := resourceComputeInstanceV2UpdateServer(ctx, d, meta, computeClient); diags.HasError() {
+		return diags
 	}
 
+	return resourceComputeInstanceV2Read(ctx, d, meta)
+}
+
+// resourceComputeInstanceV2UpdateServer applies the changes of the instance.
+//
+// A locked instance only allows administrators to change it, so it is
+// unlocked if the update changes the server. It is locked again when the
+// update ends, also if it fails.
+func resourceComputeInstanceV2UpdateServer(ctx context.Context, d *schema.ResourceData, meta interface{}, computeClient *gophercloud.ServiceClient) (diags diag.Diagnostics) {
+	config := meta.(*Config)
+
+	lockedOld, lockedNew := d.GetChange("locked")
+	unlocked := false
+	if lockedOld.(bool) && (!lockedNew.(bool) || computeV2InstanceUpdateChangesServer(d)) {
+		if err := computeV2InstanceAction(computeClient, d, "unlock"); err != nil {
+			return diag.Errorf("Error unlocking OpenStack instance %s: %s", d.Id(), err)
+		}
+		unlocked = true
+	}
+	defer func() {
+		lock := lockedNew.(bool) || (lockedOld.(bool) && diags.HasError())
+		if !lock || (lockedOld.(bool) && !unlocked) {
+			return
+		}
+		if err := computeV2InstanceAction(computeClient, d, "lock"); err != nil {
+			diags = append(diags, diag.Errorf("Error locking OpenStack instance %s: %s", d.Id(), err)...)
+		}
+	}()
+
 	var updateOpts servers.UpdateOpts
 	if d.HasChange("name") {
 		updateOpts.Name = d.Get("name").(string)
@@ -749,63 +796,68 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 	if updateOpts != (servers.UpdateOpts{}) {
 		_, err := servers.Update(computeClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating OpenStack server: %s", err)
+			return diag.Errorf("Error updating OpenStack server: %s", err)
 		}
 	}
 
-	if d.HasChange("power_state") {
-		powerStateOldRaw, powerStateNewRaw := d.GetChange("power_state")
-		powerStateOld := powerStateOldRaw.(string)
-		powerStateNew := powerStateNewRaw.(string)
-		if strings.ToLower(powerStateNew) == "shutoff" {
-			err = startstop.Stop(computeClient, d.Id()).ExtractErr()
-			if err != nil {
-				return fmt.Errorf("Error stopping OpenStack instance: %s", err)
-			}
-			stopStateConf := &resource.StateChangeConf{
-				//Pending:    []string{"ACTIVE"},
-				Target:     []string{"SHUTOFF"},
-				Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
-				Timeout:    d.Timeout(schema.TimeoutUpdate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
+	rebuilt := computeV2InstanceRebuild(d)
+	if rebuilt {
+		imageID, err := getImageIDFromConfig(config, computeClient, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if imageID == "" {
+			return diag.Errorf("Error rebuilding OpenStack server (%s): instances booted from a volume cannot be rebuilt", d.Id())
+		}
 
-			log.Printf("[DEBUG] Waiting for instance (%s) to stop", d.Id())
-			_, err = stopStateConf.WaitForState()
-			if err != nil {
-				return fmt.Errorf("Error waiting for instance (%s) to become inactive(shutoff): %s", d.Id(), err)
-			}
+		rebuildClient, err := config.microversionClient(computeClient, computeV2InstanceRebuildMicroversions(d)...)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		if strings.ToLower(powerStateNew) == "active" {
-			if strings.ToLower(powerStateOld) == "shelved" || strings.ToLower(powerStateOld) == "shelved_offloaded" {
-				unshelveOpt := &shelveunshelve.UnshelveOpts{
-					AvailabilityZone: d.Get("availability_zone").(string),
-				}
-				err = shelveunshelve.Unshelve(computeClient, d.Id(), unshelveOpt).ExtractErr()
-				if err != nil {
-					return fmt.Errorf("Error unshelving OpenStack instance: %s", err)
-				}
-			} else {
-				err = startstop.Start(computeClient, d.Id()).ExtractErr()
-				if err != nil {
-					return fmt.Errorf("Error starting OpenStack instance: %s", err)
-				}
-			}
-			startStateConf := &resource.StateChangeConf{
-				//Pending:    []string{"SHUTOFF"},
-				Target:     []string{"ACTIVE"},
-				Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
-				Timeout:    d.Timeout(schema.TimeoutUpdate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
 
-			log.Printf("[DEBUG] Waiting for instance (%s) to start/unshelve", d.Id())
-			_, err = startStateConf.WaitForState()
-			if err != nil {
-				return fmt.Errorf("Error waiting for instance (%s) to become active: %s", d.Id(), err)
-			}
+		rebuildOpts := computeV2InstanceRebuildOpts(d, imageID)
+		log.Printf("[DEBUG] openstack_compute_instance_v2 %s rebuild options: %#v", d.Id(), rebuildOpts.RebuildOpts)
+		if _, err := servers.Rebuild(rebuildClient, d.Id(), rebuildOpts).Extract(); err != nil {
+			return diag.Errorf("Error rebuilding OpenStack server (%s): %s", d.Id(), err)
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"REBUILD"},
+			Target:     []string{"ACTIVE", "SHUTOFF"},
+			Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		log.Printf("[DEBUG] Waiting for instance (%s) to rebuild", d.Id())
+		_, err = stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return diag.Errorf("Error waiting for instance (%s) to rebuild: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("network") {
+		if err := computeV2InstanceUpdateNetworks(ctx, computeClient, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("block_device") {
+		blockDeviceClient, err := config.microversionClient(computeClient, computeV2InstanceBlockDeviceMicroversions(d)...)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := computeV2InstanceUpdateBlockDevices(ctx, blockDeviceClient, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("power_state") {
+		powerStateOld, powerStateNew := d.GetChange("power_state")
+		err := computeV2InstanceSetPowerState(ctx, computeClient, d, powerStateOld.(string), powerStateNew.(string), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -831,7 +883,7 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		for _, key := range metadataToDelete {
 			err := servers.DeleteMetadatum(computeClient, d.Id(), key).ExtractErr()
 			if err != nil {
-				return fmt.Errorf("Error deleting metadata (%s) from server (%s): %s", key, d.Id(), err)
+				return diag.Errorf("Error deleting metadata (%s) from server (%s): %s", key, d.Id(), err)
 			}
 		}
 
@@ -843,7 +895,7 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 
 		_, err := servers.UpdateMetadata(computeClient, d.Id(), metadataOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating OpenStack server (%s) metadata: %s", d.Id(), err)
+			return diag.Errorf("Error updating OpenStack server (%s) metadata: %s", d.Id(), err)
 		}
 	}
 
@@ -865,7 +917,7 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 					continue
 				}
 
-				return fmt.Errorf("Error removing security group (%s) from OpenStack server (%s): %s", g, d.Id(), err)
+				return diag.Errorf("Error removing security group (%s) from OpenStack server (%s): %s", g, d.Id(), err)
 			} else {
 				log.Printf("[DEBUG] Removed security group (%s) from instance (%s)", g, d.Id())
 			}
@@ -874,17 +926,18 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		for _, g := range secgroupsToAdd.List() {
 			err := secgroups.AddServer(computeClient, d.Id(), g.(string)).ExtractErr()
 			if err != nil && err.Error() != "EOF" {
-				return fmt.Errorf("Error adding security group (%s) to OpenStack server (%s): %s", g, d.Id(), err)
+				return diag.Errorf("Error adding security group (%s) to OpenStack server (%s): %s", g, d.Id(), err)
 			}
 			log.Printf("[DEBUG] Added security group (%s) to instance (%s)", g, d.Id())
 		}
 	}
 
-	if d.HasChange("admin_pass") {
+	// A rebuild already sets the admin password.
+	if d.HasChange("admin_pass") && !rebuilt {
 		if newPwd, ok := d.Get("admin_pass").(string); ok {
 			err := servers.ChangeAdminPassword(computeClient, d.Id(), newPwd).ExtractErr()
 			if err != nil {
-				return fmt.Errorf("Error changing admin password of OpenStack server (%s): %s", d.Id(), err)
+				return diag.Errorf("Error changing admin password of OpenStack server (%s): %s", d.Id(), err)
 			}
 		}
 	}
@@ -904,9 +957,9 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 			newFlavorId = d.Get("flavor_id").(string)
 		} else {
 			newFlavorName := d.Get("flavor_name").(string)
-			newFlavorId, err = flavors_utils.IDFromName(computeClient, newFlavorName)
+			newFlavorId, err = config.flavorIDFromName(computeClient, newFlavorName)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 
@@ -916,7 +969,7 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		log.Printf("[DEBUG] Resize configuration: %#v", resizeOpts)
 		err = servers.Resize(computeClient, d.Id(), resizeOpts).ExtractErr()
 		if err != nil {
-			return fmt.Errorf("Error resizing OpenStack server: %s", err)
+			return diag.Errorf("Error resizing OpenStack server: %s", err)
 		}
 
 		// Wait for the instance to finish resizing.
@@ -933,9 +986,9 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 				MinTimeout: 3 * time.Second,
 			}
 
-			_, err = stateConf.WaitForState()
+			_, err = stateConf.WaitForStateContext(ctx)
 			if err != nil {
-				return fmt.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
+				return diag.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
 			}
 		} else {
 			stateConf := &resource.StateChangeConf{
@@ -947,16 +1000,16 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 				MinTimeout: 3 * time.Second,
 			}
 
-			_, err = stateConf.WaitForState()
+			_, err = stateConf.WaitForStateContext(ctx)
 			if err != nil {
-				return fmt.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
+				return diag.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
 			}
 
 			// Confirm resize.
 			log.Printf("[DEBUG] Confirming resize")
 			err = servers.ConfirmResize(computeClient, d.Id()).ExtractErr()
 			if err != nil {
-				return fmt.Errorf("Error confirming resize of OpenStack server: %s", err)
+				return diag.Errorf("Error confirming resize of OpenStack server: %s", err)
 			}
 
 			stateConf = &resource.StateChangeConf{
@@ -968,33 +1021,42 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 				MinTimeout: 3 * time.Second,
 			}
 
-			_, err = stateConf.WaitForState()
+			_, err = stateConf.WaitForStateContext(ctx)
 			if err != nil {
-				return fmt.Errorf("Error waiting for instance (%s) to confirm resize: %s", d.Id(), err)
+				return diag.Errorf("Error waiting for instance (%s) to confirm resize: %s", d.Id(), err)
 			}
 		}
 	}
 
 	// Perform any required updates to the tags.
-	if d.HasChange("tags") {
-		instanceTags := computeV2InstanceUpdateTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		instanceTags := computeV2InstanceUpdateTags(d, config.DefaultTags)
 		instanceTagsOpts := tags.ReplaceAllOpts{Tags: instanceTags}
-		computeClient.Microversion = computeV2TagsExtensionMicroversion
-		instanceTags, err := tags.ReplaceAll(computeClient, d.Id(), instanceTagsOpts).Extract()
+		tagsClient, err := config.microversionClient(computeClient, microversionRequirement{"tags", computeV2TagsExtensionMicroversion})
 		if err != nil {
-			return fmt.Errorf("Error setting tags on openstack_compute_instance_v2 %s: %s", d.Id(), err)
+			return diag.FromErr(err)
+		}
+		instanceTags, err = tags.ReplaceAll(tagsClient, d.Id(), instanceTagsOpts).Extract()
+		if err != nil {
+			return diag.Errorf("Error setting tags on openstack_compute_instance_v2 %s: %s", d.Id(), err)
 		}
 		log.Printf("[DEBUG] Set tags %s on openstack_compute_instance_v2 %s", instanceTags, d.Id())
 	}
 
-	return resourceComputeInstanceV2Read(d, meta)
+	return nil
 }
 
-func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeInstanceV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
+	}
+
+	if d.Get("locked").(bool) {
+		if err := computeV2InstanceAction(computeClient, d, "unlock"); err != nil {
+			return diag.Errorf("Error unlocking OpenStack instance %s: %s", d.Id(), err)
+		}
 	}
 
 	if d.Get("stop_before_destroy").(bool) {
@@ -1011,7 +1073,7 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 				MinTimeout: 3 * time.Second,
 			}
 			log.Printf("[DEBUG] Waiting for instance (%s) to stop", d.Id())
-			_, err = stopStateConf.WaitForState()
+			_, err = stopStateConf.WaitForStateContext(ctx)
 			if err != nil {
 				log.Printf("[WARN] Error waiting for instance (%s) to stop: %s, proceeding to delete", d.Id(), err)
 			}
@@ -1024,7 +1086,7 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 		detachPortBeforeDestroy = vendorOptions["detach_ports_before_destroy"].(bool)
 	}
 	if detachPortBeforeDestroy {
-		allInstanceNetworks, err := getAllInstanceNetworks(d, meta)
+		allInstanceNetworks, err := getAllInstanceNetworks(ctx, d, meta)
 		if err != nil {
 			log.Printf("[WARN] Unable to get openstack_compute_instance_v2 ports: %s", err)
 		} else {
@@ -1039,8 +1101,8 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 						Delay:      5 * time.Second,
 						MinTimeout: 5 * time.Second,
 					}
-					if _, err = stateConf.WaitForState(); err != nil {
-						return fmt.Errorf("Error detaching openstack_compute_instance_v2 %s: %s", d.Id(), err)
+					if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+						return diag.Errorf("Error detaching openstack_compute_instance_v2 %s: %s", d.Id(), err)
 					}
 				}
 			}
@@ -1050,13 +1112,13 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 		log.Printf("[DEBUG] Force deleting OpenStack Instance %s", d.Id())
 		err = servers.ForceDelete(computeClient, d.Id()).ExtractErr()
 		if err != nil {
-			return CheckDeleted(d, err, "Error force deleting openstack_compute_instance_v2")
+			return diag.FromErr(CheckDeleted(d, err, "Error force deleting openstack_compute_instance_v2"))
 		}
 	} else {
 		log.Printf("[DEBUG] Deleting OpenStack Instance %s", d.Id())
 		err = servers.Delete(computeClient, d.Id()).ExtractErr()
 		if err != nil {
-			return CheckDeleted(d, err, "Error deleting openstack_compute_instance_v2")
+			return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_compute_instance_v2"))
 		}
 	}
 
@@ -1072,9 +1134,9 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for instance (%s) to delete: %s",
 			d.Id(), err)
 	}
@@ -1082,22 +1144,21 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceOpenStackComputeInstanceV2ImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceOpenStackComputeInstanceV2ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 	var serverWithAttachments struct {
 		VolumesAttached []map[string]interface{} `json:"os-extended-volumes:volumes_attached"`
 	}
 
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
 		return nil, fmt.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	results := make([]*schema.ResourceData, 1)
-	err = resourceComputeInstanceV2Read(d, meta)
-	if err != nil {
-		return nil, fmt.Errorf("Error reading openstack_compute_instance_v2 %s: %s", d.Id(), err)
+	if diags := resourceComputeInstanceV2Read(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("Error reading openstack_compute_instance_v2 %s: %v", d.Id(), diags)
 	}
 
 	raw := servers.Get(computeClient, d.Id())
@@ -1112,7 +1173,7 @@ func resourceOpenStackComputeInstanceV2ImportState(d *schema.ResourceData, meta
 
 	bds := []map[string]interface{}{}
 	if len(serverWithAttachments.VolumesAttached) > 0 {
-		blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+		blockStorageClient, err := config.BlockStorageV2Client(ctx, GetRegion(d, config))
 		if err != nil {
 			return nil, fmt.Errorf("Error creating OpenStack volume client: %s", err)
 		}
@@ -1166,6 +1227,11 @@ func ServerV2StateRefreshFunc(client *gophercloud.ServiceClient, instanceID stri
 			return nil, "", err
 		}
 
+		// Surface the reason of the failure instead of an unexpected state.
+		if s.Status == "ERROR" && s.Fault.Message != "" {
+			return s, s.Status, fmt.Errorf("The instance is in error status: %s", s.Fault.Message)
+		}
+
 		return s, s.Status, nil
 	}
 }
@@ -1266,7 +1332,7 @@ func resourceInstanceSchedulerHintsV2(d *schema.ResourceData, schedulerHintsRaw
 	return schedulerHints
 }
 
-func getImageIDFromConfig(computeClient *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
+func getImageIDFromConfig(config *Config, computeClient *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
 	// If block_device was used, an Image does not need to be specified, unless an image/local
 	// combination was used. This emulates normal boot behavior. Otherwise, ignore the image altogether.
 	if vL, ok := d.GetOk("block_device"); ok {
@@ -1300,7 +1366,7 @@ func getImageIDFromConfig(computeClient *gophercloud.ServiceClient, d *schema.Re
 	}
 
 	if imageName != "" {
-		imageId, err := images_utils.IDFromName(computeClient, imageName)
+		imageId, err := config.imageIDFromName(computeClient, imageName)
 		if err != nil {
 			return "", err
 		}
@@ -1310,7 +1376,7 @@ func getImageIDFromConfig(computeClient *gophercloud.ServiceClient, d *schema.Re
 	return "", fmt.Errorf("Neither a boot device, image ID, or image name were able to be determined.")
 }
 
-func setImageInformation(computeClient *gophercloud.ServiceClient, server *servers.Server, d *schema.ResourceData) error {
+func setImageInformation(config *Config, computeClient *gophercloud.ServiceClient, server *servers.Server, d *schema.ResourceData) error {
 	// If block_device was used, an Image does not need to be specified, unless an image/local
 	// combination was used. This emulates normal boot behavior. Otherwise, ignore the image altogether.
 	if vL, ok := d.GetOk("block_device"); ok {
@@ -1331,7 +1397,7 @@ func setImageInformation(computeClient *gophercloud.ServiceClient, server *serve
 		imageId := server.Image["id"].(string)
 		if imageId != "" {
 			d.Set("image_id", imageId)
-			if image, err := images.Get(computeClient, imageId).Extract(); err != nil {
+			if image, err := config.image(computeClient, imageId); err != nil {
 				if _, ok := err.(gophercloud.ErrDefault404); ok {
 					// If the image name can't be found, set the value to "Image not found".
 					// The most likely scenario is that the image no longer exists in the Image Service
@@ -1349,7 +1415,7 @@ func setImageInformation(computeClient *gophercloud.ServiceClient, server *serve
 	return nil
 }
 
-func getFlavorID(computeClient *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
+func getFlavorID(config *Config, computeClient *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
 	if flavorId := d.Get("flavor_id").(string); flavorId != "" {
 		return flavorId, nil
 	} else {
@@ -1368,7 +1434,7 @@ func getFlavorID(computeClient *gophercloud.ServiceClient, d *schema.ResourceDat
 	}
 
 	if flavorName != "" {
-		flavorId, err := flavors_utils.IDFromName(computeClient, flavorName)
+		flavorId, err := config.flavorIDFromName(computeClient, flavorName)
 		if err != nil {
 			return "", err
 		}
@@ -1487,5 +1553,10 @@ func suppressPowerStateDiffs(k, old, new string, d *schema.ResourceData) bool {
 		return true
 	}
 
+	// Nova offloads shelved instances right away if shelved_offload_time is 0.
+	if old == "shelved_offloaded" && strings.ToLower(new) == "shelved" {
+		return true
+	}
+
 	return false
 }
@@ -1,20 +1,21 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceComputeKeypairV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceComputeKeypairV2Create,
-		Read:   resourceComputeKeypairV2Read,
-		Delete: resourceComputeKeypairV2Delete,
+		CreateContext: resourceComputeKeypairV2Create,
+		ReadContext:   resourceComputeKeypairV2Read,
+		DeleteContext: resourceComputeKeypairV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,11 +59,11 @@ func resourceComputeKeypairV2() *schema.Resource {
 	}
 }
 
-func resourceComputeKeypairV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeKeypairV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	name := d.Get("name").(string)
@@ -78,7 +79,7 @@ func resourceComputeKeypairV2Create(d *schema.ResourceData, meta interface{}) er
 
 	kp, err := keypairs.Create(computeClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Unable to create openstack_compute_keypair_v2 %s: %s", name, err)
+		return diag.Errorf("Unable to create openstack_compute_keypair_v2 %s: %s", name, err)
 	}
 
 	d.SetId(kp.Name)
@@ -86,19 +87,19 @@ func resourceComputeKeypairV2Create(d *schema.ResourceData, meta interface{}) er
 	// Private Key is only available in the response to a create.
 	d.Set("private_key", kp.PrivateKey)
 
-	return resourceComputeKeypairV2Read(d, meta)
+	return resourceComputeKeypairV2Read(ctx, d, meta)
 }
 
-func resourceComputeKeypairV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeKeypairV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	kp, err := keypairs.Get(computeClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_compute_keypair_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_compute_keypair_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_compute_keypair_v2 %s: %#v", d.Id(), kp)
@@ -111,16 +112,16 @@ func resourceComputeKeypairV2Read(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
-func resourceComputeKeypairV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeKeypairV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	err = keypairs.Delete(computeClient, d.Id()).ExtractErr()
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_compute_keypair_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_compute_keypair_v2"))
 	}
 
 	return nil
@@ -1,22 +1,23 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceComputeQuotasetV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceComputeQuotasetV2Create,
-		Read:   resourceComputeQuotasetV2Read,
-		Update: resourceComputeQuotasetV2Update,
-		Delete: schema.RemoveFromState,
+		CreateContext: resourceComputeQuotasetV2Create,
+		ReadContext:   resourceComputeQuotasetV2Read,
+		UpdateContext: resourceComputeQuotasetV2Update,
+		DeleteContext: schema.NoopContext,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -126,11 +127,11 @@ func resourceComputeQuotasetV2() *schema.Resource {
 	}
 }
 
-func resourceComputeQuotasetV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeQuotasetV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	projectID := d.Get("project_id").(string)
@@ -168,26 +169,26 @@ func resourceComputeQuotasetV2Create(d *schema.ResourceData, meta interface{}) e
 
 	q, err := quotasets.Update(computeClient, projectID, updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_compute_quotaset_v2: %s", err)
+		return diag.Errorf("Error creating openstack_compute_quotaset_v2: %s", err)
 	}
 
 	d.SetId(projectID)
 
 	log.Printf("[DEBUG] Created openstack_compute_quotaset_v2 %#v", q)
 
-	return resourceComputeQuotasetV2Read(d, meta)
+	return resourceComputeQuotasetV2Read(ctx, d, meta)
 }
 
-func resourceComputeQuotasetV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeQuotasetV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	q, err := quotasets.Get(computeClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_compute_quotaset_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_compute_quotaset_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_compute_quotaset_v2 %s: %#v", d.Id(), q)
@@ -211,11 +212,11 @@ func resourceComputeQuotasetV2Read(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceComputeQuotasetV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeQuotasetV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	var (
@@ -311,9 +312,9 @@ func resourceComputeQuotasetV2Update(d *schema.ResourceData, meta interface{}) e
 		log.Printf("[DEBUG] openstack_compute_quotaset_v2 %s update options: %#v", d.Id(), updateOpts)
 		_, err := quotasets.Update(computeClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_compute_quotaset_v2: %s", err)
+			return diag.Errorf("Error updating openstack_compute_quotaset_v2: %s", err)
 		}
 	}
 
-	return resourceComputeQuotasetV2Read(d, meta)
+	return resourceComputeQuotasetV2Read(ctx, d, meta)
 }
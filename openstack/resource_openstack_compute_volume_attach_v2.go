@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,17 +9,18 @@ import (
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceComputeVolumeAttachV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceComputeVolumeAttachV2Create,
-		Read:   resourceComputeVolumeAttachV2Read,
-		Delete: resourceComputeVolumeAttachV2Delete,
+		CreateContext: resourceComputeVolumeAttachV2Create,
+		ReadContext:   resourceComputeVolumeAttachV2Read,
+		DeleteContext: resourceComputeVolumeAttachV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -26,6 +28,8 @@ func resourceComputeVolumeAttachV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: customizeDiffMicroversions((*Config).ComputeV2Client, computeVolumeAttachV2DiffMicroversions),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -61,11 +65,11 @@ func resourceComputeVolumeAttachV2() *schema.Resource {
 	}
 }
 
-func resourceComputeVolumeAttachV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeVolumeAttachV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	instanceId := d.Get("instance_id").(string)
@@ -84,13 +88,13 @@ func resourceComputeVolumeAttachV2Create(d *schema.ResourceData, meta interface{
 	log.Printf("[DEBUG] openstack_compute_volume_attach_v2 attach options %s: %#v", instanceId, attachOpts)
 
 	multiattach := d.Get("multiattach").(bool)
-	if multiattach {
-		computeClient.Microversion = "2.60"
+	if err := config.setMicroversion(computeClient, computeVolumeAttachV2Microversions(d)...); err != nil {
+		return diag.FromErr(err)
 	}
 
 	var attachment *volumeattach.VolumeAttachment
 	timeout := d.Timeout(schema.TimeoutCreate)
-	err = resource.Retry(timeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		attachment, err = volumeattach.Create(computeClient, instanceId, attachOpts).Extract()
 		if err != nil {
 			if _, ok := err.(gophercloud.ErrDefault400); ok && multiattach {
@@ -104,7 +108,7 @@ func resourceComputeVolumeAttachV2Create(d *schema.ResourceData, meta interface{
 	})
 
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_compute_volume_attach_v2 %s: %s", instanceId, err)
+		return diag.Errorf("Error creating openstack_compute_volume_attach_v2 %s: %s", instanceId, err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -116,8 +120,8 @@ func resourceComputeVolumeAttachV2Create(d *schema.ResourceData, meta interface{
 		MinTimeout: 3 * time.Second,
 	}
 
-	if _, err = stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error attaching openstack_compute_volume_attach_v2 %s: %s", instanceId, err)
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("Error attaching openstack_compute_volume_attach_v2 %s: %s", instanceId, err)
 	}
 
 	// Use the instance ID and attachment ID as the resource ID.
@@ -126,24 +130,24 @@ func resourceComputeVolumeAttachV2Create(d *schema.ResourceData, meta interface{
 
 	d.SetId(id)
 
-	return resourceComputeVolumeAttachV2Read(d, meta)
+	return resourceComputeVolumeAttachV2Read(ctx, d, meta)
 }
 
-func resourceComputeVolumeAttachV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeVolumeAttachV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	instanceId, attachmentId, err := computeVolumeAttachV2ParseID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	attachment, err := volumeattach.Get(computeClient, instanceId, attachmentId).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_compute_volume_attach_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_compute_volume_attach_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_compute_volume_attach_v2 %s: %#v", d.Id(), attachment)
@@ -156,16 +160,16 @@ func resourceComputeVolumeAttachV2Read(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceComputeVolumeAttachV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceComputeVolumeAttachV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	computeClient, err := config.ComputeV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack compute client: %s", err)
+		return diag.Errorf("Error creating OpenStack compute client: %s", err)
 	}
 
 	instanceId, attachmentId, err := computeVolumeAttachV2ParseID(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -177,8 +181,8 @@ func resourceComputeVolumeAttachV2Delete(d *schema.ResourceData, meta interface{
 		MinTimeout: 3 * time.Second,
 	}
 
-	if _, err = stateConf.WaitForState(); err != nil {
-		return CheckDeleted(d, err, "Error detaching openstack_compute_volume_attach_v2")
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error detaching openstack_compute_volume_attach_v2"))
 	}
 
 	return nil
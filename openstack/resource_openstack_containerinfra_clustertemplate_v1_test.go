@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -83,7 +84,7 @@ func testAccCheckContainerInfraV1ClusterTemplateExists(n string, clustertemplate
 		}
 
 		config := testAccProvider.Meta().(*Config)
-		containerInfraClient, err := config.ContainerInfraV1Client(OS_REGION_NAME)
+		containerInfraClient, err := config.ContainerInfraV1Client(context.TODO(), OS_REGION_NAME)
 		if err != nil {
 			return fmt.Errorf("Error creating OpenStack container infra client: %s", err)
 		}
@@ -105,7 +106,7 @@ func testAccCheckContainerInfraV1ClusterTemplateExists(n string, clustertemplate
 
 func testAccCheckContainerInfraV1ClusterTemplateDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
-	containerInfraClient, err := config.ContainerInfraV1Client(OS_REGION_NAME)
+	containerInfraClient, err := config.ContainerInfraV1Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return fmt.Errorf("Error creating OpenStack container infra client: %s", err)
 	}
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,16 +9,20 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/db/v1/databases"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/instances"
 	"github.com/gophercloud/gophercloud/openstack/db/v1/users"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDatabaseInstanceV1() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDatabaseInstanceV1Create,
-		Read:   resourceDatabaseInstanceV1Read,
-		Delete: resourceDatabaseInstanceV1Delete,
-		Update: resourceDatabaseInstanceUpdate,
+		CreateContext: resourceDatabaseInstanceV1Create,
+		ReadContext:   resourceDatabaseInstanceV1Read,
+		DeleteContext: resourceDatabaseInstanceV1Delete,
+		UpdateContext: resourceDatabaseInstanceUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDatabaseInstanceV1Import,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -169,11 +174,11 @@ func resourceDatabaseInstanceV1() *schema.Resource {
 	}
 }
 
-func resourceDatabaseInstanceV1Create(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseInstanceV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
 	createOpts := &instances.CreateOpts{
@@ -214,7 +219,7 @@ func resourceDatabaseInstanceV1Create(d *schema.ResourceData, meta interface{})
 
 	instance, err := instances.Create(DatabaseV1Client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_db_instance_v1: %s", err)
+		return diag.Errorf("Error creating openstack_db_instance_v1: %s", err)
 	}
 
 	// Wait for the instance to become available.
@@ -229,16 +234,16 @@ func resourceDatabaseInstanceV1Create(d *schema.ResourceData, meta interface{})
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_db_instance_v1 %s to become ready: %s", instance.ID, err)
+		return diag.Errorf("Error waiting for openstack_db_instance_v1 %s to become ready: %s", instance.ID, err)
 	}
 
 	if configuration, ok := d.GetOk("configuration_id"); ok {
 		log.Printf("[DEBUG] Attaching configuration %s to openstack_db_instance_v1 %s", configuration, instance.ID)
 		err := instances.AttachConfigurationGroup(DatabaseV1Client, instance.ID, configuration.(string)).ExtractErr()
 		if err != nil {
-			return fmt.Errorf("error attaching configuration group %s to openstack_db_instance_v1 %s: %s",
+			return diag.Errorf("error attaching configuration group %s to openstack_db_instance_v1 %s: %s",
 				configuration, instance.ID, err)
 		}
 	}
@@ -246,36 +251,47 @@ func resourceDatabaseInstanceV1Create(d *schema.ResourceData, meta interface{})
 	// Store the ID now
 	d.SetId(instance.ID)
 
-	return resourceDatabaseInstanceV1Read(d, meta)
+	return resourceDatabaseInstanceV1Read(ctx, d, meta)
 }
 
-func resourceDatabaseInstanceV1Read(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseInstanceV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
-	instance, err := instances.Get(DatabaseV1Client, d.Id()).Extract()
+	r := instances.Get(DatabaseV1Client, d.Id())
+	instance, err := r.Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_db_instance_v1")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_db_instance_v1"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_db_instance_v1 %s: %#v", d.Id(), instance)
 
+	configurationID, err := databaseInstanceV1ConfigurationID(r)
+	if err != nil {
+		return diag.Errorf("Error retrieving openstack_db_instance_v1 %s configuration: %s", d.Id(), err)
+	}
+
 	d.Set("name", instance.Name)
-	d.Set("flavor_id", instance.Flavor)
-	d.Set("datastore", instance.Datastore)
+	d.Set("flavor_id", instance.Flavor.ID)
+	d.Set("size", instance.Volume.Size)
+	d.Set("configuration_id", configurationID)
 	d.Set("region", GetRegion(d, config))
 
+	if err := d.Set("datastore", flattenDatabaseInstanceV1Datastore(instance.Datastore)); err != nil {
+		log.Printf("[DEBUG] Unable to set openstack_db_instance_v1 %s datastore: %s", d.Id(), err)
+	}
+
 	return nil
 }
 
-func resourceDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
 	if d.HasChange("configuration_id") {
@@ -283,32 +299,32 @@ func resourceDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 
 		err := instances.DetachConfigurationGroup(DatabaseV1Client, d.Id()).ExtractErr()
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		log.Printf("Detaching configuration %s from openstack_db_instance_v1 %s", old, d.Id())
 
 		if new != "" {
 			err := instances.AttachConfigurationGroup(DatabaseV1Client, d.Id(), new.(string)).ExtractErr()
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			log.Printf("Attaching configuration %s to openstack_db_instance_v1 %s", new, d.Id())
 		}
 	}
 
-	return resourceDatabaseInstanceV1Read(d, meta)
+	return resourceDatabaseInstanceV1Read(ctx, d, meta)
 }
 
-func resourceDatabaseInstanceV1Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseInstanceV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
 	err = instances.Delete(DatabaseV1Client, d.Id()).ExtractErr()
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_db_instance_v1")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_db_instance_v1"))
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -320,10 +336,43 @@ func resourceDatabaseInstanceV1Delete(d *schema.ResourceData, meta interface{})
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_db_instance_v1 %s to delete: %s", d.Id(), err)
+		return diag.Errorf("Error waiting for openstack_db_instance_v1 %s to delete: %s", d.Id(), err)
 	}
 
 	return nil
 }
+
+// resourceDatabaseInstanceV1Import also imports the databases and users of
+// the instance. Read leaves them alone, because they can be managed by
+// openstack_db_database_v1 and openstack_db_user_v1 resources as well.
+func resourceDatabaseInstanceV1Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating OpenStack database client: %s", err)
+	}
+
+	pages, err := databases.List(DatabaseV1Client, d.Id()).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving openstack_db_instance_v1 %s databases: %s", d.Id(), err)
+	}
+	allDatabases, err := databases.ExtractDBs(pages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting openstack_db_instance_v1 %s databases: %s", d.Id(), err)
+	}
+	if err := d.Set("database", flattenDatabaseInstanceV1Databases(allDatabases)); err != nil {
+		return nil, fmt.Errorf("Unable to set openstack_db_instance_v1 %s databases: %s", d.Id(), err)
+	}
+
+	allUsers, err := databaseUserV1List(DatabaseV1Client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving openstack_db_instance_v1 %s users: %s", d.Id(), err)
+	}
+	if err := d.Set("user", flattenDatabaseInstanceV1Users(allUsers)); err != nil {
+		return nil, fmt.Errorf("Unable to set openstack_db_instance_v1 %s users: %s", d.Id(), err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
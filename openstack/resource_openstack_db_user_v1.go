@@ -1,20 +1,25 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/db/v1/users"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDatabaseUserV1() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDatabaseUserV1Create,
-		Read:   resourceDatabaseUserV1Read,
-		Delete: resourceDatabaseUserV1Delete,
+		CreateContext: resourceDatabaseUserV1Create,
+		ReadContext:   resourceDatabaseUserV1Read,
+		DeleteContext: resourceDatabaseUserV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDatabaseUserV1Import,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -65,11 +70,11 @@ func resourceDatabaseUserV1() *schema.Resource {
 	}
 }
 
-func resourceDatabaseUserV1Create(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseUserV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
 	userName := d.Get("name").(string)
@@ -86,7 +91,7 @@ func resourceDatabaseUserV1Create(d *schema.ResourceData, meta interface{}) erro
 
 	err = users.Create(DatabaseV1Client, instanceID, usersList).ExtractErr()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_db_user_v1: %s", err)
+		return diag.Errorf("Error creating openstack_db_user_v1: %s", err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -98,27 +103,27 @@ func resourceDatabaseUserV1Create(d *schema.ResourceData, meta interface{}) erro
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_db_user_v1 %s to be created: %s", userName, err)
+		return diag.Errorf("Error waiting for openstack_db_user_v1 %s to be created: %s", userName, err)
 	}
 
 	// Store the ID now
 	d.SetId(fmt.Sprintf("%s/%s", instanceID, userName))
 
-	return resourceDatabaseUserV1Read(d, meta)
+	return resourceDatabaseUserV1Read(ctx, d, meta)
 }
 
-func resourceDatabaseUserV1Read(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseUserV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
 	userID := strings.SplitN(d.Id(), "/", 2)
 	if len(userID) != 2 {
-		return fmt.Errorf("Invalid openstack_db_user_v1 ID: %s", d.Id())
+		return diag.Errorf("Invalid openstack_db_user_v1 ID: %s", d.Id())
 	}
 
 	instanceID := userID[0]
@@ -126,7 +131,7 @@ func resourceDatabaseUserV1Read(d *schema.ResourceData, meta interface{}) error
 
 	exists, userObj, err := databaseUserV1Exists(DatabaseV1Client, instanceID, userName)
 	if err != nil {
-		return fmt.Errorf("Error checking if openstack_db_user_v1 %s exists: %s", d.Id(), err)
+		return diag.Errorf("Error checking if openstack_db_user_v1 %s exists: %s", d.Id(), err)
 	}
 
 	if !exists {
@@ -135,25 +140,28 @@ func resourceDatabaseUserV1Read(d *schema.ResourceData, meta interface{}) error
 	}
 
 	d.Set("name", userName)
+	d.Set("instance_id", instanceID)
+	d.Set("host", flattenDatabaseUserV1Host(d.Get("host").(string), userObj.Host))
+	d.Set("region", GetRegion(d, config))
 
 	databases := flattenDatabaseUserV1Databases(userObj.Databases)
 	if err := d.Set("databases", databases); err != nil {
-		return fmt.Errorf("Unable to set databases: %s", err)
+		return diag.Errorf("Unable to set databases: %s", err)
 	}
 
 	return nil
 }
 
-func resourceDatabaseUserV1Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceDatabaseUserV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	DatabaseV1Client, err := config.DatabaseV1Client(GetRegion(d, config))
+	DatabaseV1Client, err := config.DatabaseV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack database client: %s", err)
+		return diag.Errorf("Error creating OpenStack database client: %s", err)
 	}
 
 	userID := strings.SplitN(d.Id(), "/", 2)
 	if len(userID) != 2 {
-		return fmt.Errorf("Invalid openstack_db_user_v1 ID: %s", d.Id())
+		return diag.Errorf("Invalid openstack_db_user_v1 ID: %s", d.Id())
 	}
 
 	instanceID := userID[0]
@@ -161,7 +169,7 @@ func resourceDatabaseUserV1Delete(d *schema.ResourceData, meta interface{}) erro
 
 	exists, _, err := databaseUserV1Exists(DatabaseV1Client, instanceID, userName)
 	if err != nil {
-		return fmt.Errorf("Error checking if openstack_db_user_v1 %s exists: %s", d.Id(), err)
+		return diag.Errorf("Error checking if openstack_db_user_v1 %s exists: %s", d.Id(), err)
 	}
 
 	if !exists {
@@ -170,8 +178,17 @@ func resourceDatabaseUserV1Delete(d *schema.ResourceData, meta interface{}) erro
 
 	err = users.Delete(DatabaseV1Client, instanceID, userName).ExtractErr()
 	if err != nil {
-		return fmt.Errorf("Error deleting openstack_db_user_v1 %s: %s", d.Id(), err)
+		return diag.Errorf("Error deleting openstack_db_user_v1 %s: %s", d.Id(), err)
 	}
 
 	return nil
 }
+
+func resourceDatabaseUserV1Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	userID := strings.SplitN(d.Id(), "/", 2)
+	if len(userID) != 2 || userID[0] == "" || userID[1] == "" {
+		return nil, fmt.Errorf("Invalid format specified for openstack_db_user_v1. Format must be <instance id>/<user name>")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -1,12 +1,13 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -14,12 +15,12 @@ import (
 
 func resourceDNSZoneV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDNSZoneV2Create,
-		Read:   resourceDNSZoneV2Read,
-		Update: resourceDNSZoneV2Update,
-		Delete: resourceDNSZoneV2Delete,
+		CreateContext: resourceDNSZoneV2Create,
+		ReadContext:   resourceDNSZoneV2Read,
+		UpdateContext: resourceDNSZoneV2Update,
+		DeleteContext: resourceDNSZoneV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -93,11 +94,11 @@ func resourceDNSZoneV2() *schema.Resource {
 	}
 }
 
-func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceDNSZoneV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dnsClient, err := config.DNSV2Client(GetRegion(d, config))
+	dnsClient, err := config.DNSV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack DNS client: %s", err)
+		return diag.Errorf("Error creating OpenStack DNS client: %s", err)
 	}
 
 	createOpts := ZoneCreateOpts{
@@ -116,7 +117,7 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] openstack_dns_zone_v2 create options: %#v", createOpts)
 	n, err := zones.Create(dnsClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_dns_zone_v2: %s", err)
+		return diag.Errorf("Error creating openstack_dns_zone_v2: %s", err)
 	}
 
 	log.Printf("[DEBUG] Waiting for openstack_dns_zone_v2 %s to become available", n.ID)
@@ -129,24 +130,24 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 
 	d.SetId(n.ID)
 
 	log.Printf("[DEBUG] Created OpenStack DNS Zone %s: %#v", n.ID, n)
-	return resourceDNSZoneV2Read(d, meta)
+	return resourceDNSZoneV2Read(ctx, d, meta)
 }
 
-func resourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceDNSZoneV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dnsClient, err := config.DNSV2Client(GetRegion(d, config))
+	dnsClient, err := config.DNSV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack DNS client: %s", err)
+		return diag.Errorf("Error creating OpenStack DNS client: %s", err)
 	}
 
 	n, err := zones.Get(dnsClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_dns_zone_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_dns_zone_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_dns_zone_v2 %s: %#v", d.Id(), n)
@@ -163,11 +164,11 @@ func resourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceDNSZoneV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceDNSZoneV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dnsClient, err := config.DNSV2Client(GetRegion(d, config))
+	dnsClient, err := config.DNSV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack DNS client: %s", err)
+		return diag.Errorf("Error creating OpenStack DNS client: %s", err)
 	}
 
 	var updateOpts zones.UpdateOpts
@@ -192,7 +193,7 @@ func resourceDNSZoneV2Update(d *schema.ResourceData, meta interface{}) error {
 
 	_, err = zones.Update(dnsClient, d.Id(), updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error updating openstack_dns_zone_v2 %s: %s", d.Id(), err)
+		return diag.Errorf("Error updating openstack_dns_zone_v2 %s: %s", d.Id(), err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -204,21 +205,21 @@ func resourceDNSZoneV2Update(d *schema.ResourceData, meta interface{}) error {
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 
-	return resourceDNSZoneV2Read(d, meta)
+	return resourceDNSZoneV2Read(ctx, d, meta)
 }
 
-func resourceDNSZoneV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceDNSZoneV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	dnsClient, err := config.DNSV2Client(GetRegion(d, config))
+	dnsClient, err := config.DNSV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack DNS client: %s", err)
+		return diag.Errorf("Error creating OpenStack DNS client: %s", err)
 	}
 
 	_, err = zones.Delete(dnsClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_dns_zone_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_dns_zone_v2"))
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -230,7 +231,7 @@ func resourceDNSZoneV2Delete(d *schema.ResourceData, meta interface{}) error {
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 
 	return nil
 }
@@ -1,23 +1,24 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceIdentityApplicationCredentialV3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIdentityApplicationCredentialV3Create,
-		Read:   resourceIdentityApplicationCredentialV3Read,
-		Delete: resourceIdentityApplicationCredentialV3Delete,
+		CreateContext: resourceIdentityApplicationCredentialV3Create,
+		ReadContext:   resourceIdentityApplicationCredentialV3Read,
+		DeleteContext: resourceIdentityApplicationCredentialV3Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -114,16 +115,16 @@ func resourceIdentityApplicationCredentialV3() *schema.Resource {
 	}
 }
 
-func resourceIdentityApplicationCredentialV3Create(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityApplicationCredentialV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	userID, _, err := GetTokenInfo(identityClient)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var expiresAt *time.Time
@@ -147,9 +148,9 @@ func resourceIdentityApplicationCredentialV3Create(d *schema.ResourceData, meta
 	applicationCredential, err := applicationcredentials.Create(identityClient, userID, createOpts).Extract()
 	if err != nil {
 		if v, ok := err.(gophercloud.ErrDefault404); ok {
-			return fmt.Errorf("Error creating openstack_identity_application_credential_v3: %s", v.ErrUnexpectedResponseCode.Body)
+			return diag.Errorf("Error creating openstack_identity_application_credential_v3: %s", v.ErrUnexpectedResponseCode.Body)
 		}
-		return fmt.Errorf("Error creating openstack_identity_application_credential_v3: %s", err)
+		return diag.Errorf("Error creating openstack_identity_application_credential_v3: %s", err)
 	}
 
 	d.SetId(applicationCredential.ID)
@@ -157,24 +158,24 @@ func resourceIdentityApplicationCredentialV3Create(d *schema.ResourceData, meta
 	// Secret is returned only once
 	d.Set("secret", applicationCredential.Secret)
 
-	return resourceIdentityApplicationCredentialV3Read(d, meta)
+	return resourceIdentityApplicationCredentialV3Read(ctx, d, meta)
 }
 
-func resourceIdentityApplicationCredentialV3Read(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityApplicationCredentialV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	userID, _, err := GetTokenInfo(identityClient)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	applicationCredential, err := applicationcredentials.Get(identityClient, userID, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_identity_application_credential_v3")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_identity_application_credential_v3"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_identity_application_credential_v3 %s: %#v", d.Id(), applicationCredential)
@@ -196,27 +197,27 @@ func resourceIdentityApplicationCredentialV3Read(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceIdentityApplicationCredentialV3Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityApplicationCredentialV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	userID, _, err := GetTokenInfo(identityClient)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = applicationcredentials.Delete(identityClient, userID, d.Id()).ExtractErr()
 	if err != nil {
 		err = CheckDeleted(d, err, "Error deleting openstack_identity_application_credential_v3")
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	// cleanup access rules
 	accessRules := expandIdentityApplicationCredentialAccessRulesV3(d.Get("access_rules").(*schema.Set).List())
-	return applicationCredentialCleanupAccessRulesV3(identityClient, userID, d.Id(), accessRules)
+	return diag.FromErr(applicationCredentialCleanupAccessRulesV3(identityClient, userID, d.Id(), accessRules))
 }
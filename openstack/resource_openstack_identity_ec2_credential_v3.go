@@ -1,21 +1,22 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/ec2credentials"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIdentityEc2CredentialV3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIdentityEc2CredentialV3Create,
-		Read:   resourceIdentityEc2CredentialV3Read,
-		Delete: resourceIdentityEc2CredentialV3Delete,
+		CreateContext: resourceIdentityEc2CredentialV3Create,
+		ReadContext:   resourceIdentityEc2CredentialV3Read,
+		DeleteContext: resourceIdentityEc2CredentialV3Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,17 +63,17 @@ func resourceIdentityEc2CredentialV3() *schema.Resource {
 	}
 }
 
-func resourceIdentityEc2CredentialV3Create(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityEc2CredentialV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	user, project, err := GetTokenInfo(identityClient)
 	if err != nil {
-		return fmt.Errorf("Error getting token info: %s", err)
+		return diag.Errorf("Error getting token info: %s", err)
 	}
 
 	var tenantID string
@@ -101,9 +102,9 @@ func resourceIdentityEc2CredentialV3Create(d *schema.ResourceData, meta interfac
 
 	if err != nil {
 		if v, ok := err.(gophercloud.ErrDefault404); ok {
-			return fmt.Errorf("Error creating openstack_identity_ec2_credential_v3: %s", v.ErrUnexpectedResponseCode.Body)
+			return diag.Errorf("Error creating openstack_identity_ec2_credential_v3: %s", v.ErrUnexpectedResponseCode.Body)
 		}
-		return fmt.Errorf("Error creating openstack_identity_ec2_credential_v3: %s", err)
+		return diag.Errorf("Error creating openstack_identity_ec2_credential_v3: %s", err)
 	}
 
 	d.SetId(ec2Credential.Access)
@@ -114,19 +115,19 @@ func resourceIdentityEc2CredentialV3Create(d *schema.ResourceData, meta interfac
 	d.Set("project_id", ec2Credential.TenantID)
 	d.Set("trust_id", ec2Credential.TrustID)
 
-	return resourceIdentityEc2CredentialV3Read(d, meta)
+	return resourceIdentityEc2CredentialV3Read(ctx, d, meta)
 }
 
-func resourceIdentityEc2CredentialV3Read(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityEc2CredentialV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	user, _, err := GetTokenInfo(identityClient)
 	if err != nil {
-		return fmt.Errorf("Error getting token info: %s", err)
+		return diag.Errorf("Error getting token info: %s", err)
 	}
 
 	var userID string
@@ -139,7 +140,7 @@ func resourceIdentityEc2CredentialV3Read(d *schema.ResourceData, meta interface{
 
 	ec2Credential, err := ec2credentials.Get(identityClient, userID, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_identity_ec2_credential_v3")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_identity_ec2_credential_v3"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_identity_ec2_credential_v3 %s: %#v", d.Id(), ec2Credential)
@@ -153,16 +154,16 @@ func resourceIdentityEc2CredentialV3Read(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceIdentityEc2CredentialV3Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityEc2CredentialV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	user, _, err := GetTokenInfo(identityClient)
 	if err != nil {
-		return fmt.Errorf("Error getting token info: %s", err)
+		return diag.Errorf("Error getting token info: %s", err)
 	}
 
 	var userID string
@@ -177,7 +178,7 @@ func resourceIdentityEc2CredentialV3Delete(d *schema.ResourceData, meta interfac
 	if err != nil {
 		err = CheckDeleted(d, err, "Error deleting openstack_identity_ec2_credential_v3")
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	return nil
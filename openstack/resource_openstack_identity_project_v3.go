@@ -1,23 +1,26 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIdentityProjectV3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIdentityProjectV3Create,
-		Read:   resourceIdentityProjectV3Read,
-		Update: resourceIdentityProjectV3Update,
-		Delete: resourceIdentityProjectV3Delete,
+		CreateContext: resourceIdentityProjectV3Create,
+		ReadContext:   resourceIdentityProjectV3Read,
+		UpdateContext: resourceIdentityProjectV3Update,
+		DeleteContext: resourceIdentityProjectV3Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: customizeDiffDefaultTags,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -65,15 +68,22 @@ func resourceIdentityProjectV3() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
+
+			"all_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
 		},
 	}
 }
 
-func resourceIdentityProjectV3Create(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityProjectV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	enabled := d.Get("enabled").(bool)
@@ -87,32 +97,31 @@ func resourceIdentityProjectV3Create(d *schema.ResourceData, meta interface{}) e
 		ParentID:    d.Get("parent_id").(string),
 	}
 
-	if v, ok := d.GetOk("tags"); ok {
-		tags := v.(*schema.Set).List()
-		createOpts.Tags = expandToStringSlice(tags)
+	if tags := expandObjectCreateTags(d, config.DefaultTags); len(tags) > 0 {
+		createOpts.Tags = tags
 	}
 
 	log.Printf("[DEBUG] openstack_identity_project_v3 create options: %#v", createOpts)
 	project, err := projects.Create(identityClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_identity_project_v3: %s", err)
+		return diag.Errorf("Error creating openstack_identity_project_v3: %s", err)
 	}
 
 	d.SetId(project.ID)
 
-	return resourceIdentityProjectV3Read(d, meta)
+	return resourceIdentityProjectV3Read(ctx, d, meta)
 }
 
-func resourceIdentityProjectV3Read(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityProjectV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	project, err := projects.Get(identityClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_identity_project_v3")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_identity_project_v3"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_identity_project_v3 %s: %#v", d.Id(), project)
@@ -124,16 +133,16 @@ func resourceIdentityProjectV3Read(d *schema.ResourceData, meta interface{}) err
 	d.Set("name", project.Name)
 	d.Set("parent_id", project.ParentID)
 	d.Set("region", GetRegion(d, config))
-	d.Set("tags", project.Tags)
+	expandObjectReadAllTags(d, project.Tags, config.DefaultTags)
 
 	return nil
 }
 
-func resourceIdentityProjectV3Update(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityProjectV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	var hasChange bool
@@ -172,37 +181,35 @@ func resourceIdentityProjectV3Update(d *schema.ResourceData, meta interface{}) e
 		updateOpts.Description = &description
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "all_tags") {
 		hasChange = true
-		if v, ok := d.GetOk("tags"); ok {
-			tags := v.(*schema.Set).List()
-			tagsToUpdate := expandToStringSlice(tags)
-			updateOpts.Tags = &tagsToUpdate
-		} else {
-			updateOpts.Tags = &[]string{}
+		tags := expandObjectUpdateTags(d, config.DefaultTags)
+		if tags == nil {
+			tags = []string{}
 		}
+		updateOpts.Tags = &tags
 	}
 
 	if hasChange {
 		_, err := projects.Update(identityClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_identity_project_v3 %s: %s", d.Id(), err)
+			return diag.Errorf("Error updating openstack_identity_project_v3 %s: %s", d.Id(), err)
 		}
 	}
 
-	return resourceIdentityProjectV3Read(d, meta)
+	return resourceIdentityProjectV3Read(ctx, d, meta)
 }
 
-func resourceIdentityProjectV3Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceIdentityProjectV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	identityClient, err := config.IdentityV3Client(GetRegion(d, config))
+	identityClient, err := config.IdentityV3Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack identity client: %s", err)
+		return diag.Errorf("Error creating OpenStack identity client: %s", err)
 	}
 
 	err = projects.Delete(identityClient, d.Id()).ExtractErr()
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_identity_project_v3")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_identity_project_v3"))
 	}
 
 	return nil
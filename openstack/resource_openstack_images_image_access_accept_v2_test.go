@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -44,7 +45,7 @@ func TestAccImagesImageAccessAcceptV2_basic(t *testing.T) {
 
 func testAccCheckImagesImageAccessAcceptV2Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
-	imageClient, err := config.ImageV2Client(OS_REGION_NAME)
+	imageClient, err := config.ImageV2Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return fmt.Errorf("Error creating OpenStack Image: %s", err)
 	}
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -11,6 +12,8 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/imageimport"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,15 +21,18 @@ import (
 
 func resourceImagesImageV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceImagesImageV2Create,
-		Read:   resourceImagesImageV2Read,
-		Update: resourceImagesImageV2Update,
-		Delete: resourceImagesImageV2Delete,
+		CreateContext: resourceImagesImageV2Create,
+		ReadContext:   resourceImagesImageV2Read,
+		UpdateContext: resourceImagesImageV2Update,
+		DeleteContext: resourceImagesImageV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		CustomizeDiff: resourceImagesImageV2UpdateComputedAttributes,
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffDefaultTags,
+			resourceImagesImageV2UpdateComputedAttributes,
+		),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -119,6 +125,13 @@ func resourceImagesImageV2() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"all_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"verify_checksum": {
 				Type:          schema.TypeBool,
 				Optional:      true,
@@ -199,11 +212,11 @@ func resourceImagesImageV2() *schema.Resource {
 	}
 }
 
-func resourceImagesImageV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceImagesImageV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	imageClient, err := config.ImageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack image client: %s", err)
+		return diag.Errorf("Error creating OpenStack image client: %s", err)
 	}
 
 	protected := d.Get("protected").(bool)
@@ -223,9 +236,8 @@ func resourceImagesImageV2Create(d *schema.ResourceData, meta interface{}) error
 		Properties:      imageProperties,
 	}
 
-	if v, ok := d.GetOk("tags"); ok {
-		tags := v.(*schema.Set).List()
-		createOpts.Tags = resourceImagesImageV2BuildTags(tags)
+	if tags := expandObjectCreateTags(d, config.DefaultTags); len(tags) > 0 {
+		createOpts.Tags = tags
 	}
 
 	d.Partial(true)
@@ -233,9 +245,10 @@ func resourceImagesImageV2Create(d *schema.ResourceData, meta interface{}) error
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	newImg, err := images.Create(imageClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating Image: %s", err)
+		return diag.Errorf("Error creating Image: %s", err)
 	}
 
+	config.lookups.invalidate(lookupImage)
 	d.SetId(newImg.ID)
 
 	var fileChecksum string
@@ -248,27 +261,27 @@ func resourceImagesImageV2Create(d *schema.ResourceData, meta interface{}) error
 		var imgFile *os.File
 
 		// downloading/getting image file props
-		imgFilePath, err = resourceImagesImageV2File(d)
+		imgFilePath, err = resourceImagesImageV2File(config.httpClient(), d)
 		if err != nil {
-			return fmt.Errorf("Error opening file for Image: %s", err)
+			return diag.Errorf("Error opening file for Image: %s", err)
 
 		}
 		fileSize, fileChecksum, err = resourceImagesImageV2FileProps(imgFilePath)
 		if err != nil {
-			return fmt.Errorf("Error getting file props: %s", err)
+			return diag.Errorf("Error getting file props: %s", err)
 		}
 
 		// upload
 		imgFile, err = os.Open(imgFilePath)
 		if err != nil {
-			return fmt.Errorf("Error opening file %q: %s", imgFilePath, err)
+			return diag.Errorf("Error opening file %q: %s", imgFilePath, err)
 		}
 		defer imgFile.Close()
 		log.Printf("[WARN] Uploading image %s (%d bytes). This can be pretty long.", d.Id(), fileSize)
 
 		res := imagedata.Upload(imageClient, d.Id(), imgFile)
 		if res.Err != nil {
-			return fmt.Errorf("Error while uploading file %q: %s", imgFilePath, res.Err)
+			return diag.Errorf("Error while uploading file %q: %s", imgFilePath, res.Err)
 		}
 	} else {
 		// import
@@ -282,7 +295,7 @@ func resourceImagesImageV2Create(d *schema.ResourceData, meta interface{}) error
 		log.Printf("[DEBUG] Import Options: %#v", importOpts)
 		res := imageimport.Create(imageClient, d.Id(), importOpts)
 		if res.Err != nil {
-			return fmt.Errorf("Error while importing url %q: %s", imgUrl, res.Err)
+			return diag.Errorf("Error while importing url %q: %s", imgUrl, res.Err)
 		}
 	}
 
@@ -296,36 +309,36 @@ func resourceImagesImageV2Create(d *schema.ResourceData, meta interface{}) error
 		MinTimeout: 3 * time.Second,
 	}
 
-	if _, err = stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for Image: %s", err)
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("Error waiting for Image: %s", err)
 	}
 
 	img, err := images.Get(imageClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "image")
+		return diag.FromErr(CheckDeleted(d, err, "image"))
 	}
 
 	if v, ok := d.GetOkExists("verify_checksum"); !useWebDownload && (!ok || (ok && v.(bool))) {
 		if img.Checksum != fileChecksum {
-			return fmt.Errorf("Error wrong checksum: got %q, expected %q", img.Checksum, fileChecksum)
+			return diag.Errorf("Error wrong checksum: got %q, expected %q", img.Checksum, fileChecksum)
 		}
 	}
 
 	d.Partial(false)
 
-	return resourceImagesImageV2Read(d, meta)
+	return resourceImagesImageV2Read(ctx, d, meta)
 }
 
-func resourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceImagesImageV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	imageClient, err := config.ImageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack image client: %s", err)
+		return diag.Errorf("Error creating OpenStack image client: %s", err)
 	}
 
 	img, err := images.Get(imageClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "image")
+		return diag.FromErr(CheckDeleted(d, err, "image"))
 	}
 
 	log.Printf("[DEBUG] Retrieved Image %s: %#v", d.Id(), img)
@@ -347,7 +360,7 @@ func resourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", img.Name)
 	d.Set("protected", img.Protected)
 	d.Set("size_bytes", img.SizeBytes)
-	d.Set("tags", img.Tags)
+	expandObjectReadAllTags(d, img.Tags, config.DefaultTags)
 	d.Set("visibility", img.Visibility)
 	d.Set("region", GetRegion(d, config))
 
@@ -362,11 +375,11 @@ func resourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceImagesImageV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceImagesImageV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	imageClient, err := config.ImageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack image client: %s", err)
+		return diag.Errorf("Error creating OpenStack image client: %s", err)
 	}
 
 	updateOpts := make(images.UpdateOpts, 0)
@@ -382,10 +395,9 @@ func resourceImagesImageV2Update(d *schema.ResourceData, meta interface{}) error
 		updateOpts = append(updateOpts, v)
 	}
 
-	if d.HasChange("tags") {
-		tags := d.Get("tags").(*schema.Set).List()
+	if d.HasChanges("tags", "all_tags") {
 		v := images.ReplaceImageTags{
-			NewTags: resourceImagesImageV2BuildTags(tags),
+			NewTags: expandObjectUpdateTags(d, config.DefaultTags),
 		}
 		updateOpts = append(updateOpts, v)
 	}
@@ -460,23 +472,25 @@ func resourceImagesImageV2Update(d *schema.ResourceData, meta interface{}) error
 
 	_, err = images.Update(imageClient, d.Id(), updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error updating image: %s", err)
+		return diag.Errorf("Error updating image: %s", err)
 	}
+	config.lookups.invalidate(lookupImage)
 
-	return resourceImagesImageV2Read(d, meta)
+	return resourceImagesImageV2Read(ctx, d, meta)
 }
 
-func resourceImagesImageV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceImagesImageV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	imageClient, err := config.ImageV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack image client: %s", err)
+		return diag.Errorf("Error creating OpenStack image client: %s", err)
 	}
 
 	log.Printf("[DEBUG] Deleting Image %s", d.Id())
 	if err := images.Delete(imageClient, d.Id()).Err; err != nil {
-		return fmt.Errorf("Error deleting Image: %s", err)
+		return diag.Errorf("Error deleting Image: %s", err)
 	}
+	config.lookups.invalidate(lookupImage)
 
 	d.SetId("")
 	return nil
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -37,7 +38,7 @@ func TestAccKeyManagerOrderV1_basic(t *testing.T) {
 
 func testAccCheckOrderV1Destroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
-	kmClient, err := config.KeyManagerV1Client(OS_REGION_NAME)
+	kmClient, err := config.KeyManagerV1Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return fmt.Errorf("Error creating OpenStack KeyManager client: %s", err)
 	}
@@ -74,7 +75,7 @@ func testAccCheckOrderV1Exists(n string, order *orders.Order) resource.TestCheck
 		}
 
 		config := testAccProvider.Meta().(*Config)
-		kmClient, err := config.KeyManagerV1Client(OS_REGION_NAME)
+		kmClient, err := config.KeyManagerV1Client(context.TODO(), OS_REGION_NAME)
 		if err != nil {
 			return fmt.Errorf("Error creating OpenStack KeyManager client: %s", err)
 		}
@@ -2,13 +2,13 @@ package openstack
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/acls"
 	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/secrets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,13 +17,13 @@ import (
 
 func resourceKeyManagerSecretV1() *schema.Resource {
 	ret := &schema.Resource{
-		Create: resourceKeyManagerSecretV1Create,
-		Read:   resourceKeyManagerSecretV1Read,
-		Update: resourceKeyManagerSecretV1Update,
-		Delete: resourceKeyManagerSecretV1Delete,
+		CreateContext: resourceKeyManagerSecretV1Create,
+		ReadContext:   resourceKeyManagerSecretV1Read,
+		UpdateContext: resourceKeyManagerSecretV1Update,
+		DeleteContext: resourceKeyManagerSecretV1Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -184,11 +184,11 @@ func resourceKeyManagerSecretV1() *schema.Resource {
 	return ret
 }
 
-func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{}) error {
+func resourceKeyManagerSecretV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	kmClient, err := config.KeyManagerV1Client(GetRegion(d, config))
+	kmClient, err := config.KeyManagerV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack KeyManager client: %s", err)
+		return diag.Errorf("Error creating OpenStack KeyManager client: %s", err)
 	}
 
 	var expiration *time.Time
@@ -212,7 +212,7 @@ func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{})
 	var secret *secrets.Secret
 	secret, err = secrets.Create(kmClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_keymanager_secret_v1: %s", err)
+		return diag.Errorf("Error creating openstack_keymanager_secret_v1: %s", err)
 	}
 
 	uuid := keyManagerSecretV1GetUUIDfromSecretRef(secret.SecretRef)
@@ -226,9 +226,9 @@ func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{})
 		MinTimeout: 2 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_keymanager_secret_v1: %s", err)
+		return diag.Errorf("Error waiting for openstack_keymanager_secret_v1: %s", err)
 	}
 
 	d.SetId(uuid)
@@ -240,7 +240,7 @@ func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{})
 		setOpts := expandKeyManagerV1ACLs(acl)
 		_, err = acls.SetSecretACL(kmClient, uuid, setOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error settings ACLs for the openstack_keymanager_secret_v1: %s", err)
+			return diag.Errorf("Error settings ACLs for the openstack_keymanager_secret_v1: %s", err)
 		}
 	}
 
@@ -252,12 +252,12 @@ func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{})
 	}
 	err = secrets.Update(kmClient, uuid, updateOpts).Err
 	if err != nil {
-		return fmt.Errorf("Error setting openstack_keymanager_secret_v1 payload: %s", err)
+		return diag.Errorf("Error setting openstack_keymanager_secret_v1 payload: %s", err)
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_keymanager_secret_v1: %s", err)
+		return diag.Errorf("Error waiting for openstack_keymanager_secret_v1: %s", err)
 	}
 
 	// set the metadata
@@ -269,7 +269,7 @@ func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{})
 	if len(metadataCreateOpts) > 0 {
 		_, err = secrets.CreateMetadata(kmClient, uuid, metadataCreateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error creating metadata for openstack_keymanager_secret_v1 with ID %s: %s", uuid, err)
+			return diag.Errorf("Error creating metadata for openstack_keymanager_secret_v1 with ID %s: %s", uuid, err)
 		}
 
 		stateConf = &resource.StateChangeConf{
@@ -281,27 +281,27 @@ func resourceKeyManagerSecretV1Create(d *schema.ResourceData, meta interface{})
 			MinTimeout: 2 * time.Second,
 		}
 
-		_, err = stateConf.WaitForState()
+		_, err = stateConf.WaitForStateContext(ctx)
 		if err != nil {
-			return fmt.Errorf("Error creating metadata for openstack_keymanager_secret_v1 %s: %s", uuid, err)
+			return diag.Errorf("Error creating metadata for openstack_keymanager_secret_v1 %s: %s", uuid, err)
 		}
 	}
 
 	d.Partial(false)
 
-	return resourceKeyManagerSecretV1Read(d, meta)
+	return resourceKeyManagerSecretV1Read(ctx, d, meta)
 }
 
-func resourceKeyManagerSecretV1Read(d *schema.ResourceData, meta interface{}) error {
+func resourceKeyManagerSecretV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	kmClient, err := config.KeyManagerV1Client(GetRegion(d, config))
+	kmClient, err := config.KeyManagerV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack barbican client: %s", err)
+		return diag.Errorf("Error creating OpenStack barbican client: %s", err)
 	}
 
 	secret, err := secrets.Get(kmClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_keymanager_secret_v1")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_keymanager_secret_v1"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_keymanager_secret_v1 %s: %#v", d.Id(), secret)
@@ -348,18 +348,18 @@ func resourceKeyManagerSecretV1Read(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceKeyManagerSecretV1Update(d *schema.ResourceData, meta interface{}) error {
+func resourceKeyManagerSecretV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	kmClient, err := config.KeyManagerV1Client(GetRegion(d, config))
+	kmClient, err := config.KeyManagerV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack barbican client: %s", err)
+		return diag.Errorf("Error creating OpenStack barbican client: %s", err)
 	}
 
 	if d.HasChange("acl") {
 		updateOpts := expandKeyManagerV1ACLs(d.Get("acl"))
 		_, err := acls.UpdateSecretACL(kmClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_keymanager_secret_v1 %s acl: %s", d.Id(), err)
+			return diag.Errorf("Error updating openstack_keymanager_secret_v1 %s acl: %s", d.Id(), err)
 		}
 	}
 
@@ -386,7 +386,7 @@ func resourceKeyManagerSecretV1Update(d *schema.ResourceData, meta interface{})
 		for _, key := range metadataToDelete {
 			err := secrets.DeleteMetadatum(kmClient, d.Id(), key).ExtractErr()
 			if err != nil {
-				return fmt.Errorf("Error deleting openstack_keymanager_secret_v1 %s metadata %s: %s", d.Id(), key, err)
+				return diag.Errorf("Error deleting openstack_keymanager_secret_v1 %s metadata %s: %s", d.Id(), key, err)
 			}
 		}
 
@@ -414,7 +414,7 @@ func resourceKeyManagerSecretV1Update(d *schema.ResourceData, meta interface{})
 			metadatumOpts.Value = newMetadata[key].(string)
 			_, err := secrets.UpdateMetadatum(kmClient, d.Id(), metadatumOpts).Extract()
 			if err != nil {
-				return fmt.Errorf("Error updating openstack_keymanager_secret_v1 %s metadata %s: %s", d.Id(), key, err)
+				return diag.Errorf("Error updating openstack_keymanager_secret_v1 %s metadata %s: %s", d.Id(), key, err)
 			}
 		}
 
@@ -426,19 +426,19 @@ func resourceKeyManagerSecretV1Update(d *schema.ResourceData, meta interface{})
 			metadatumOpts.Value = newMetadata[key].(string)
 			err := secrets.CreateMetadatum(kmClient, d.Id(), metadatumOpts).Err
 			if err != nil {
-				return fmt.Errorf("Error adding openstack_keymanager_secret_v1 %s metadata %s: %s", d.Id(), key, err)
+				return diag.Errorf("Error adding openstack_keymanager_secret_v1 %s metadata %s: %s", d.Id(), key, err)
 			}
 		}
 	}
 
-	return resourceKeyManagerSecretV1Read(d, meta)
+	return resourceKeyManagerSecretV1Read(ctx, d, meta)
 }
 
-func resourceKeyManagerSecretV1Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceKeyManagerSecretV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	kmClient, err := config.KeyManagerV1Client(GetRegion(d, config))
+	kmClient, err := config.KeyManagerV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack barbican client: %s", err)
+		return diag.Errorf("Error creating OpenStack barbican client: %s", err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -450,8 +450,8 @@ func resourceKeyManagerSecretV1Delete(d *schema.ResourceData, meta interface{})
 		MinTimeout: 2 * time.Second,
 	}
 
-	if _, err = stateConf.WaitForState(); err != nil {
-		return err
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
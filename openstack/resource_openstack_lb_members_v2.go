@@ -1,10 +1,12 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,12 +17,12 @@ import (
 
 func resourceMembersV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMembersV2Create,
-		Read:   resourceMembersV2Read,
-		Update: resourceMembersV2Update,
-		Delete: resourceMembersV2Delete,
+		CreateContext: resourceMembersV2Create,
+		ReadContext:   resourceMembersV2Read,
+		UpdateContext: resourceMembersV2Update,
+		DeleteContext: resourceMembersV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -93,11 +95,11 @@ func resourceMembersV2() *schema.Resource {
 	}
 }
 
-func resourceMembersV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceMembersV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	createOpts := expandLBMembersV2(d.Get("member").(*schema.Set))
@@ -107,18 +109,18 @@ func resourceMembersV2Create(d *schema.ResourceData, meta interface{}) error {
 	poolID := d.Get("pool_id").(string)
 	parentPool, err := neutronpools.Get(lbClient, poolID).Extract()
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve parent pool %s: %s", poolID, err)
+		return diag.Errorf("Unable to retrieve parent pool %s: %s", poolID, err)
 	}
 
 	// Wait for parent pool to become active before continuing
 	timeout := d.Timeout(schema.TimeoutCreate)
-	err = waitForLBV2Pool(lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] Attempting to create members")
-	err = resource.Retry(timeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = octaviapools.BatchUpdateMembers(lbClient, poolID, createOpts).ExtractErr()
 		if err != nil {
 			return checkForRetryableError(err)
@@ -127,35 +129,35 @@ func resourceMembersV2Create(d *schema.ResourceData, meta interface{}) error {
 	})
 
 	if err != nil {
-		return fmt.Errorf("Error creating members: %s", err)
+		return diag.Errorf("Error creating members: %s", err)
 	}
 
 	// Wait for parent pool to become active before continuing
-	err = waitForLBV2Pool(lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(poolID)
 
-	return resourceMembersV2Read(d, meta)
+	return resourceMembersV2Read(ctx, d, meta)
 }
 
-func resourceMembersV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceMembersV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	allPages, err := octaviapools.ListMembers(lbClient, d.Id(), octaviapools.ListMembersOpts{}).AllPages()
 	if err != nil {
-		return CheckDeleted(d, err, "Error getting openstack_lb_members_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error getting openstack_lb_members_v2"))
 	}
 
 	members, err := octaviapools.ExtractMembers(allPages)
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve openstack_lb_members_v2: %s", err)
+		return diag.Errorf("Unable to retrieve openstack_lb_members_v2: %s", err)
 	}
 
 	log.Printf("[DEBUG] Retrieved members for the %s pool: %#v", d.Id(), members)
@@ -167,11 +169,11 @@ func resourceMembersV2Read(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceMembersV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceMembersV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	if d.HasChange("member") {
@@ -180,18 +182,18 @@ func resourceMembersV2Update(d *schema.ResourceData, meta interface{}) error {
 		// Get a clean copy of the parent pool.
 		parentPool, err := neutronpools.Get(lbClient, d.Id()).Extract()
 		if err != nil {
-			return fmt.Errorf("Unable to retrieve parent pool %s: %s", d.Id(), err)
+			return diag.Errorf("Unable to retrieve parent pool %s: %s", d.Id(), err)
 		}
 
 		// Wait for parent pool to become active before continuing.
 		timeout := d.Timeout(schema.TimeoutUpdate)
-		err = waitForLBV2Pool(lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
+		err = waitForLBV2Pool(ctx, lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		log.Printf("[DEBUG] Updating %s pool members with options: %#v", d.Id(), updateOpts)
-		err = resource.Retry(timeout, func() *resource.RetryError {
+		err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 			err = octaviapools.BatchUpdateMembers(lbClient, d.Id(), updateOpts).ExtractErr()
 			if err != nil {
 				return checkForRetryableError(err)
@@ -200,41 +202,41 @@ func resourceMembersV2Update(d *schema.ResourceData, meta interface{}) error {
 		})
 
 		if err != nil {
-			return fmt.Errorf("Unable to update member %s: %s", d.Id(), err)
+			return diag.Errorf("Unable to update member %s: %s", d.Id(), err)
 		}
 
 		// Wait for parent pool to become active before continuing
-		err = waitForLBV2Pool(lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
+		err = waitForLBV2Pool(ctx, lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return resourceMembersV2Read(d, meta)
+	return resourceMembersV2Read(ctx, d, meta)
 }
 
-func resourceMembersV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceMembersV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	// Get a clean copy of the parent pool.
 	parentPool, err := neutronpools.Get(lbClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, fmt.Sprintf("Unable to retrieve parent pool (%s) for the member", d.Id()))
+		return diag.FromErr(CheckDeleted(d, err, fmt.Sprintf("Unable to retrieve parent pool (%s) for the member", d.Id())))
 	}
 
 	// Wait for parent pool to become active before continuing.
 	timeout := d.Timeout(schema.TimeoutDelete)
-	err = waitForLBV2Pool(lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return CheckDeleted(d, err, "Error waiting for the members' pool status")
+		return diag.FromErr(CheckDeleted(d, err, "Error waiting for the members' pool status"))
 	}
 
 	log.Printf("[DEBUG] Attempting to delete %s pool members", d.Id())
-	err = resource.Retry(timeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = octaviapools.BatchUpdateMembers(lbClient, d.Id(), []octaviapools.BatchUpdateMemberOpts{}).ExtractErr()
 		if err != nil {
 			return checkForRetryableError(err)
@@ -243,13 +245,13 @@ func resourceMembersV2Delete(d *schema.ResourceData, meta interface{}) error {
 	})
 
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting members")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting members"))
 	}
 
 	// Wait for parent pool to become active before continuing.
-	err = waitForLBV2Pool(lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, parentPool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return CheckDeleted(d, err, "Error waiting for the members' pool status")
+		return diag.FromErr(CheckDeleted(d, err, "Error waiting for the members' pool status"))
 	}
 
 	return nil
@@ -1,10 +1,12 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,12 +17,12 @@ import (
 
 func resourcePoolV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePoolV2Create,
-		Read:   resourcePoolV2Read,
-		Update: resourcePoolV2Update,
-		Delete: resourcePoolV2Delete,
+		CreateContext: resourcePoolV2Create,
+		ReadContext:   resourcePoolV2Read,
+		UpdateContext: resourcePoolV2Update,
+		DeleteContext: resourcePoolV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: resourcePoolV2Import,
+			StateContext: resourcePoolV2Import,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -120,11 +122,11 @@ func resourcePoolV2() *schema.Resource {
 	}
 }
 
-func resourcePoolV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourcePoolV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	adminStateUp := d.Get("admin_state_up").(bool)
@@ -140,14 +142,14 @@ func resourcePoolV2Create(d *schema.ResourceData, meta interface{}) error {
 
 		if persistence.Type == "APP_COOKIE" {
 			if pV["cookie_name"].(string) == "" {
-				return fmt.Errorf(
+				return diag.Errorf(
 					"Persistence cookie_name needs to be set if using 'APP_COOKIE' persistence type.")
 			} else {
 				persistence.CookieName = pV["cookie_name"].(string)
 			}
 		} else {
 			if pV["cookie_name"].(string) != "" {
-				return fmt.Errorf(
+				return diag.Errorf(
 					"Persistence cookie_name can only be set if using 'APP_COOKIE' persistence type.")
 			}
 		}
@@ -177,21 +179,21 @@ func resourcePoolV2Create(d *schema.ResourceData, meta interface{}) error {
 	if listenerID != "" {
 		listener, err := listeners.Get(lbClient, listenerID).Extract()
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
-		err = waitForLBV2Listener(lbClient, listener, "ACTIVE", lbPendingStatuses, timeout)
+		err = waitForLBV2Listener(ctx, lbClient, listener, "ACTIVE", lbPendingStatuses, timeout)
 	} else {
-		err = waitForLBV2LoadBalancer(lbClient, lbID, "ACTIVE", lbPendingStatuses, timeout)
+		err = waitForLBV2LoadBalancer(ctx, lbClient, lbID, "ACTIVE", lbPendingStatuses, timeout)
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] Attempting to create pool")
 	var pool *pools.Pool
-	err = resource.Retry(timeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		pool, err = pools.Create(lbClient, createOpts).Extract()
 		if err != nil {
 			return checkForRetryableError(err)
@@ -200,31 +202,31 @@ func resourcePoolV2Create(d *schema.ResourceData, meta interface{}) error {
 	})
 
 	if err != nil {
-		return fmt.Errorf("Error creating pool: %s", err)
+		return diag.Errorf("Error creating pool: %s", err)
 	}
 
 	// Pool was successfully created
 	// Wait for pool to become active before continuing
-	err = waitForLBV2Pool(lbClient, pool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, pool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(pool.ID)
 
-	return resourcePoolV2Read(d, meta)
+	return resourcePoolV2Read(ctx, d, meta)
 }
 
-func resourcePoolV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourcePoolV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	pool, err := pools.Get(lbClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "pool")
+		return diag.FromErr(CheckDeleted(d, err, "pool"))
 	}
 
 	log.Printf("[DEBUG] Retrieved pool %s: %#v", d.Id(), pool)
@@ -241,11 +243,11 @@ func resourcePoolV2Read(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourcePoolV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourcePoolV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	var updateOpts pools.UpdateOpts
@@ -270,17 +272,17 @@ func resourcePoolV2Update(d *schema.ResourceData, meta interface{}) error {
 	// Get a clean copy of the pool.
 	pool, err := pools.Get(lbClient, d.Id()).Extract()
 	if err != nil {
-		return fmt.Errorf("Unable to retrieve pool %s: %s", d.Id(), err)
+		return diag.Errorf("Unable to retrieve pool %s: %s", d.Id(), err)
 	}
 
 	// Wait for pool to become active before continuing
-	err = waitForLBV2Pool(lbClient, pool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, pool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] Updating pool %s with options: %#v", d.Id(), updateOpts)
-	err = resource.Retry(timeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		_, err = pools.Update(lbClient, d.Id(), updateOpts).Extract()
 		if err != nil {
 			return checkForRetryableError(err)
@@ -289,23 +291,23 @@ func resourcePoolV2Update(d *schema.ResourceData, meta interface{}) error {
 	})
 
 	if err != nil {
-		return fmt.Errorf("Unable to update pool %s: %s", d.Id(), err)
+		return diag.Errorf("Unable to update pool %s: %s", d.Id(), err)
 	}
 
 	// Wait for pool to become active before continuing
-	err = waitForLBV2Pool(lbClient, pool, "ACTIVE", lbPendingStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, pool, "ACTIVE", lbPendingStatuses, timeout)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePoolV2Read(d, meta)
+	return resourcePoolV2Read(ctx, d, meta)
 }
 
-func resourcePoolV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourcePoolV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	timeout := d.Timeout(schema.TimeoutDelete)
@@ -313,11 +315,11 @@ func resourcePoolV2Delete(d *schema.ResourceData, meta interface{}) error {
 	// Get a clean copy of the pool.
 	pool, err := pools.Get(lbClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Unable to retrieve pool")
+		return diag.FromErr(CheckDeleted(d, err, "Unable to retrieve pool"))
 	}
 
 	log.Printf("[DEBUG] Attempting to delete pool %s", d.Id())
-	err = resource.Retry(timeout, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		err = pools.Delete(lbClient, d.Id()).ExtractErr()
 		if err != nil {
 			return checkForRetryableError(err)
@@ -326,21 +328,21 @@ func resourcePoolV2Delete(d *schema.ResourceData, meta interface{}) error {
 	})
 
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting pool")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting pool"))
 	}
 
 	// Wait for Pool to delete
-	err = waitForLBV2Pool(lbClient, pool, "DELETED", lbPendingDeleteStatuses, timeout)
+	err = waitForLBV2Pool(ctx, lbClient, pool, "DELETED", lbPendingDeleteStatuses, timeout)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePoolV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourcePoolV2Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	config := meta.(*Config)
-	lbClient, err := chooseLBV2Client(d, config)
+	lbClient, err := chooseLBV2Client(ctx, d, config)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating OpenStack networking client: %s", err)
 	}
@@ -1,10 +1,11 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -13,12 +14,12 @@ import (
 
 func resourceNetworkingAddressScopeV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkingAddressScopeV2Create,
-		Read:   resourceNetworkingAddressScopeV2Read,
-		Update: resourceNetworkingAddressScopeV2Update,
-		Delete: resourceNetworkingAddressScopeV2Delete,
+		CreateContext: resourceNetworkingAddressScopeV2Create,
+		ReadContext:   resourceNetworkingAddressScopeV2Read,
+		UpdateContext: resourceNetworkingAddressScopeV2Update,
+		DeleteContext: resourceNetworkingAddressScopeV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -64,11 +65,11 @@ func resourceNetworkingAddressScopeV2() *schema.Resource {
 	}
 }
 
-func resourceNetworkingAddressScopeV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingAddressScopeV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	createOpts := addressscopes.CreateOpts{
@@ -81,7 +82,7 @@ func resourceNetworkingAddressScopeV2Create(d *schema.ResourceData, meta interfa
 	log.Printf("[DEBUG] openstack_networking_addressscope_v2 create options: %#v", createOpts)
 	a, err := addressscopes.Create(networkingClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_networking_addressscope_v2: %s", err)
+		return diag.Errorf("Error creating openstack_networking_addressscope_v2: %s", err)
 	}
 
 	log.Printf("[DEBUG] Waiting for openstack_networking_addressscope_v2 %s to become available", a.ID)
@@ -94,27 +95,27 @@ func resourceNetworkingAddressScopeV2Create(d *schema.ResourceData, meta interfa
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_networking_addressscope_v2 %s to become available: %s", a.ID, err)
+		return diag.Errorf("Error waiting for openstack_networking_addressscope_v2 %s to become available: %s", a.ID, err)
 	}
 
 	d.SetId(a.ID)
 
 	log.Printf("[DEBUG] Created openstack_networking_addressscope_v2 %s: %#v", a.ID, a)
-	return resourceNetworkingAddressScopeV2Read(d, meta)
+	return resourceNetworkingAddressScopeV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingAddressScopeV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingAddressScopeV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	a, err := addressscopes.Get(networkingClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error getting openstack_networking_addressscope_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error getting openstack_networking_addressscope_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_networking_addressscope_v2 %s: %#v", d.Id(), a)
@@ -128,11 +129,11 @@ func resourceNetworkingAddressScopeV2Read(d *schema.ResourceData, meta interface
 	return nil
 }
 
-func resourceNetworkingAddressScopeV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingAddressScopeV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	var (
@@ -156,22 +157,22 @@ func resourceNetworkingAddressScopeV2Update(d *schema.ResourceData, meta interfa
 		log.Printf("[DEBUG] openstack_networking_addressscope_v2 %s update options: %#v", d.Id(), updateOpts)
 		_, err = addressscopes.Update(networkingClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_networking_addressscope_v2 %s: %s", d.Id(), err)
+			return diag.Errorf("Error updating openstack_networking_addressscope_v2 %s: %s", d.Id(), err)
 		}
 	}
 
-	return resourceNetworkingAddressScopeV2Read(d, meta)
+	return resourceNetworkingAddressScopeV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingAddressScopeV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingAddressScopeV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	if err := addressscopes.Delete(networkingClient, d.Id()).ExtractErr(); err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_networking_addressscope_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_networking_addressscope_v2"))
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -183,9 +184,9 @@ func resourceNetworkingAddressScopeV2Delete(d *schema.ResourceData, meta interfa
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_networking_addressscope_v2 %s to delete: %s", d.Id(), err)
+		return diag.Errorf("Error waiting for openstack_networking_addressscope_v2 %s to delete: %s", d.Id(), err)
 	}
 
 	return nil
@@ -1,9 +1,10 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
@@ -11,12 +12,12 @@ import (
 
 func resourceNetworkingFloatingIPAssociateV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkingFloatingIPAssociateV2Create,
-		Read:   resourceNetworkingFloatingIPAssociateV2Read,
-		Update: resourceNetworkingFloatingIPAssociateV2Update,
-		Delete: resourceNetworkingFloatingIPAssociateV2Delete,
+		CreateContext: resourceNetworkingFloatingIPAssociateV2Create,
+		ReadContext:   resourceNetworkingFloatingIPAssociateV2Read,
+		UpdateContext: resourceNetworkingFloatingIPAssociateV2Update,
+		DeleteContext: resourceNetworkingFloatingIPAssociateV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -47,11 +48,11 @@ func resourceNetworkingFloatingIPAssociateV2() *schema.Resource {
 	}
 }
 
-func resourceNetworkingFloatingIPAssociateV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingFloatingIPAssociateV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack network client: %s", err)
+		return diag.Errorf("Error creating OpenStack network client: %s", err)
 	}
 
 	floatingIP := d.Get("floating_ip").(string)
@@ -60,7 +61,7 @@ func resourceNetworkingFloatingIPAssociateV2Create(d *schema.ResourceData, meta
 
 	fipID, err := networkingFloatingIPV2ID(networkingClient, floatingIP)
 	if err != nil {
-		return fmt.Errorf("Unable to get ID of openstack_networking_floatingip_associate_v2 floating_ip %s: %s", floatingIP, err)
+		return diag.Errorf("Unable to get ID of openstack_networking_floatingip_associate_v2 floating_ip %s: %s", floatingIP, err)
 	}
 
 	updateOpts := floatingips.UpdateOpts{
@@ -71,24 +72,24 @@ func resourceNetworkingFloatingIPAssociateV2Create(d *schema.ResourceData, meta
 	log.Printf("[DEBUG] openstack_networking_floatingip_associate_v2 create options: %#v", updateOpts)
 	_, err = floatingips.Update(networkingClient, fipID, updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error associating openstack_networking_floatingip_associate_v2 floating_ip %s with port %s: %s", fipID, portID, err)
+		return diag.Errorf("Error associating openstack_networking_floatingip_associate_v2 floating_ip %s with port %s: %s", fipID, portID, err)
 	}
 
 	d.SetId(fipID)
 
-	return resourceNetworkingFloatingIPAssociateV2Read(d, meta)
+	return resourceNetworkingFloatingIPAssociateV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingFloatingIPAssociateV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingFloatingIPAssociateV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack network client: %s", err)
+		return diag.Errorf("Error creating OpenStack network client: %s", err)
 	}
 
 	fip, err := floatingips.Get(networkingClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error getting openstack_networking_floatingip_associate_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error getting openstack_networking_floatingip_associate_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_networking_floatingip_associate_v2 %s: %#v", d.Id(), fip)
@@ -101,11 +102,11 @@ func resourceNetworkingFloatingIPAssociateV2Read(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceNetworkingFloatingIPAssociateV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingFloatingIPAssociateV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack network client: %s", err)
+		return diag.Errorf("Error creating OpenStack network client: %s", err)
 	}
 
 	var updateOpts floatingips.UpdateOpts
@@ -121,17 +122,17 @@ func resourceNetworkingFloatingIPAssociateV2Update(d *schema.ResourceData, meta
 	log.Printf("[DEBUG] openstack_networking_floatingip_associate_v2 %s update options: %#v", d.Id(), updateOpts)
 	_, err = floatingips.Update(networkingClient, d.Id(), updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error updating openstack_networking_floatingip_associate_v2 %s: %s", d.Id(), err)
+		return diag.Errorf("Error updating openstack_networking_floatingip_associate_v2 %s: %s", d.Id(), err)
 	}
 
-	return resourceNetworkingFloatingIPAssociateV2Read(d, meta)
+	return resourceNetworkingFloatingIPAssociateV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingFloatingIPAssociateV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingFloatingIPAssociateV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack network client: %s", err)
+		return diag.Errorf("Error creating OpenStack network client: %s", err)
 	}
 
 	portID := d.Get("port_id").(string)
@@ -142,7 +143,7 @@ func resourceNetworkingFloatingIPAssociateV2Delete(d *schema.ResourceData, meta
 	log.Printf("[DEBUG] openstack_networking_floatingip_associate_v2 disassociating options: %#v", updateOpts)
 	_, err = floatingips.Update(networkingClient, d.Id(), updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error disassociating openstack_networking_floatingip_associate_v2 floating_ip %s with port %s: %s", d.Id(), portID, err)
+		return diag.Errorf("Error disassociating openstack_networking_floatingip_associate_v2 floating_ip %s with port %s: %s", d.Id(), portID, err)
 	}
 
 	return nil
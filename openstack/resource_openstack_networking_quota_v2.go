@@ -1,22 +1,23 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetworkingQuotaV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkingQuotaV2Create,
-		Read:   resourceNetworkingQuotaV2Read,
-		Update: resourceNetworkingQuotaV2Update,
-		Delete: schema.RemoveFromState,
+		CreateContext: resourceNetworkingQuotaV2Create,
+		ReadContext:   resourceNetworkingQuotaV2Read,
+		UpdateContext: resourceNetworkingQuotaV2Update,
+		DeleteContext: schema.NoopContext,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -96,11 +97,11 @@ func resourceNetworkingQuotaV2() *schema.Resource {
 	}
 }
 
-func resourceNetworkingQuotaV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingQuotaV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	projectID := d.Get("project_id").(string)
@@ -128,26 +129,26 @@ func resourceNetworkingQuotaV2Create(d *schema.ResourceData, meta interface{}) e
 
 	q, err := quotas.Update(networkingClient, projectID, updateOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_networking_quota_v2: %s", err)
+		return diag.Errorf("Error creating openstack_networking_quota_v2: %s", err)
 	}
 
 	d.SetId(projectID)
 
 	log.Printf("[DEBUG] Created openstack_networking_quota_v2 %#v", q)
 
-	return resourceNetworkingQuotaV2Read(d, meta)
+	return resourceNetworkingQuotaV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingQuotaV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingQuotaV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	q, err := quotas.Get(networkingClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_networking_quota_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_networking_quota_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_networking_quota_v2 %s: %#v", d.Id(), q)
@@ -166,11 +167,11 @@ func resourceNetworkingQuotaV2Read(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceNetworkingQuotaV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingQuotaV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	var (
@@ -236,9 +237,9 @@ func resourceNetworkingQuotaV2Update(d *schema.ResourceData, meta interface{}) e
 		log.Printf("[DEBUG] openstack_networking_quota_v2 %s update options: %#v", d.Id(), updateOpts)
 		_, err := quotas.Update(networkingClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_networking_quota_v2: %s", err)
+			return diag.Errorf("Error updating openstack_networking_quota_v2: %s", err)
 		}
 	}
 
-	return resourceNetworkingQuotaV2Read(d, meta)
+	return resourceNetworkingQuotaV2Read(ctx, d, meta)
 }
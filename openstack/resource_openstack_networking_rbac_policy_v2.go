@@ -1,9 +1,10 @@
 package openstack
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -12,12 +13,12 @@ import (
 
 func resourceNetworkingRBACPolicyV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkingRBACPolicyV2Create,
-		Read:   resourceNetworkingRBACPolicyV2Read,
-		Update: resourceNetworkingRBACPolicyV2Update,
-		Delete: resourceNetworkingRBACPolicyV2Delete,
+		CreateContext: resourceNetworkingRBACPolicyV2Create,
+		ReadContext:   resourceNetworkingRBACPolicyV2Read,
+		UpdateContext: resourceNetworkingRBACPolicyV2Update,
+		DeleteContext: resourceNetworkingRBACPolicyV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -65,11 +66,11 @@ func resourceNetworkingRBACPolicyV2() *schema.Resource {
 	}
 }
 
-func resourceNetworkingRBACPolicyV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRBACPolicyV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	createOpts := rbacpolicies.CreateOpts{
@@ -82,24 +83,24 @@ func resourceNetworkingRBACPolicyV2Create(d *schema.ResourceData, meta interface
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	rbac, err := rbacpolicies.Create(networkingClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_networking_rbac_policy_v2: %s", err)
+		return diag.Errorf("Error creating openstack_networking_rbac_policy_v2: %s", err)
 	}
 
 	d.SetId(rbac.ID)
 
-	return resourceNetworkingRBACPolicyV2Read(d, meta)
+	return resourceNetworkingRBACPolicyV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingRBACPolicyV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRBACPolicyV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	rbac, err := rbacpolicies.Get(networkingClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error retrieving openstack_networking_rbac_policy_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving openstack_networking_rbac_policy_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved RBAC policy %s: %+v", d.Id(), rbac)
@@ -115,11 +116,11 @@ func resourceNetworkingRBACPolicyV2Read(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceNetworkingRBACPolicyV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRBACPolicyV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	var updateOpts rbacpolicies.UpdateOpts
@@ -129,23 +130,23 @@ func resourceNetworkingRBACPolicyV2Update(d *schema.ResourceData, meta interface
 
 		_, err := rbacpolicies.Update(networkingClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_networking_rbac_policy_v2: %s", err)
+			return diag.Errorf("Error updating openstack_networking_rbac_policy_v2: %s", err)
 		}
 	}
 
-	return resourceNetworkingRBACPolicyV2Read(d, meta)
+	return resourceNetworkingRBACPolicyV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingRBACPolicyV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRBACPolicyV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	err = rbacpolicies.Delete(networkingClient, d.Id()).ExtractErr()
 	if err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_networking_rbac_policy_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_networking_rbac_policy_v2"))
 	}
 
 	return nil
@@ -1,11 +1,12 @@
 package openstack
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -24,12 +25,12 @@ const (
 
 func resourceNetworkingRouterV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkingRouterV2Create,
-		Read:   resourceNetworkingRouterV2Read,
-		Update: resourceNetworkingRouterV2Update,
-		Delete: resourceNetworkingRouterV2Delete,
+		CreateContext: resourceNetworkingRouterV2Create,
+		ReadContext:   resourceNetworkingRouterV2Read,
+		UpdateContext: resourceNetworkingRouterV2Update,
+		DeleteContext: resourceNetworkingRouterV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -37,6 +38,8 @@ func resourceNetworkingRouterV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: customizeDiffDefaultTags,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -167,11 +170,11 @@ func resourceNetworkingRouterV2() *schema.Resource {
 	}
 }
 
-func resourceNetworkingRouterV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRouterV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	createOpts := RouterCreateOpts{
@@ -217,7 +220,7 @@ func resourceNetworkingRouterV2Create(d *schema.ResourceData, meta interface{})
 
 	if esRaw, ok := d.GetOkExists("enable_snat"); ok {
 		if externalNetworkID == "" {
-			return errors.New(errEnableSNATWithoutExternalNet)
+			return diag.FromErr(errors.New(errEnableSNATWithoutExternalNet))
 		}
 		es := esRaw.(bool)
 		gatewayInfo.EnableSNAT = &es
@@ -226,7 +229,7 @@ func resourceNetworkingRouterV2Create(d *schema.ResourceData, meta interface{})
 	externalFixedIPs := expandNetworkingRouterExternalFixedIPsV2(d.Get("external_fixed_ip").([]interface{}))
 	if len(externalFixedIPs) > 0 {
 		if externalNetworkID == "" {
-			return errors.New(errExternalFixedIPWithoutExternalNet)
+			return diag.FromErr(errors.New(errExternalFixedIPWithoutExternalNet))
 		}
 		gatewayInfo.ExternalFixedIPs = externalFixedIPs
 	}
@@ -243,7 +246,7 @@ func resourceNetworkingRouterV2Create(d *schema.ResourceData, meta interface{})
 
 	r, err := routers.Create(networkingClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_networking_router_v2: %s", err)
+		return diag.Errorf("Error creating openstack_networking_router_v2: %s", err)
 	}
 
 	log.Printf("[DEBUG] Waiting for openstack_networking_router_v2 %s to become available.", r.ID)
@@ -257,9 +260,9 @@ func resourceNetworkingRouterV2Create(d *schema.ResourceData, meta interface{})
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_networking_router_v2 %s to become available: %s", r.ID, err)
+		return diag.Errorf("Error waiting for openstack_networking_router_v2 %s to become available: %s", r.ID, err)
 	}
 
 	d.SetId(r.ID)
@@ -275,29 +278,29 @@ func resourceNetworkingRouterV2Create(d *schema.ResourceData, meta interface{})
 		log.Printf("[DEBUG] Assigning external_gateway to openstack_networking_router_v2 %s with options: %#v", r.ID, updateOpts)
 		_, err = routers.Update(networkingClient, r.ID, updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_networking_router_v2: %s", err)
+			return diag.Errorf("Error updating openstack_networking_router_v2: %s", err)
 		}
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2CreateAttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "routers", r.ID, tagOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error setting tags on openstack_networking_router_v2 %s: %s", r.ID, err)
+			return diag.Errorf("Error setting tags on openstack_networking_router_v2 %s: %s", r.ID, err)
 		}
 		log.Printf("[DEBUG] Set tags %s on openstack_networking_router_v2 %s", tags, r.ID)
 	}
 
 	log.Printf("[DEBUG] Created openstack_networking_router_v2 %s: %#v", r.ID, r)
-	return resourceNetworkingRouterV2Read(d, meta)
+	return resourceNetworkingRouterV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingRouterV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRouterV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	r, err := routers.Get(networkingClient, d.Id()).Extract()
@@ -307,7 +310,7 @@ func resourceNetworkingRouterV2Read(d *schema.ResourceData, meta interface{}) er
 			return nil
 		}
 
-		return fmt.Errorf("Error retrieving openstack_networking_router_v2: %s", err)
+		return diag.Errorf("Error retrieving openstack_networking_router_v2: %s", err)
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_networking_router_v2 %s: %#v", d.Id(), r)
@@ -339,11 +342,11 @@ func resourceNetworkingRouterV2Read(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceNetworkingRouterV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRouterV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	routerID := d.Id()
@@ -395,7 +398,7 @@ func resourceNetworkingRouterV2Update(d *schema.ResourceData, meta interface{})
 	if d.HasChange("enable_snat") {
 		updateGatewaySettings = true
 		if externalNetworkID == "" {
-			return errors.New(errEnableSNATWithoutExternalNet)
+			return diag.FromErr(errors.New(errEnableSNATWithoutExternalNet))
 		}
 
 		enableSNAT := d.Get("enable_snat").(bool)
@@ -409,7 +412,7 @@ func resourceNetworkingRouterV2Update(d *schema.ResourceData, meta interface{})
 		gatewayInfo.ExternalFixedIPs = externalFixedIPs
 		if len(externalFixedIPs) > 0 {
 			if externalNetworkID == "" {
-				return errors.New(errExternalFixedIPWithoutExternalNet)
+				return diag.FromErr(errors.New(errExternalFixedIPWithoutExternalNet))
 			}
 		}
 	}
@@ -423,33 +426,33 @@ func resourceNetworkingRouterV2Update(d *schema.ResourceData, meta interface{})
 		log.Printf("[DEBUG] openstack_networking_router_v2 %s update options: %#v", d.Id(), updateOpts)
 		_, err = routers.Update(networkingClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating openstack_networking_router_v2: %s", err)
+			return diag.Errorf("Error updating openstack_networking_router_v2: %s", err)
 		}
 	}
 
 	// Next, perform any required updates to the tags.
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "routers", d.Id(), tagOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error setting tags on openstack_networking_router_v2 %s: %s", d.Id(), err)
+			return diag.Errorf("Error setting tags on openstack_networking_router_v2 %s: %s", d.Id(), err)
 		}
 		log.Printf("[DEBUG] Set tags %s on openstack_networking_router_v2 %s", tags, d.Id())
 	}
 
-	return resourceNetworkingRouterV2Read(d, meta)
+	return resourceNetworkingRouterV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingRouterV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingRouterV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	if err := routers.Delete(networkingClient, d.Id()).ExtractErr(); err != nil {
-		return CheckDeleted(d, err, "Error deleting openstack_networking_router_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting openstack_networking_router_v2"))
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -461,9 +464,9 @@ func resourceNetworkingRouterV2Delete(d *schema.ResourceData, meta interface{})
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error deleting openstack_networking_router_v2: %s", err)
+		return diag.Errorf("Error deleting openstack_networking_router_v2: %s", err)
 	}
 
 	d.SetId("")
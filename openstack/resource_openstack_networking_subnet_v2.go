@@ -2,10 +2,10 @@ package openstack
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,12 +18,12 @@ import (
 
 func resourceNetworkingSubnetV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkingSubnetV2Create,
-		Read:   resourceNetworkingSubnetV2Read,
-		Update: resourceNetworkingSubnetV2Update,
-		Delete: resourceNetworkingSubnetV2Delete,
+		CreateContext: resourceNetworkingSubnetV2Create,
+		ReadContext:   resourceNetworkingSubnetV2Read,
+		UpdateContext: resourceNetworkingSubnetV2Update,
+		DeleteContext: resourceNetworkingSubnetV2Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -221,6 +221,8 @@ func resourceNetworkingSubnetV2() *schema.Resource {
 		},
 
 		CustomizeDiff: customdiff.Sequence(
+			customizeDiffDefaultTags,
+
 			// Clear the diff if the old and new allocation_pools are the same.
 			func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 				return networkingSubnetV2AllocationPoolsCustomizeDiff(ctx, diff)
@@ -229,16 +231,16 @@ func resourceNetworkingSubnetV2() *schema.Resource {
 	}
 }
 
-func resourceNetworkingSubnetV2Create(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingSubnetV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	// Check nameservers.
 	if err := networkingSubnetV2DNSNameserverAreUnique(d.Get("dns_nameservers").([]interface{})); err != nil {
-		return fmt.Errorf("openstack_networking_subnet_v2 dns_nameservers argument is invalid: %s", err)
+		return diag.Errorf("openstack_networking_subnet_v2 dns_nameservers argument is invalid: %s", err)
 	}
 
 	// Get raw allocation pool value.
@@ -283,7 +285,7 @@ func resourceNetworkingSubnetV2Create(d *schema.ResourceData, meta interface{})
 	// Validate and set prefix options.
 	if v, ok := d.GetOk("prefix_length"); ok {
 		if d.Get("subnetpool_id").(string) == "" {
-			return fmt.Errorf("'prefix_length' is only valid if 'subnetpool_id' is set for openstack_networking_subnet_v2")
+			return diag.Errorf("'prefix_length' is only valid if 'subnetpool_id' is set for openstack_networking_subnet_v2")
 		}
 		prefixLength := v.(int)
 		createOpts.Prefixlen = prefixLength
@@ -296,7 +298,7 @@ func resourceNetworkingSubnetV2Create(d *schema.ResourceData, meta interface{})
 	log.Printf("[DEBUG] openstack_networking_subnet_v2 create options: %#v", createOpts)
 	s, err := subnets.Create(networkingClient, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating openstack_networking_subnet_v2: %s", err)
+		return diag.Errorf("Error creating openstack_networking_subnet_v2: %s", err)
 	}
 
 	log.Printf("[DEBUG] Waiting for openstack_networking_subnet_v2 %s to become available", s.ID)
@@ -308,37 +310,37 @@ func resourceNetworkingSubnetV2Create(d *schema.ResourceData, meta interface{})
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_networking_subnet_v2 %s to become available: %s", s.ID, err)
+		return diag.Errorf("Error waiting for openstack_networking_subnet_v2 %s to become available: %s", s.ID, err)
 	}
 
 	d.SetId(s.ID)
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2CreateAttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "subnets", s.ID, tagOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error creating tags on openstack_networking_subnet_v2 %s: %s", s.ID, err)
+			return diag.Errorf("Error creating tags on openstack_networking_subnet_v2 %s: %s", s.ID, err)
 		}
 		log.Printf("[DEBUG] Set tags %s on openstack_networking_subnet_v2 %s", tags, s.ID)
 	}
 
 	log.Printf("[DEBUG] Created openstack_networking_subnet_v2 %s: %#v", s.ID, s)
-	return resourceNetworkingSubnetV2Read(d, meta)
+	return resourceNetworkingSubnetV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingSubnetV2Read(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingSubnetV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	s, err := subnets.Get(networkingClient, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "Error getting openstack_networking_subnet_v2")
+		return diag.FromErr(CheckDeleted(d, err, "Error getting openstack_networking_subnet_v2"))
 	}
 
 	log.Printf("[DEBUG] Retrieved openstack_networking_subnet_v2 %s: %#v", d.Id(), s)
@@ -381,11 +383,11 @@ func resourceNetworkingSubnetV2Read(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceNetworkingSubnetV2Update(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingSubnetV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	var hasChange bool
@@ -422,7 +424,7 @@ func resourceNetworkingSubnetV2Update(d *schema.ResourceData, meta interface{})
 
 	if d.HasChange("dns_nameservers") {
 		if err := networkingSubnetV2DNSNameserverAreUnique(d.Get("dns_nameservers").([]interface{})); err != nil {
-			return fmt.Errorf("openstack_networking_subnet_v2 dns_nameservers argument is invalid: %s", err)
+			return diag.Errorf("openstack_networking_subnet_v2 dns_nameservers argument is invalid: %s", err)
 		}
 		hasChange = true
 		nameservers := expandToStringSlice(d.Get("dns_nameservers").([]interface{}))
@@ -453,28 +455,28 @@ func resourceNetworkingSubnetV2Update(d *schema.ResourceData, meta interface{})
 		log.Printf("[DEBUG] Updating openstack_networking_subnet_v2 %s with options: %#v", d.Id(), updateOpts)
 		_, err = subnets.Update(networkingClient, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating OpenStack Neutron openstack_networking_subnet_v2 %s: %s", d.Id(), err)
+			return diag.Errorf("Error updating OpenStack Neutron openstack_networking_subnet_v2 %s: %s", d.Id(), err)
 		}
 	}
 
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "subnets", d.Id(), tagOpts).Extract()
 		if err != nil {
-			return fmt.Errorf("Error updating tags on openstack_networking_subnet_v2 %s: %s", d.Id(), err)
+			return diag.Errorf("Error updating tags on openstack_networking_subnet_v2 %s: %s", d.Id(), err)
 		}
 		log.Printf("[DEBUG] Updated tags %s on openstack_networking_subnet_v2 %s", tags, d.Id())
 	}
 
-	return resourceNetworkingSubnetV2Read(d, meta)
+	return resourceNetworkingSubnetV2Read(ctx, d, meta)
 }
 
-func resourceNetworkingSubnetV2Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceNetworkingSubnetV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	networkingClient, err := config.NetworkingV2Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack networking client: %s", err)
+		return diag.Errorf("Error creating OpenStack networking client: %s", err)
 	}
 
 	stateConf := &resource.StateChangeConf{
@@ -486,9 +488,9 @@ func resourceNetworkingSubnetV2Delete(d *schema.ResourceData, meta interface{})
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf("Error waiting for openstack_networking_subnet_v2 %s to become deleted: %s", d.Id(), err)
+		return diag.Errorf("Error waiting for openstack_networking_subnet_v2 %s to become deleted: %s", d.Id(), err)
 	}
 
 	return nil
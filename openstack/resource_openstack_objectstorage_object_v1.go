@@ -2,22 +2,28 @@ package openstack
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mitchellh/go-homedir"
 )
 
 func resourceObjectStorageObjectV1() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectStorageObjectV1Create,
-		Read:   resourceObjectStorageObjectV1Read,
-		Update: resourceObjectStorageObjectV1Update,
-		Delete: resourceObjectStorageObjectV1Delete,
+		CreateContext: resourceObjectStorageObjectV1Create,
+		ReadContext:   resourceObjectStorageObjectV1Read,
+		UpdateContext: resourceObjectStorageObjectV1Update,
+		DeleteContext: resourceObjectStorageObjectV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceObjectStorageObjectV1Import,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -140,11 +146,11 @@ func resourceObjectStorageObjectV1() *schema.Resource {
 	}
 }
 
-func resourceObjectStorageObjectV1Create(d *schema.ResourceData, meta interface{}) error {
+func resourceObjectStorageObjectV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	objectStorageClient, err := config.ObjectStorageV1Client(GetRegion(d, config))
+	objectStorageClient, err := config.ObjectStorageV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack object storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack object storage client: %s", err)
 	}
 
 	name := d.Get("name").(string)
@@ -160,7 +166,7 @@ func resourceObjectStorageObjectV1Create(d *schema.ResourceData, meta interface{
 		isValid = true
 		file, size, err := resourceObjectSourceV1(v.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		createOpts.Content = file
@@ -188,7 +194,7 @@ func resourceObjectStorageObjectV1Create(d *schema.ResourceData, meta interface{
 	}
 
 	if !isValid {
-		return fmt.Errorf("Must specify \"source\", \"content\", \"copy_from\" or \"object_manifest\" field")
+		return diag.Errorf("Must specify \"source\", \"content\", \"copy_from\" or \"object_manifest\" field")
 	}
 
 	if v, ok := d.GetOk("content_disposition"); ok {
@@ -210,7 +216,7 @@ func resourceObjectStorageObjectV1Create(d *schema.ResourceData, meta interface{
 	if v, ok := d.GetOk("delete_at"); ok && v != "" {
 		t, err := time.Parse(time.RFC3339, fmt.Sprintf("%s", v))
 		if err != nil {
-			return fmt.Errorf("Error Parsing Swift Object Lifecycle Expiration Date: %s, %s", err.Error(), v)
+			return diag.Errorf("Error Parsing Swift Object Lifecycle Expiration Date: %s, %s", err.Error(), v)
 		}
 
 		createOpts.DeleteAt = int(t.Unix())
@@ -227,20 +233,20 @@ func resourceObjectStorageObjectV1Create(d *schema.ResourceData, meta interface{
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	_, err = objects.Create(objectStorageClient, cn, name, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack container object: %s", err)
+		return diag.Errorf("Error creating OpenStack container object: %s", err)
 	}
 
 	// Store the ID now
 	d.SetId(fmt.Sprintf("%s/%s", cn, name))
 
-	return resourceObjectStorageObjectV1Read(d, meta)
+	return resourceObjectStorageObjectV1Read(ctx, d, meta)
 }
 
-func resourceObjectStorageObjectV1Read(d *schema.ResourceData, meta interface{}) error {
+func resourceObjectStorageObjectV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	objectStorageClient, err := config.ObjectStorageV1Client(GetRegion(d, config))
+	objectStorageClient, err := config.ObjectStorageV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack object storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack object storage client: %s", err)
 	}
 
 	name := d.Get("name").(string)
@@ -258,7 +264,7 @@ func resourceObjectStorageObjectV1Read(d *schema.ResourceData, meta interface{})
 	log.Printf("[DEBUG] Get Options: %#v", getOpts)
 	result, err := objects.Get(objectStorageClient, cn, name, getOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error getting OpenStack container object: %s", err)
+		return diag.Errorf("Error getting OpenStack container object: %s", err)
 	}
 
 	log.Printf("[DEBUG] Retrieved OpenStack Object Storage Object: %#v", result)
@@ -283,11 +289,11 @@ func resourceObjectStorageObjectV1Read(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceObjectStorageObjectV1Update(d *schema.ResourceData, meta interface{}) error {
+func resourceObjectStorageObjectV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	objectStorageClient, err := config.ObjectStorageV1Client(GetRegion(d, config))
+	objectStorageClient, err := config.ObjectStorageV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack object storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack object storage client: %s", err)
 	}
 
 	name := d.Get("name").(string)
@@ -307,7 +313,7 @@ func resourceObjectStorageObjectV1Update(d *schema.ResourceData, meta interface{
 		v := d.Get("source").(string)
 		file, size, err := resourceObjectSourceV1(v)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		createOpts.Content = file
@@ -352,7 +358,7 @@ func resourceObjectStorageObjectV1Update(d *schema.ResourceData, meta interface{
 	if v, ok := d.GetOk("delete_at"); ok && v != "" {
 		t, err := time.Parse(time.RFC3339, fmt.Sprintf("%s", v))
 		if err != nil {
-			return fmt.Errorf("Error Parsing Swift Object Lifecycle Expiration Date: %s, %s", err.Error(), v)
+			return diag.Errorf("Error Parsing Swift Object Lifecycle Expiration Date: %s, %s", err.Error(), v)
 		}
 
 		createOpts.DeleteAt = int(t.Unix())
@@ -370,17 +376,17 @@ func resourceObjectStorageObjectV1Update(d *schema.ResourceData, meta interface{
 	log.Printf("[DEBUG] Update Options: %#v", createOpts)
 	_, err = objects.Create(objectStorageClient, cn, name, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error updating OpenStack container object: %s", err)
+		return diag.Errorf("Error updating OpenStack container object: %s", err)
 	}
 
-	return resourceObjectStorageObjectV1Read(d, meta)
+	return resourceObjectStorageObjectV1Read(ctx, d, meta)
 }
 
-func resourceObjectStorageObjectV1Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceObjectStorageObjectV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
-	objectStorageClient, err := config.ObjectStorageV1Client(GetRegion(d, config))
+	objectStorageClient, err := config.ObjectStorageV1Client(ctx, GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating OpenStack object storage client: %s", err)
+		return diag.Errorf("Error creating OpenStack object storage client: %s", err)
 	}
 
 	name := d.Get("name").(string)
@@ -389,11 +395,43 @@ func resourceObjectStorageObjectV1Delete(d *schema.ResourceData, meta interface{
 
 	_, err = objects.Delete(objectStorageClient, cn, name, deleteOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error getting OpenStack container object: %s", err)
+		return diag.Errorf("Error getting OpenStack container object: %s", err)
 	}
 	return nil
 }
 
+func resourceObjectStorageObjectV1Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid format specified for Object. Format must be <container name>/<object name>")
+	}
+
+	config := meta.(*Config)
+	objectStorageClient, err := config.ObjectStorageV1Client(ctx, GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating OpenStack object storage client: %s", err)
+	}
+
+	cn, name := parts[0], parts[1]
+	metadata, err := objects.Get(objectStorageClient, cn, name, nil).ExtractMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting OpenStack container object %s: %s", d.Id(), err)
+	}
+
+	// Swift returns the metadata keys in canonical header form.
+	m := make(map[string]string, len(metadata))
+	for key, val := range metadata {
+		m[strings.ToLower(key)] = val
+	}
+
+	d.Set("container_name", cn)
+	d.Set("name", name)
+	d.Set("metadata", m)
+	d.Set("region", GetRegion(d, config))
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceObjectMetadataV1(d *schema.ResourceData) map[string]string {
 	m := make(map[string]string)
 	for key, val := range d.Get("metadata").(map[string]interface{}) {
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -122,7 +123,7 @@ func TestAccOrchestrationV1Stack_outputs(t *testing.T) {
 
 func testAccCheckOrchestrationV1StackDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
-	orchestrationClient, err := config.OrchestrationV1Client(OS_REGION_NAME)
+	orchestrationClient, err := config.OrchestrationV1Client(context.TODO(), OS_REGION_NAME)
 	if err != nil {
 		return fmt.Errorf("Error creating OpenStack Orchestration client: %s", err)
 	}
@@ -155,7 +156,7 @@ func testAccCheckOrchestrationV1StackExists(n string, stack *stacks.RetrievedSta
 		}
 
 		config := testAccProvider.Meta().(*Config)
-		orchestrationClient, err := config.OrchestrationV1Client(OS_REGION_NAME)
+		orchestrationClient, err := config.OrchestrationV1Client(context.TODO(), OS_REGION_NAME)
 		if err != nil {
 			return fmt.Errorf("Error creating OpenStack orchestration client: %s", err)
 		}
@@ -0,0 +1,184 @@
+package openstack
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how API requests failing with a transient error are
+// retried.
+type RetryPolicy struct {
+	// StatusCodes are the response status codes which are retried.
+	StatusCodes []int
+
+	// MinBackoff is the delay before the first retry. The delay doubles
+	// with each retry up to MaxBackoff. Zero retries without delay.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// MaxElapsedTime is the time after which a request is not retried
+	// anymore. Zero disables retries.
+	MaxElapsedTime time.Duration
+
+	// RetryPOST enables retries of POST requests, which are not idempotent.
+	RetryPOST bool
+}
+
+// defaultRetryPolicy returns the retry policy of a retry block without
+// arguments.
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		StatusCodes:    []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		MinBackoff:     time.Second,
+		MaxBackoff:     30 * time.Second,
+		MaxElapsedTime: time.Minute,
+	}
+}
+
+func (p RetryPolicy) retryMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	case "POST":
+		return p.RetryPOST
+	}
+
+	return false
+}
+
+func (p RetryPolicy) retryStatus(code int) bool {
+	for _, c := range p.StatusCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay before the given retry, starting at 0. The delay
+// is randomized between half and the full exponential backoff.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	backoff := p.MinBackoff
+	for i := 0; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	if half := int64(backoff / 2); half > 0 {
+		backoff = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	return backoff
+}
+
+// retryAfter parses the Retry-After header of a response, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+type retryContextKey struct{}
+
+// withRetry records the number of a retry in the context of its request.
+func withRetry(ctx context.Context, retry int) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, retry)
+}
+
+// retryFromContext returns the number of the retry of a request, 0 for the
+// first attempt.
+func retryFromContext(ctx context.Context) int {
+	retry, _ := ctx.Value(retryContextKey{}).(int)
+	return retry
+}
+
+// retryTransport is an http.RoundTripper which retries requests according
+// to a RetryPolicy.
+type retryTransport struct {
+	rt     http.RoundTripper
+	policy RetryPolicy
+}
+
+func newRetryTransport(rt http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if policy.MaxElapsedTime <= 0 || len(policy.StatusCodes) == 0 {
+		return rt
+	}
+
+	return &retryTransport{rt: rt, policy: policy}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests with a body which cannot be replayed are sent only once.
+	if !t.policy.retryMethod(req.Method) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return t.rt.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	start := time.Now()
+
+	for retry := 0; ; retry++ {
+		attempt := req
+		if retry > 0 {
+			attempt = req.Clone(withRetry(ctx, retry))
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attempt.Body = body
+			}
+		}
+
+		resp, err := t.rt.RoundTrip(attempt)
+		if err != nil || !t.policy.retryStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		now := time.Now()
+		wait, ok := retryAfter(resp, now)
+		if !ok {
+			wait = t.policy.backoff(retry)
+		}
+		if now.Add(wait).Sub(start) > t.policy.MaxElapsedTime {
+			return resp, nil
+		}
+
+		log.Printf("[DEBUG] OpenStack API returned %d for %s %s, retry %d in %s", resp.StatusCode, req.Method, req.URL, retry+1, wait)
+
+		// Drain the body to reuse the connection.
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package openstack
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		StatusCodes:    []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		MinBackoff:     time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		MaxElapsedTime: time.Second,
+	}
+}
+
+// testRetryServer fails the first failures requests with status and records
+// the request bodies.
+func testRetryServer(failures, status int, header map[string]string) (*httptest.Server, *[]string) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failures {
+			for k, v := range header {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(status)
+		}
+	}))
+
+	return srv, &bodies
+}
+
+func testRetryRequest(t *testing.T, rt http.RoundTripper, method, url, body string) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func TestRetryTransportDisabled(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.MaxElapsedTime = 0
+
+	assert.Equal(t, http.DefaultTransport, newRetryTransport(http.DefaultTransport, policy))
+}
+
+func TestRetryTransportStatusCodes(t *testing.T) {
+	srv, bodies := testRetryServer(2, http.StatusServiceUnavailable, nil)
+	defer srv.Close()
+
+	rt := newRetryTransport(http.DefaultTransport, testRetryPolicy())
+	resp := testRetryRequest(t, rt, "PUT", srv.URL, "body")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"body", "body", "body"}, *bodies)
+
+	// Other errors are returned as is.
+	srv, bodies = testRetryServer(1, http.StatusConflict, nil)
+	defer srv.Close()
+
+	resp = testRetryRequest(t, rt, "DELETE", srv.URL, "")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Len(t, *bodies, 1)
+}
+
+func TestRetryTransportPOST(t *testing.T) {
+	srv, bodies := testRetryServer(1, http.StatusTooManyRequests, nil)
+	defer srv.Close()
+
+	policy := testRetryPolicy()
+	resp := testRetryRequest(t, newRetryTransport(http.DefaultTransport, policy), "POST", srv.URL, "body")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Len(t, *bodies, 1)
+
+	*bodies = nil
+	policy.RetryPOST = true
+	resp = testRetryRequest(t, newRetryTransport(http.DefaultTransport, policy), "POST", srv.URL, "body")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"body", "body"}, *bodies)
+}
+
+func TestRetryTransportMaxElapsedTime(t *testing.T) {
+	srv, bodies := testRetryServer(1, http.StatusTooManyRequests, map[string]string{"Retry-After": "120"})
+	defer srv.Close()
+
+	// Waiting for Retry-After would exceed the maximum elapsed time.
+	start := time.Now()
+	resp := testRetryRequest(t, newRetryTransport(http.DefaultTransport, testRetryPolicy()), "GET", srv.URL, "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Len(t, *bodies, 1)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestRetryTransportContext(t *testing.T) {
+	srv, _ := testRetryServer(1, http.StatusServiceUnavailable, map[string]string{"Retry-After": "1"})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	policy := testRetryPolicy()
+	policy.MaxElapsedTime = time.Minute
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	_, err := newRetryTransport(http.DefaultTransport, policy).RoundTrip(req)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 8, 21, 12, 0, 0, 0, time.UTC)
+
+	for header, expected := range map[string]time.Duration{
+		"3":                             3 * time.Second,
+		"Fri, 21 Aug 2020 12:00:10 GMT": 10 * time.Second,
+		"Fri, 21 Aug 2020 11:00:00 GMT": 0,
+	} {
+		resp := &http.Response{Header: http.Header{"Retry-After": {header}}}
+		d, ok := retryAfter(resp, now)
+		assert.True(t, ok)
+		assert.Equal(t, expected, d)
+	}
+
+	_, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {"soon"}}}, now)
+	assert.False(t, ok)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	for retry, max := range []time.Duration{1, 2, 4, 8, 10, 10} {
+		max *= time.Second
+		for i := 0; i < 10; i++ {
+			backoff := policy.backoff(retry)
+			assert.True(t, backoff >= max/2 && backoff <= max, "retry %d: %s", retry, backoff)
+		}
+	}
+	assert.True(t, policy.backoff(100) <= policy.MaxBackoff)
+
+	// A zero min_backoff retries without delay.
+	policy.MinBackoff = 0
+	assert.Equal(t, time.Duration(0), policy.backoff(0))
+	assert.Equal(t, time.Duration(0), policy.backoff(5))
+}
+
+func TestExpandProviderRetryPolicy(t *testing.T) {
+	retry := map[string]interface{}{
+		"status_codes":     []interface{}{},
+		"min_backoff":      "0s",
+		"max_backoff":      "5s",
+		"max_elapsed_time": "1m",
+		"retry_post":       false,
+	}
+
+	policy := defaultRetryPolicy()
+	assert.NoError(t, expandProviderRetryPolicy(retry, &policy))
+	assert.Equal(t, time.Duration(0), policy.MinBackoff)
+	assert.Equal(t, 5*time.Second, policy.MaxBackoff)
+	assert.Equal(t, defaultRetryPolicy().StatusCodes, policy.StatusCodes)
+
+	retry["min_backoff"] = "10s"
+	assert.Error(t, expandProviderRetryPolicy(retry, &policy))
+}
@@ -4,8 +4,27 @@ import (
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/shares"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// sharedFilesystemShareAccessV2Microversions returns the microversions
+// needed to grant the configured access.
+func sharedFilesystemShareAccessV2Microversions(d resourceGetter) []microversionRequirement {
+	if d.Get("access_type").(string) == "cephx" {
+		return []microversionRequirement{{"access_type cephx", sharedFilesystemV2SharedAccessCephXMicroversion}}
+	}
+
+	return nil
+}
+
+func sharedFilesystemShareAccessV2DiffMicroversions(diff *schema.ResourceDiff) []microversionRequirement {
+	if diff.Id() == "" || diff.HasChange("access_type") {
+		return sharedFilesystemShareAccessV2Microversions(diff)
+	}
+
+	return nil
+}
+
 func sharedFilesystemShareAccessV2StateRefreshFunc(client *gophercloud.ServiceClient, shareID string, accessID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		access, err := shares.ListAccessRights(client, shareID).Extract()
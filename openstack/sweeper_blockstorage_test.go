@@ -0,0 +1,53 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+)
+
+func init() {
+	addTestSweepers(testSweeper{
+		name:         "openstack_blockstorage_volume_v3",
+		dependencies: []string{"openstack_compute_instance_v2"},
+		sweep:        sweepBlockStorageV3Volumes,
+	})
+}
+
+func sweepBlockStorageV3Volumes(ctx context.Context, config *Config, region string) error {
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+
+	allPages, err := volumes.List(blockStorageClient, volumes.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allVolumes, err := volumes.ExtractVolumes(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range allVolumes {
+		if !sweepable(v.Name) {
+			continue
+		}
+
+		// Volumes still attached to instances which are not swept are
+		// left alone.
+		if len(v.Attachments) > 0 {
+			continue
+		}
+
+		err := volumes.Delete(blockStorageClient, v.ID, volumes.DeleteOpts{Cascade: true}).ExtractErr()
+		if err := sweepDeleted("openstack_blockstorage_volume_v3", v.Name, v.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package openstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name: "openstack_compute_instance_v2",
+			// Floating IPs are matched by the instances they are
+			// associated with.
+			dependencies: []string{"openstack_networking_floatingip_v2"},
+			sweep:        sweepComputeV2Instances,
+		},
+		testSweeper{
+			name:  "openstack_compute_keypair_v2",
+			sweep: sweepComputeV2Keypairs,
+		},
+	)
+}
+
+// sweepComputeV2SweptInstances returns the instances which are swept, or nil
+// if the cloud offers no compute service.
+func sweepComputeV2SweptInstances(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, []servers.Server, error) {
+	computeClient, err := config.ComputeV2Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	allPages, err := servers.List(computeClient, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, nil, err
+	}
+	allServers, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var swept []servers.Server
+	for _, s := range allServers {
+		if sweepable(s.Name) {
+			swept = append(swept, s)
+		}
+	}
+
+	return computeClient, swept, nil
+}
+
+func sweepComputeV2Instances(ctx context.Context, config *Config, region string) error {
+	computeClient, swept, err := sweepComputeV2SweptInstances(ctx, config, region)
+	if computeClient == nil || err != nil {
+		return err
+	}
+
+	var deleted []string
+	for _, s := range swept {
+		err := servers.Delete(computeClient, s.ID).ExtractErr()
+		if err := sweepDeleted("openstack_compute_instance_v2", s.Name, s.ID, err); err != nil {
+			return err
+		}
+		deleted = append(deleted, s.ID)
+	}
+
+	// Wait for the instances to go away so that their ports and volumes are
+	// released before the dependent sweepers run.
+	for _, id := range deleted {
+		stateConf := &resource.StateChangeConf{
+			Target:     []string{"DELETED", "SOFT_DELETED"},
+			Refresh:    sweepComputeV2InstanceRefreshFunc(computeClient, id),
+			Timeout:    10 * time.Minute,
+			MinTimeout: 1 * time.Second,
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepComputeV2InstanceRefreshFunc reports the status of an instance being
+// swept. Unlike ServerV2StateRefreshFunc it does not fail on instances in
+// error status, which are the ones most likely to be leaked.
+func sweepComputeV2InstanceRefreshFunc(client *gophercloud.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := servers.Get(client, id).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return s, "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		return s, s.Status, nil
+	}
+}
+
+func sweepComputeV2Keypairs(ctx context.Context, config *Config, region string) error {
+	computeClient, err := config.ComputeV2Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+
+	allPages, err := keypairs.List(computeClient).AllPages()
+	if err != nil {
+		return err
+	}
+	allKeypairs, err := keypairs.ExtractKeyPairs(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, kp := range allKeypairs {
+		if !sweepable(kp.Name) {
+			continue
+		}
+
+		err := keypairs.Delete(computeClient, kp.Name).ExtractErr()
+		if err := sweepDeleted("openstack_compute_keypair_v2", kp.Name, kp.Name, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
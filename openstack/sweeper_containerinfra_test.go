@@ -0,0 +1,110 @@
+package openstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/clusters"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/clustertemplates"
+)
+
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_containerinfra_cluster_v1",
+			sweep: sweepContainerInfraV1Clusters,
+		},
+		testSweeper{
+			name:         "openstack_containerinfra_clustertemplate_v1",
+			dependencies: []string{"openstack_containerinfra_cluster_v1"},
+			sweep:        sweepContainerInfraV1ClusterTemplates,
+		},
+	)
+}
+
+// sweepContainerInfraV1Client returns the container infra client, or nil if
+// the cloud offers no container infra service.
+func sweepContainerInfraV1Client(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, error) {
+	containerInfraClient, err := config.ContainerInfraV1Client(ctx, region)
+	if err != nil && sweepUnsupported(err) {
+		return nil, nil
+	}
+
+	return containerInfraClient, err
+}
+
+// sweepContainerInfraV1Clusters deletes clusters and waits for them to go
+// away, since their cluster templates cannot be deleted before.
+func sweepContainerInfraV1Clusters(ctx context.Context, config *Config, region string) error {
+	containerInfraClient, err := sweepContainerInfraV1Client(ctx, config, region)
+	if containerInfraClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := clusters.List(containerInfraClient, clusters.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allClusters, err := clusters.ExtractClusters(allPages)
+	if err != nil {
+		return err
+	}
+
+	var deleted []string
+	for _, cluster := range allClusters {
+		if !sweepable(cluster.Name) {
+			continue
+		}
+
+		err := clusters.Delete(containerInfraClient, cluster.UUID).ExtractErr()
+		if err := sweepDeleted("openstack_containerinfra_cluster_v1", cluster.Name, cluster.UUID, err); err != nil {
+			return err
+		}
+		deleted = append(deleted, cluster.UUID)
+	}
+
+	for _, id := range deleted {
+		err := sweepWaitForDeleted(ctx, 30*time.Minute, func() (string, error) {
+			cluster, err := clusters.Get(containerInfraClient, id).Extract()
+			if err != nil {
+				return "", err
+			}
+			return cluster.Status, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepContainerInfraV1ClusterTemplates(ctx context.Context, config *Config, region string) error {
+	containerInfraClient, err := sweepContainerInfraV1Client(ctx, config, region)
+	if containerInfraClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := clustertemplates.List(containerInfraClient, clustertemplates.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allClusterTemplates, err := clustertemplates.ExtractClusterTemplates(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, ct := range allClusterTemplates {
+		if !sweepable(ct.Name) {
+			continue
+		}
+
+		err := clustertemplates.Delete(containerInfraClient, ct.UUID).ExtractErr()
+		if err := sweepDeleted("openstack_containerinfra_clustertemplate_v1", ct.Name, ct.UUID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package openstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/db/v1/configurations"
+	"github.com/gophercloud/gophercloud/openstack/db/v1/instances"
+)
+
+// Databases and users are deleted together with their instances.
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_db_instance_v1",
+			sweep: sweepDatabaseV1Instances,
+		},
+		testSweeper{
+			name:         "openstack_db_configuration_v1",
+			dependencies: []string{"openstack_db_instance_v1"},
+			sweep:        sweepDatabaseV1Configurations,
+		},
+	)
+}
+
+// sweepDatabaseV1Client returns the database client, or nil if the cloud
+// offers no database service.
+func sweepDatabaseV1Client(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, error) {
+	databaseClient, err := config.DatabaseV1Client(ctx, region)
+	if err != nil && sweepUnsupported(err) {
+		return nil, nil
+	}
+
+	return databaseClient, err
+}
+
+// sweepDatabaseV1Instances deletes instances and waits for them to go away,
+// since the configurations attached to them cannot be deleted before.
+func sweepDatabaseV1Instances(ctx context.Context, config *Config, region string) error {
+	databaseClient, err := sweepDatabaseV1Client(ctx, config, region)
+	if databaseClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := instances.List(databaseClient).AllPages()
+	if err != nil {
+		return err
+	}
+	allInstances, err := instances.ExtractInstances(allPages)
+	if err != nil {
+		return err
+	}
+
+	var deleted []string
+	for _, instance := range allInstances {
+		if !sweepable(instance.Name) {
+			continue
+		}
+
+		err := instances.Delete(databaseClient, instance.ID).ExtractErr()
+		if err := sweepDeleted("openstack_db_instance_v1", instance.Name, instance.ID, err); err != nil {
+			return err
+		}
+		deleted = append(deleted, instance.ID)
+	}
+
+	for _, id := range deleted {
+		err := sweepWaitForDeleted(ctx, 10*time.Minute, func() (string, error) {
+			instance, err := instances.Get(databaseClient, id).Extract()
+			if err != nil {
+				return "", err
+			}
+			return instance.Status, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepDatabaseV1Configurations(ctx context.Context, config *Config, region string) error {
+	databaseClient, err := sweepDatabaseV1Client(ctx, config, region)
+	if databaseClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := configurations.List(databaseClient).AllPages()
+	if err != nil {
+		return err
+	}
+	allConfigurations, err := configurations.ExtractConfigs(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range allConfigurations {
+		if !sweepable(c.Name) {
+			continue
+		}
+
+		err := configurations.Delete(databaseClient, c.ID).ExtractErr()
+		if err := sweepDeleted("openstack_db_configuration_v1", c.Name, c.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
+)
+
+func init() {
+	addTestSweepers(testSweeper{
+		name:  "openstack_dns_zone_v2",
+		sweep: sweepDNSV2Zones,
+	})
+}
+
+// sweepDNSV2Zones deletes zones together with their record sets.
+func sweepDNSV2Zones(ctx context.Context, config *Config, region string) error {
+	dnsClient, err := config.DNSV2Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+
+	allPages, err := zones.List(dnsClient, zones.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allZones, err := zones.ExtractZones(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, z := range allZones {
+		if !sweepable(z.Name) {
+			continue
+		}
+
+		_, err := zones.Delete(dnsClient, z.ID).Extract()
+		if err := sweepDeleted("openstack_dns_zone_v2", z.Name, z.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package openstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas/firewalls"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas/policies"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas/rules"
+)
+
+// Clouds without the FWaaS extension answer the listings with 404, which
+// leaves nothing to sweep.
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_fw_firewall_v1",
+			sweep: sweepFWV1Firewalls,
+		},
+		testSweeper{
+			name:         "openstack_fw_policy_v1",
+			dependencies: []string{"openstack_fw_firewall_v1"},
+			sweep:        sweepFWV1Policies,
+		},
+		testSweeper{
+			name:         "openstack_fw_rule_v1",
+			dependencies: []string{"openstack_fw_policy_v1"},
+			sweep:        sweepFWV1Rules,
+		},
+	)
+}
+
+// sweepFWV1Firewalls deletes firewalls and waits for them to go away, since
+// their policies and routers cannot be deleted before.
+func sweepFWV1Firewalls(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := firewalls.List(networkingClient, firewalls.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allFirewalls, err := firewalls.ExtractFirewalls(allPages)
+	if err != nil {
+		return err
+	}
+
+	var deleted []string
+	for _, fw := range allFirewalls {
+		if !sweepable(fw.Name) {
+			continue
+		}
+
+		err := firewalls.Delete(networkingClient, fw.ID).ExtractErr()
+		if err := sweepDeleted("openstack_fw_firewall_v1", fw.Name, fw.ID, err); err != nil {
+			return err
+		}
+		deleted = append(deleted, fw.ID)
+	}
+
+	for _, id := range deleted {
+		err := sweepWaitForDeleted(ctx, 10*time.Minute, func() (string, error) {
+			fw, err := firewalls.Get(networkingClient, id).Extract()
+			if err != nil {
+				return "", err
+			}
+			return fw.Status, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepFWV1Policies(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := policies.List(networkingClient, policies.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allPolicies, err := policies.ExtractPolicies(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range allPolicies {
+		if !sweepable(policy.Name) {
+			continue
+		}
+
+		err := policies.Delete(networkingClient, policy.ID).ExtractErr()
+		if err := sweepDeleted("openstack_fw_policy_v1", policy.Name, policy.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepFWV1Rules(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := rules.List(networkingClient, rules.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allRules, err := rules.ExtractRules(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range allRules {
+		if !sweepable(rule.Name) {
+			continue
+		}
+
+		err := rules.Delete(networkingClient, rule.ID).ExtractErr()
+		if err := sweepDeleted("openstack_fw_rule_v1", rule.Name, rule.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+)
+
+func init() {
+	addTestSweepers(testSweeper{
+		name:         "openstack_images_image_v2",
+		dependencies: []string{"openstack_compute_instance_v2"},
+		sweep:        sweepImagesV2Images,
+	})
+}
+
+func sweepImagesV2Images(ctx context.Context, config *Config, region string) error {
+	imageClient, err := config.ImageV2Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+
+	allPages, err := images.List(imageClient, images.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allImages, err := images.ExtractImages(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range allImages {
+		// Protected images cannot be deleted, so they are left alone.
+		if !sweepable(image.Name) || image.Protected {
+			continue
+		}
+
+		err := images.Delete(imageClient, image.ID).ExtractErr()
+		if err := sweepDeleted("openstack_images_image_v2", image.Name, image.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
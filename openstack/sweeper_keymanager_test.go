@@ -0,0 +1,135 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/containers"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/orders"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/secrets"
+)
+
+// Containers and orders refer to secrets, so they are swept first.
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_keymanager_container_v1",
+			sweep: sweepKeyManagerV1Containers,
+		},
+		testSweeper{
+			name:  "openstack_keymanager_order_v1",
+			sweep: sweepKeyManagerV1Orders,
+		},
+		testSweeper{
+			name: "openstack_keymanager_secret_v1",
+			dependencies: []string{
+				"openstack_keymanager_container_v1",
+				"openstack_keymanager_order_v1",
+			},
+			sweep: sweepKeyManagerV1Secrets,
+		},
+	)
+}
+
+// sweepKeyManagerV1Client returns the key manager client, or nil if the cloud
+// offers no key manager service.
+func sweepKeyManagerV1Client(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, error) {
+	kmClient, err := config.KeyManagerV1Client(ctx, region)
+	if err != nil && sweepUnsupported(err) {
+		return nil, nil
+	}
+
+	return kmClient, err
+}
+
+func sweepKeyManagerV1Containers(ctx context.Context, config *Config, region string) error {
+	kmClient, err := sweepKeyManagerV1Client(ctx, config, region)
+	if kmClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := containers.List(kmClient, containers.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allContainers, err := containers.ExtractContainers(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, container := range allContainers {
+		if !sweepable(container.Name) {
+			continue
+		}
+
+		id := keyManagerContainerV1GetUUIDfromContainerRef(container.ContainerRef)
+		err := containers.Delete(kmClient, id).ExtractErr()
+		if err := sweepDeleted("openstack_keymanager_container_v1", container.Name, id, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepKeyManagerV1Orders deletes orders by the name they give their secret.
+// The secrets themselves are swept on their own.
+func sweepKeyManagerV1Orders(ctx context.Context, config *Config, region string) error {
+	kmClient, err := sweepKeyManagerV1Client(ctx, config, region)
+	if kmClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := orders.List(kmClient, orders.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allOrders, err := orders.ExtractOrders(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range allOrders {
+		if !sweepable(order.Meta.Name) {
+			continue
+		}
+
+		id := keyManagerOrderV1GetUUIDfromOrderRef(order.OrderRef)
+		err := orders.Delete(kmClient, id).ExtractErr()
+		if err := sweepDeleted("openstack_keymanager_order_v1", order.Meta.Name, id, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepKeyManagerV1Secrets(ctx context.Context, config *Config, region string) error {
+	kmClient, err := sweepKeyManagerV1Client(ctx, config, region)
+	if kmClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := secrets.List(kmClient, secrets.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allSecrets, err := secrets.ExtractSecrets(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range allSecrets {
+		if !sweepable(secret.Name) {
+			continue
+		}
+
+		id := keyManagerSecretV1GetUUIDfromSecretRef(secret.SecretRef)
+		err := secrets.Delete(kmClient, id).ExtractErr()
+		if err := sweepDeleted("openstack_keymanager_secret_v1", secret.Name, id, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,375 @@
+package openstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/listeners"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/monitors"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/pools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Load balancer objects are swept if their own name or the name of their
+// load balancer matches. Octavia rejects changes while a load balancer is
+// busy, so every deletion waits for the load balancer to settle.
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_lb_member_v2",
+			sweep: sweepLBV2Members,
+		},
+		testSweeper{
+			name:  "openstack_lb_monitor_v2",
+			sweep: sweepLBV2Monitors,
+		},
+		testSweeper{
+			name: "openstack_lb_pool_v2",
+			dependencies: []string{
+				"openstack_lb_member_v2",
+				"openstack_lb_monitor_v2",
+			},
+			sweep: sweepLBV2Pools,
+		},
+		testSweeper{
+			name:         "openstack_lb_listener_v2",
+			dependencies: []string{"openstack_lb_pool_v2"},
+			sweep:        sweepLBV2Listeners,
+		},
+		testSweeper{
+			name:         "openstack_lb_loadbalancer_v2",
+			dependencies: []string{"openstack_lb_listener_v2"},
+			sweep:        sweepLBV2LoadBalancers,
+		},
+	)
+}
+
+const sweepLBV2Timeout = 10 * time.Minute
+
+// sweepLBV2Client returns the load balancer client and the load balancers
+// which are swept, or a nil client if the cloud offers no load balancing.
+func sweepLBV2Client(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, []loadbalancers.LoadBalancer, error) {
+	lbClient, err := chooseLBV2AccTestClient(ctx, config, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	allPages, err := loadbalancers.List(lbClient, loadbalancers.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	allLoadBalancers, err := loadbalancers.ExtractLoadBalancers(allPages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var swept []loadbalancers.LoadBalancer
+	for _, lb := range allLoadBalancers {
+		if sweepable(lb.Name) {
+			swept = append(swept, lb)
+		}
+	}
+
+	return lbClient, swept, nil
+}
+
+func sweepLBV2LoadBalancerIDs(lbs []loadbalancers.LoadBalancer) map[string]bool {
+	ids := make(map[string]bool)
+	for _, lb := range lbs {
+		ids[lb.ID] = true
+	}
+
+	return ids
+}
+
+// sweepLBV2SweptPools returns the pools which are swept.
+func sweepLBV2SweptPools(lbClient *gophercloud.ServiceClient, lbs []loadbalancers.LoadBalancer) ([]pools.Pool, error) {
+	allPages, err := pools.List(lbClient, pools.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allPools, err := pools.ExtractPools(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	lbIDs := sweepLBV2LoadBalancerIDs(lbs)
+	var swept []pools.Pool
+	for _, pool := range allPools {
+		if sweepable(pool.Name) || lbIDs[sweepLBV2PoolLoadBalancerID(pool)] {
+			swept = append(swept, pool)
+		}
+	}
+
+	return swept, nil
+}
+
+func sweepLBV2PoolLoadBalancerID(pool pools.Pool) string {
+	if len(pool.Loadbalancers) == 0 {
+		return ""
+	}
+
+	return pool.Loadbalancers[0].ID
+}
+
+func sweepLBV2WaitForLoadBalancer(ctx context.Context, lbClient *gophercloud.ServiceClient, lbID string) error {
+	if lbID == "" {
+		return nil
+	}
+
+	// Load balancers in ERROR status accept deletions as well.
+	stateConf := &resource.StateChangeConf{
+		Pending:    lbPendingStatuses,
+		Target:     lbSkipLBStatuses,
+		Refresh:    resourceLBV2LoadBalancerRefreshFunc(lbClient, lbID),
+		Timeout:    sweepLBV2Timeout,
+		MinTimeout: 1 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+func sweepLBV2Members(ctx context.Context, config *Config, region string) error {
+	lbClient, lbs, err := sweepLBV2Client(ctx, config, region)
+	if lbClient == nil || err != nil {
+		return err
+	}
+
+	swept, err := sweepLBV2SweptPools(lbClient, lbs)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range swept {
+		allPages, err := pools.ListMembers(lbClient, pool.ID, pools.ListMembersOpts{}).AllPages()
+		if err != nil {
+			return err
+		}
+		allMembers, err := pools.ExtractMembers(allPages)
+		if err != nil {
+			return err
+		}
+
+		lbID := sweepLBV2PoolLoadBalancerID(pool)
+		for _, member := range allMembers {
+			if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+				return err
+			}
+
+			err := pools.DeleteMember(lbClient, pool.ID, member.ID).ExtractErr()
+			if err := sweepDeleted("openstack_lb_member_v2", member.Name, member.ID, err); err != nil {
+				return err
+			}
+
+			err = sweepWaitForDeleted(ctx, sweepLBV2Timeout, func() (string, error) {
+				m, err := pools.GetMember(lbClient, pool.ID, member.ID).Extract()
+				if err != nil {
+					return "", err
+				}
+				return m.ProvisioningStatus, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepLBV2Monitors(ctx context.Context, config *Config, region string) error {
+	lbClient, lbs, err := sweepLBV2Client(ctx, config, region)
+	if lbClient == nil || err != nil {
+		return err
+	}
+
+	swept, err := sweepLBV2SweptPools(lbClient, lbs)
+	if err != nil {
+		return err
+	}
+	poolLBs := make(map[string]string)
+	for _, pool := range swept {
+		poolLBs[pool.ID] = sweepLBV2PoolLoadBalancerID(pool)
+	}
+
+	allPages, err := monitors.List(lbClient, monitors.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allMonitors, err := monitors.ExtractMonitors(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, monitor := range allMonitors {
+		var lbID string
+		var found bool
+		for _, pool := range monitor.Pools {
+			if lbID, found = poolLBs[pool.ID]; found {
+				break
+			}
+		}
+		if !found && !sweepable(monitor.Name) {
+			continue
+		}
+
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+
+		err := monitors.Delete(lbClient, monitor.ID).ExtractErr()
+		if err := sweepDeleted("openstack_lb_monitor_v2", monitor.Name, monitor.ID, err); err != nil {
+			return err
+		}
+
+		err = sweepWaitForDeleted(ctx, sweepLBV2Timeout, func() (string, error) {
+			m, err := monitors.Get(lbClient, monitor.ID).Extract()
+			if err != nil {
+				return "", err
+			}
+			return m.ProvisioningStatus, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepLBV2Pools(ctx context.Context, config *Config, region string) error {
+	lbClient, lbs, err := sweepLBV2Client(ctx, config, region)
+	if lbClient == nil || err != nil {
+		return err
+	}
+
+	swept, err := sweepLBV2SweptPools(lbClient, lbs)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range swept {
+		lbID := sweepLBV2PoolLoadBalancerID(pool)
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+
+		err := pools.Delete(lbClient, pool.ID).ExtractErr()
+		if err := sweepDeleted("openstack_lb_pool_v2", pool.Name, pool.ID, err); err != nil {
+			return err
+		}
+
+		err = sweepWaitForDeleted(ctx, sweepLBV2Timeout, func() (string, error) {
+			p, err := pools.Get(lbClient, pool.ID).Extract()
+			if err != nil {
+				return "", err
+			}
+			return p.ProvisioningStatus, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepLBV2Listeners(ctx context.Context, config *Config, region string) error {
+	lbClient, lbs, err := sweepLBV2Client(ctx, config, region)
+	if lbClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := listeners.List(lbClient, listeners.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allListeners, err := listeners.ExtractListeners(allPages)
+	if err != nil {
+		return err
+	}
+
+	lbIDs := sweepLBV2LoadBalancerIDs(lbs)
+	for _, listener := range allListeners {
+		var lbID string
+		if len(listener.Loadbalancers) > 0 {
+			lbID = listener.Loadbalancers[0].ID
+		}
+		if !sweepable(listener.Name) && !lbIDs[lbID] {
+			continue
+		}
+
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+
+		err := listeners.Delete(lbClient, listener.ID).ExtractErr()
+		if err := sweepDeleted("openstack_lb_listener_v2", listener.Name, listener.ID, err); err != nil {
+			return err
+		}
+
+		err = sweepWaitForDeleted(ctx, sweepLBV2Timeout, func() (string, error) {
+			l, err := listeners.Get(lbClient, listener.ID).Extract()
+			if err != nil {
+				return "", err
+			}
+			return l.ProvisioningStatus, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lbID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepLBV2LoadBalancers(ctx context.Context, config *Config, region string) error {
+	lbClient, lbs, err := sweepLBV2Client(ctx, config, region)
+	if lbClient == nil || err != nil {
+		return err
+	}
+
+	for _, lb := range lbs {
+		if err := sweepLBV2WaitForLoadBalancer(ctx, lbClient, lb.ID); err != nil {
+			return err
+		}
+
+		err := loadbalancers.Delete(lbClient, lb.ID).ExtractErr()
+		if err := sweepDeleted("openstack_lb_loadbalancer_v2", lb.Name, lb.ID, err); err != nil {
+			return err
+		}
+
+		// The VIP port of the load balancer is released once it is gone.
+		err = waitForLBV2LoadBalancer(ctx, lbClient, lb.ID, "DELETED", lbPendingDeleteStatuses, sweepLBV2Timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,384 @@
+package openstack
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+// Ports and floating IPs have no meaningful names in most acceptance tests,
+// so they are also swept when they belong to a swept network or instance.
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_networking_floatingip_v2",
+			sweep: sweepNetworkingV2FloatingIPs,
+		},
+		testSweeper{
+			name:         "openstack_networking_router_interface_v2",
+			dependencies: []string{"openstack_vpnaas_service_v2"},
+			sweep:        sweepNetworkingV2RouterInterfaces,
+		},
+		testSweeper{
+			name: "openstack_networking_router_v2",
+			dependencies: []string{
+				"openstack_fw_firewall_v1",
+				"openstack_networking_router_interface_v2",
+			},
+			sweep: sweepNetworkingV2Routers,
+		},
+		testSweeper{
+			name:         "openstack_networking_trunk_v2",
+			dependencies: []string{"openstack_compute_instance_v2"},
+			sweep:        sweepNetworkingV2Trunks,
+		},
+		testSweeper{
+			name: "openstack_networking_port_v2",
+			dependencies: []string{
+				"openstack_compute_instance_v2",
+				"openstack_lb_loadbalancer_v2",
+				"openstack_networking_floatingip_v2",
+				"openstack_networking_router_interface_v2",
+				"openstack_networking_trunk_v2",
+			},
+			sweep: sweepNetworkingV2Ports,
+		},
+		testSweeper{
+			name: "openstack_networking_network_v2",
+			dependencies: []string{
+				"openstack_networking_port_v2",
+				"openstack_networking_router_interface_v2",
+			},
+			sweep: sweepNetworkingV2Networks,
+		},
+		testSweeper{
+			name: "openstack_networking_secgroup_v2",
+			dependencies: []string{
+				"openstack_compute_instance_v2",
+				"openstack_networking_port_v2",
+			},
+			sweep: sweepNetworkingV2SecGroups,
+		},
+	)
+}
+
+// sweepNetworkingV2Client returns the networking client, or nil if the cloud
+// offers no networking service.
+func sweepNetworkingV2Client(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, error) {
+	networkingClient, err := config.NetworkingV2Client(ctx, region)
+	if err != nil && sweepUnsupported(err) {
+		return nil, nil
+	}
+
+	return networkingClient, err
+}
+
+// sweepNetworkingV2SweptNetworkIDs returns the IDs of the networks which are
+// swept.
+func sweepNetworkingV2SweptNetworkIDs(networkingClient *gophercloud.ServiceClient) (map[string]bool, error) {
+	allPages, err := networks.List(networkingClient, networks.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allNetworks, err := networks.ExtractNetworks(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, network := range allNetworks {
+		if sweepable(network.Name) {
+			ids[network.ID] = true
+		}
+	}
+
+	return ids, nil
+}
+
+func sweepNetworkingV2AllPorts(networkingClient *gophercloud.ServiceClient) ([]ports.Port, error) {
+	allPages, err := ports.List(networkingClient, ports.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	return ports.ExtractPorts(allPages)
+}
+
+func sweepNetworkingV2FloatingIPs(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	networkIDs, err := sweepNetworkingV2SweptNetworkIDs(networkingClient)
+	if err != nil {
+		return err
+	}
+	_, instances, err := sweepComputeV2SweptInstances(ctx, config, region)
+	if err != nil {
+		return err
+	}
+	instanceIDs := make(map[string]bool)
+	for _, s := range instances {
+		instanceIDs[s.ID] = true
+	}
+
+	allPorts, err := sweepNetworkingV2AllPorts(networkingClient)
+	if err != nil {
+		return err
+	}
+	portIDs := make(map[string]bool)
+	for _, port := range allPorts {
+		if sweepable(port.Name) || networkIDs[port.NetworkID] || instanceIDs[port.DeviceID] {
+			portIDs[port.ID] = true
+		}
+	}
+
+	allPages, err := floatingips.List(networkingClient, floatingips.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allFloatingIPs, err := floatingips.ExtractFloatingIPs(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, fip := range allFloatingIPs {
+		if !sweepable(fip.Description) && !portIDs[fip.PortID] {
+			continue
+		}
+
+		err := floatingips.Delete(networkingClient, fip.ID).ExtractErr()
+		if err := sweepDeleted("openstack_networking_floatingip_v2", fip.FloatingIP, fip.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepNetworkingV2SweptRouterIDs returns the IDs of the routers which are
+// swept.
+func sweepNetworkingV2SweptRouterIDs(networkingClient *gophercloud.ServiceClient) (map[string]bool, error) {
+	allPages, err := routers.List(networkingClient, routers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allRouters, err := routers.ExtractRouters(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, router := range allRouters {
+		if sweepable(router.Name) {
+			ids[router.ID] = true
+		}
+	}
+
+	return ids, nil
+}
+
+// sweepNetworkingV2RouterInterfaces removes the interfaces of swept routers
+// and the interfaces other routers have on swept networks.
+func sweepNetworkingV2RouterInterfaces(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	routerIDs, err := sweepNetworkingV2SweptRouterIDs(networkingClient)
+	if err != nil {
+		return err
+	}
+	networkIDs, err := sweepNetworkingV2SweptNetworkIDs(networkingClient)
+	if err != nil {
+		return err
+	}
+	allPorts, err := sweepNetworkingV2AllPorts(networkingClient)
+	if err != nil {
+		return err
+	}
+
+	for _, port := range allPorts {
+		if !strings.HasPrefix(port.DeviceOwner, "network:router_interface") {
+			continue
+		}
+		if !routerIDs[port.DeviceID] && !networkIDs[port.NetworkID] {
+			continue
+		}
+
+		_, err := routers.RemoveInterface(networkingClient, port.DeviceID, routers.RemoveInterfaceOpts{
+			PortID: port.ID,
+		}).Extract()
+		if err := sweepDeleted("openstack_networking_router_interface_v2", port.DeviceID, port.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepNetworkingV2Routers(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := routers.List(networkingClient, routers.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allRouters, err := routers.ExtractRouters(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, router := range allRouters {
+		if !sweepable(router.Name) {
+			continue
+		}
+
+		err := routers.Delete(networkingClient, router.ID).ExtractErr()
+		if err := sweepDeleted("openstack_networking_router_v2", router.Name, router.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepNetworkingV2Trunks(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := trunks.List(networkingClient, trunks.ListOpts{}).AllPages()
+	if err != nil {
+		// The trunk extension is optional.
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allTrunks, err := trunks.ExtractTrunks(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, trunk := range allTrunks {
+		if !sweepable(trunk.Name) {
+			continue
+		}
+
+		err := trunks.Delete(networkingClient, trunk.ID).ExtractErr()
+		if err := sweepDeleted("openstack_networking_trunk_v2", trunk.Name, trunk.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepNetworkingV2Ports deletes swept ports and the ports left on swept
+// networks. Ports owned by Neutron itself, such as DHCP ports and router
+// interfaces, are left to their owners.
+func sweepNetworkingV2Ports(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	networkIDs, err := sweepNetworkingV2SweptNetworkIDs(networkingClient)
+	if err != nil {
+		return err
+	}
+	allPorts, err := sweepNetworkingV2AllPorts(networkingClient)
+	if err != nil {
+		return err
+	}
+
+	for _, port := range allPorts {
+		if strings.HasPrefix(port.DeviceOwner, "network:") {
+			continue
+		}
+		if !sweepable(port.Name) && !networkIDs[port.NetworkID] {
+			continue
+		}
+
+		err := ports.Delete(networkingClient, port.ID).ExtractErr()
+		if err := sweepDeleted("openstack_networking_port_v2", port.Name, port.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepNetworkingV2Networks deletes swept networks together with their
+// subnets.
+func sweepNetworkingV2Networks(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := networks.List(networkingClient, networks.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allNetworks, err := networks.ExtractNetworks(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, network := range allNetworks {
+		if !sweepable(network.Name) {
+			continue
+		}
+
+		err := networks.Delete(networkingClient, network.ID).ExtractErr()
+		if err := sweepDeleted("openstack_networking_network_v2", network.Name, network.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepNetworkingV2SecGroups(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := groups.List(networkingClient, groups.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allGroups, err := groups.ExtractGroups(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range allGroups {
+		if group.Name == "default" || !sweepable(group.Name) {
+			continue
+		}
+
+		err := groups.Delete(networkingClient, group.ID).ExtractErr()
+		if err := sweepDeleted("openstack_networking_secgroup_v2", group.Name, group.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
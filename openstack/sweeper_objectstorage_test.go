@@ -0,0 +1,64 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
+)
+
+func init() {
+	addTestSweepers(testSweeper{
+		name:  "openstack_objectstorage_container_v1",
+		sweep: sweepObjectStorageV1Containers,
+	})
+}
+
+// sweepObjectStorageV1Containers deletes containers together with their
+// objects, which Swift requires to be deleted first.
+func sweepObjectStorageV1Containers(ctx context.Context, config *Config, region string) error {
+	objectStorageClient, err := config.ObjectStorageV1Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+
+	allPages, err := containers.List(objectStorageClient, &containers.ListOpts{Full: true}).AllPages()
+	if err != nil {
+		return err
+	}
+	allContainers, err := containers.ExtractInfo(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, container := range allContainers {
+		if !sweepable(container.Name) {
+			continue
+		}
+
+		allPages, err := objects.List(objectStorageClient, container.Name, &objects.ListOpts{}).AllPages()
+		if err != nil {
+			return err
+		}
+		allObjects, err := objects.ExtractNames(allPages)
+		if err != nil {
+			return err
+		}
+		for _, object := range allObjects {
+			_, err := objects.Delete(objectStorageClient, container.Name, object, objects.DeleteOpts{}).Extract()
+			if err := sweepDeleted("openstack_objectstorage_object_v1", object, container.Name, err); err != nil {
+				return err
+			}
+		}
+
+		_, err = containers.Delete(objectStorageClient, container.Name).Extract()
+		if err := sweepDeleted("openstack_objectstorage_container_v1", container.Name, container.Name, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/orchestration/v1/stacks"
+)
+
+func init() {
+	addTestSweepers(testSweeper{
+		name:  "openstack_orchestration_stack_v1",
+		sweep: sweepOrchestrationV1Stacks,
+	})
+}
+
+// sweepOrchestrationV1Stacks deletes stacks, which delete the resources they
+// created in turn.
+func sweepOrchestrationV1Stacks(ctx context.Context, config *Config, region string) error {
+	orchestrationClient, err := config.OrchestrationV1Client(ctx, region)
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+
+	allPages, err := stacks.List(orchestrationClient, stacks.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allStacks, err := stacks.ExtractStacks(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, stack := range allStacks {
+		if !sweepable(stack.Name) {
+			continue
+		}
+
+		err := stacks.Delete(orchestrationClient, stack.Name, stack.ID).ExtractErr()
+		if err := sweepDeleted("openstack_orchestration_stack_v1", stack.Name, stack.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package openstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/securityservices"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/shares"
+)
+
+func init() {
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_sharedfilesystem_share_v2",
+			sweep: sweepSharedFilesystemV2Shares,
+		},
+		testSweeper{
+			name:         "openstack_sharedfilesystem_sharenetwork_v2",
+			dependencies: []string{"openstack_sharedfilesystem_share_v2"},
+			sweep:        sweepSharedFilesystemV2ShareNetworks,
+		},
+		testSweeper{
+			name:         "openstack_sharedfilesystem_securityservice_v2",
+			dependencies: []string{"openstack_sharedfilesystem_sharenetwork_v2"},
+			sweep:        sweepSharedFilesystemV2SecurityServices,
+		},
+	)
+}
+
+// sweepSharedFilesystemV2Client returns the shared file system client, or nil
+// if the cloud offers no shared file system service.
+func sweepSharedFilesystemV2Client(ctx context.Context, config *Config, region string) (*gophercloud.ServiceClient, error) {
+	sfsClient, err := config.SharedfilesystemV2Client(ctx, region)
+	if err != nil && sweepUnsupported(err) {
+		return nil, nil
+	}
+
+	return sfsClient, err
+}
+
+// sweepSharedFilesystemV2Shares deletes shares and waits for them to go away,
+// since their share networks cannot be deleted before.
+func sweepSharedFilesystemV2Shares(ctx context.Context, config *Config, region string) error {
+	sfsClient, err := sweepSharedFilesystemV2Client(ctx, config, region)
+	if sfsClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := shares.ListDetail(sfsClient, shares.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allShares, err := shares.ExtractShares(allPages)
+	if err != nil {
+		return err
+	}
+
+	var deleted []string
+	for _, share := range allShares {
+		if !sweepable(share.Name) {
+			continue
+		}
+
+		err := shares.Delete(sfsClient, share.ID).ExtractErr()
+		if err := sweepDeleted("openstack_sharedfilesystem_share_v2", share.Name, share.ID, err); err != nil {
+			return err
+		}
+		deleted = append(deleted, share.ID)
+	}
+
+	for _, id := range deleted {
+		err := sweepWaitForDeleted(ctx, 10*time.Minute, func() (string, error) {
+			share, err := shares.Get(sfsClient, id).Extract()
+			if err != nil {
+				return "", err
+			}
+			return share.Status, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepSharedFilesystemV2ShareNetworks(ctx context.Context, config *Config, region string) error {
+	sfsClient, err := sweepSharedFilesystemV2Client(ctx, config, region)
+	if sfsClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := sharenetworks.ListDetail(sfsClient, sharenetworks.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allShareNetworks, err := sharenetworks.ExtractShareNetworks(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, sn := range allShareNetworks {
+		if !sweepable(sn.Name) {
+			continue
+		}
+
+		err := sharenetworks.Delete(sfsClient, sn.ID).ExtractErr()
+		if err := sweepDeleted("openstack_sharedfilesystem_sharenetwork_v2", sn.Name, sn.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepSharedFilesystemV2SecurityServices(ctx context.Context, config *Config, region string) error {
+	sfsClient, err := sweepSharedFilesystemV2Client(ctx, config, region)
+	if sfsClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := securityservices.List(sfsClient, securityservices.ListOpts{}).AllPages()
+	if err != nil {
+		return err
+	}
+	allSecurityServices, err := securityservices.ExtractSecurityServices(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, ss := range allSecurityServices {
+		if !sweepable(ss.Name) {
+			continue
+		}
+
+		err := securityservices.Delete(sfsClient, ss.ID).ExtractErr()
+		if err := sweepDeleted("openstack_sharedfilesystem_securityservice_v2", ss.Name, ss.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
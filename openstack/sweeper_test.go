@@ -0,0 +1,407 @@
+package openstack
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/listeners"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/pools"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terraform-providers/terraform-provider-openstack/internal/fakeopenstack"
+)
+
+// defaultSweepPrefixes are the name prefixes of the objects the acceptance
+// tests create with acctest.RandomWithPrefix("tf-acc-...") and of the
+// ACPTTEST DNS zones. Objects with the fixed names of some test
+// configurations, like network_1 and instance_1, are only swept when their
+// prefixes are passed explicitly, e.g.
+// -sweep-prefix=tf-acc,ACPTTEST,instance_,network_,router_, since such
+// names may well belong to real infrastructure.
+const defaultSweepPrefixes = "tf-acc,ACPTTEST"
+
+// sweepPrefixes selects the objects the sweepers delete by the prefix of
+// their name.
+var sweepPrefixes = flag.String("sweep-prefix", defaultSweepPrefixes,
+	"comma-separated name prefixes of the objects to sweep")
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// testSweeper deletes the objects of one kind which acceptance tests leaked.
+type testSweeper struct {
+	name         string
+	dependencies []string
+	sweep        func(ctx context.Context, config *Config, region string) error
+}
+
+// testSweepers are all registered sweepers in the order of registration.
+var testSweepers []testSweeper
+
+// addTestSweepers registers sweepers to be run with
+// `go test ./openstack -v -sweep=<regions> [-sweep-prefix=<prefixes>]`.
+// A sweeper runs after the sweepers it depends on.
+func addTestSweepers(sweepers ...testSweeper) {
+	for _, s := range sweepers {
+		sweep := s.sweep
+		testSweepers = append(testSweepers, s)
+		resource.AddTestSweepers(s.name, &resource.Sweeper{
+			Name:         s.name,
+			Dependencies: s.dependencies,
+			F: func(region string) error {
+				config, err := sweeperConfig(region)
+				if err != nil {
+					return err
+				}
+
+				return sweep(context.Background(), config, region)
+			},
+		})
+	}
+}
+
+// sweeperConfig configures the provider for a region from the OS_*
+// environment variables.
+func sweeperConfig(region string) (*Config, error) {
+	p := Provider()
+	raw := map[string]interface{}{
+		"region": region,
+	}
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		return nil, fmt.Errorf("Error configuring provider for region %s: %v", region, diags)
+	}
+
+	return p.Meta().(*Config), nil
+}
+
+// sweepable returns true if name starts with one of the sweep prefixes.
+func sweepable(name string) bool {
+	for _, prefix := range strings.Split(*sweepPrefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sweepUnsupported returns true if err means the cloud does not offer the
+// service or extension a sweeper lists, so there is nothing to sweep.
+func sweepUnsupported(err error) bool {
+	switch err.(type) {
+	case *gophercloud.ErrEndpointNotFound, gophercloud.ErrDefault404:
+		return true
+	}
+
+	return false
+}
+
+// sweepDeleted logs the deletion of an object and ignores objects which are
+// already gone.
+func sweepDeleted(kind, name, id string, err error) error {
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+
+		return fmt.Errorf("Error sweeping %s %s (%s): %s", kind, name, id, err)
+	}
+
+	log.Printf("[INFO] Swept %s %s (%s)", kind, name, id)
+
+	return nil
+}
+
+// sweepWaitForDeleted waits until get reports an object being swept as
+// gone. get returns the status of the object or the error of looking it up.
+func sweepWaitForDeleted(ctx context.Context, timeout time.Duration, get func() (string, error)) error {
+	stateConf := &resource.StateChangeConf{
+		Target: []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			status, err := get()
+			if err != nil {
+				if _, ok := err.(gophercloud.ErrDefault404); ok {
+					return "", "DELETED", nil
+				}
+				return nil, "", err
+			}
+
+			return status, status, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 1 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+// runTestSweepers runs all registered sweepers against config in dependency
+// order, like `go test -sweep` does.
+func runTestSweepers(t *testing.T, config *Config, region string) {
+	byName := make(map[string]testSweeper)
+	var names []string
+	for _, s := range testSweepers {
+		byName[s.name] = s
+		names = append(names, s.name)
+	}
+	sort.Strings(names)
+
+	ran := make(map[string]bool)
+	var run func(name string)
+	run = func(name string) {
+		if ran[name] {
+			return
+		}
+		ran[name] = true
+
+		s, ok := byName[name]
+		if !ok {
+			t.Fatalf("Unknown sweeper dependency %s", name)
+		}
+		for _, dep := range s.dependencies {
+			run(dep)
+		}
+		if err := s.sweep(context.Background(), config, region); err != nil {
+			t.Fatalf("Error running sweeper %s: %s", name, err)
+		}
+	}
+	for _, name := range names {
+		run(name)
+	}
+}
+
+func TestSweepers(t *testing.T) {
+	srv, config := testFakeProvider(t)
+	defer srv.Close()
+
+	ctx := context.Background()
+	networkingClient, err := config.NetworkingV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack networking client: %s", err)
+	}
+	computeClient, err := config.ComputeV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack compute client: %s", err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack block storage client: %s", err)
+	}
+
+	// Both a swept and a kept network with a router interface and an
+	// instance each.
+	var externalNetworkID string
+	for _, network := range srv.Objects(fakeopenstack.NetworkNetworks) {
+		if network["name"] == fakeopenstack.ExternalNetworkName {
+			externalNetworkID = network["id"].(string)
+		}
+	}
+	image := srv.Objects(fakeopenstack.ImageImages)[0]
+	subnetIDs := make(map[string]string)
+	serverIDs := make(map[string]string)
+	for i, name := range []string{"tf-acc-sweep", "network-prod"} {
+		network, err := networks.Create(networkingClient, networks.CreateOpts{Name: name}).Extract()
+		if err != nil {
+			t.Fatalf("Error creating network: %s", err)
+		}
+		subnet, err := subnets.Create(networkingClient, subnets.CreateOpts{
+			NetworkID: network.ID,
+			CIDR:      fmt.Sprintf("192.168.%d.0/24", 10+i),
+			IPVersion: 4,
+		}).Extract()
+		if err != nil {
+			t.Fatalf("Error creating subnet: %s", err)
+		}
+		subnetIDs[name] = subnet.ID
+
+		router, err := routers.Create(networkingClient, routers.CreateOpts{
+			Name:        strings.Replace(name, "network", "router", 1),
+			GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetworkID},
+		}).Extract()
+		if err != nil {
+			t.Fatalf("Error creating router: %s", err)
+		}
+		_, err = routers.AddInterface(networkingClient, router.ID, routers.AddInterfaceOpts{
+			SubnetID: subnet.ID,
+		}).Extract()
+		if err != nil {
+			t.Fatalf("Error adding router interface: %s", err)
+		}
+
+		server, err := servers.Create(computeClient, servers.CreateOpts{
+			Name:      strings.Replace(name, "network", "instance", 1),
+			ImageRef:  image["id"].(string),
+			FlavorRef: "2",
+			Networks:  []servers.Network{{UUID: network.ID}},
+		}).Extract()
+		if err != nil {
+			t.Fatalf("Error creating server: %s", err)
+		}
+		if err := srv.SetStatus(fakeopenstack.ComputeServers, server.ID, "ACTIVE"); err != nil {
+			t.Fatal(err)
+		}
+		serverIDs[name] = server.ID
+	}
+
+	// A floating IP of the swept instance, identified by its port only.
+	instancePorts, err := ports.List(networkingClient, ports.ListOpts{DeviceID: serverIDs["tf-acc-sweep"]}).AllPages()
+	if err != nil {
+		t.Fatalf("Error listing ports: %s", err)
+	}
+	allInstancePorts, err := ports.ExtractPorts(instancePorts)
+	if err != nil || len(allInstancePorts) != 1 {
+		t.Fatalf("Error extracting instance ports: %v", err)
+	}
+	_, err = floatingips.Create(networkingClient, floatingips.CreateOpts{
+		FloatingNetworkID: externalNetworkID,
+		PortID:            allInstancePorts[0].ID,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating floating IP: %s", err)
+	}
+
+	// An unnamed port using a swept security group.
+	group, err := groups.Create(networkingClient, groups.CreateOpts{Name: "tf-acc-sweep"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating security group: %s", err)
+	}
+	_, err = ports.Create(networkingClient, ports.CreateOpts{
+		NetworkID:      allInstancePorts[0].NetworkID,
+		SecurityGroups: &[]string{group.ID},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating port: %s", err)
+	}
+
+	// A load balancer whose children are unnamed.
+	lb, err := loadbalancers.Create(networkingClient, loadbalancers.CreateOpts{
+		Name:        "tf-acc-sweep",
+		VipSubnetID: subnetIDs["tf-acc-sweep"],
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating load balancer: %s", err)
+	}
+	if err := srv.SetStatus(fakeopenstack.LBLoadBalancers, lb.ID, "ACTIVE"); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := listeners.Create(networkingClient, listeners.CreateOpts{
+		LoadbalancerID: lb.ID,
+		Protocol:       listeners.ProtocolHTTP,
+		ProtocolPort:   80,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating listener: %s", err)
+	}
+	pool, err := pools.Create(networkingClient, pools.CreateOpts{
+		ListenerID: listener.ID,
+		LBMethod:   pools.LBMethodRoundRobin,
+		Protocol:   pools.ProtocolHTTP,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating pool: %s", err)
+	}
+	_, err = pools.CreateMember(networkingClient, pool.ID, pools.CreateMemberOpts{
+		Address:      "192.168.10.100",
+		ProtocolPort: 8080,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating member: %s", err)
+	}
+
+	_, err = volumes.Create(blockStorageClient, volumes.CreateOpts{Name: "tf-acc-sweep", Size: 1}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating volume: %s", err)
+	}
+	imageClient, err := config.ImageV2Client(ctx, srv.Region)
+	if err != nil {
+		t.Fatalf("Error creating OpenStack image client: %s", err)
+	}
+	_, err = images.Create(imageClient, images.CreateOpts{Name: "tf-acc-sweep"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating image: %s", err)
+	}
+	_, err = keypairs.Create(computeClient, keypairs.CreateOpts{
+		Name:      "tf-acc-sweep",
+		PublicKey: "ssh-rsa AAAAB3NzaC1yc2E fake",
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating keypair: %s", err)
+	}
+
+	// Load balancer objects go away as soon as they are deleted.
+	for _, kind := range []fakeopenstack.Kind{
+		fakeopenstack.LBLoadBalancers,
+		fakeopenstack.LBListeners,
+		fakeopenstack.LBPools,
+		fakeopenstack.LBMembers,
+	} {
+		srv.SetLifecycle(kind, fakeopenstack.Lifecycle{})
+	}
+
+	runTestSweepers(t, config, srv.Region)
+
+	names := func(kind fakeopenstack.Kind) []string {
+		var names []string
+		for _, obj := range srv.Objects(kind) {
+			if name, _ := obj["name"].(string); name != "" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	assert.Equal(t, []string{"network-prod", fakeopenstack.ExternalNetworkName}, names(fakeopenstack.NetworkNetworks))
+	assert.Equal(t, []string{"router-prod"}, names(fakeopenstack.NetworkRouters))
+	assert.Equal(t, []string{"instance-prod"}, names(fakeopenstack.ComputeServers))
+	assert.Equal(t, []string{"default"}, names(fakeopenstack.NetworkSecGroups))
+	assert.Empty(t, srv.Objects(fakeopenstack.NetworkFloatIPs))
+	assert.Empty(t, srv.Objects(fakeopenstack.LBLoadBalancers))
+	assert.Empty(t, srv.Objects(fakeopenstack.LBPools))
+	assert.Empty(t, srv.Objects(fakeopenstack.LBMembers))
+	for _, volume := range srv.Objects(fakeopenstack.VolumeVolumes) {
+		assert.Equal(t, "deleting", volume["status"])
+	}
+	assert.Empty(t, srv.Objects(fakeopenstack.ComputeKeypairs))
+	assert.Equal(t, []string{image["name"].(string)}, names(fakeopenstack.ImageImages))
+	for _, port := range srv.Objects(fakeopenstack.NetworkPorts) {
+		assert.NotEqual(t, allInstancePorts[0].NetworkID, port["network_id"])
+	}
+}
+
+func TestSweepable(t *testing.T) {
+	defer func(prefixes string) { *sweepPrefixes = prefixes }(*sweepPrefixes)
+
+	assert.True(t, sweepable("tf-acc-network-123"))
+	assert.True(t, sweepable("ACPTTEST-zone-abcde.com."))
+	assert.False(t, sweepable("network_1"))
+	assert.False(t, sweepable("instance_1"))
+	assert.False(t, sweepable("network-prod"))
+	assert.False(t, sweepable("default"))
+
+	*sweepPrefixes = "tf-acc, network_"
+	assert.True(t, sweepable("network_1"))
+	assert.False(t, sweepable("instance_1"))
+	assert.False(t, sweepable(""))
+}
@@ -0,0 +1,204 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/endpointgroups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/ipsecpolicies"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/services"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/siteconnections"
+)
+
+// Site connections refer to all other VPNaaS objects, so they are swept
+// first. Clouds without the VPNaaS extension answer the listings with 404,
+// which leaves nothing to sweep.
+func init() {
+	siteConnections := []string{"openstack_vpnaas_site_connection_v2"}
+	addTestSweepers(
+		testSweeper{
+			name:  "openstack_vpnaas_site_connection_v2",
+			sweep: sweepVPNaaSV2SiteConnections,
+		},
+		testSweeper{
+			name:         "openstack_vpnaas_service_v2",
+			dependencies: siteConnections,
+			sweep:        sweepVPNaaSV2Services,
+		},
+		testSweeper{
+			name:         "openstack_vpnaas_ike_policy_v2",
+			dependencies: siteConnections,
+			sweep:        sweepVPNaaSV2IKEPolicies,
+		},
+		testSweeper{
+			name:         "openstack_vpnaas_ipsec_policy_v2",
+			dependencies: siteConnections,
+			sweep:        sweepVPNaaSV2IPSecPolicies,
+		},
+		testSweeper{
+			name:         "openstack_vpnaas_endpoint_group_v2",
+			dependencies: siteConnections,
+			sweep:        sweepVPNaaSV2EndpointGroups,
+		},
+	)
+}
+
+func sweepVPNaaSV2SiteConnections(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := siteconnections.List(networkingClient, siteconnections.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allConnections, err := siteconnections.ExtractConnections(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, conn := range allConnections {
+		if !sweepable(conn.Name) {
+			continue
+		}
+
+		err := siteconnections.Delete(networkingClient, conn.ID).ExtractErr()
+		if err := sweepDeleted("openstack_vpnaas_site_connection_v2", conn.Name, conn.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepVPNaaSV2Services(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := services.List(networkingClient, services.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allServices, err := services.ExtractServices(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range allServices {
+		if !sweepable(service.Name) {
+			continue
+		}
+
+		err := services.Delete(networkingClient, service.ID).ExtractErr()
+		if err := sweepDeleted("openstack_vpnaas_service_v2", service.Name, service.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepVPNaaSV2IKEPolicies(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := ikepolicies.List(networkingClient, ikepolicies.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allPolicies, err := ikepolicies.ExtractPolicies(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range allPolicies {
+		if !sweepable(policy.Name) {
+			continue
+		}
+
+		err := ikepolicies.Delete(networkingClient, policy.ID).ExtractErr()
+		if err := sweepDeleted("openstack_vpnaas_ike_policy_v2", policy.Name, policy.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepVPNaaSV2IPSecPolicies(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := ipsecpolicies.List(networkingClient, ipsecpolicies.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allPolicies, err := ipsecpolicies.ExtractPolicies(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range allPolicies {
+		if !sweepable(policy.Name) {
+			continue
+		}
+
+		err := ipsecpolicies.Delete(networkingClient, policy.ID).ExtractErr()
+		if err := sweepDeleted("openstack_vpnaas_ipsec_policy_v2", policy.Name, policy.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sweepVPNaaSV2EndpointGroups(ctx context.Context, config *Config, region string) error {
+	networkingClient, err := sweepNetworkingV2Client(ctx, config, region)
+	if networkingClient == nil || err != nil {
+		return err
+	}
+
+	allPages, err := endpointgroups.List(networkingClient, endpointgroups.ListOpts{}).AllPages()
+	if err != nil {
+		if sweepUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	allGroups, err := endpointgroups.ExtractEndpointGroups(allPages)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range allGroups {
+		if !sweepable(group.Name) {
+			continue
+		}
+
+		err := endpointgroups.Delete(networkingClient, group.ID).ExtractErr()
+		if err := sweepDeleted("openstack_vpnaas_endpoint_group_v2", group.Name, group.ID, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
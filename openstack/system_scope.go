@@ -0,0 +1,80 @@
+package openstack
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/utils/terraform/auth"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type systemScopeContextKey struct{}
+
+// withSystemScope requests system-scoped service clients for the operations
+// using ctx. The clients stay project-scoped unless system_scope is enabled.
+func withSystemScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemScopeContextKey{}, true)
+}
+
+// systemScopeFromContext returns whether withSystemScope was applied to ctx.
+func systemScopeFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	systemScope, _ := ctx.Value(systemScopeContextKey{}).(bool)
+	return systemScope
+}
+
+// systemScoped makes all operations of a resource or data source request
+// system-scoped service clients. It is applied to the cloud-admin resources
+// in the provider's resource maps.
+func systemScoped(r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return f(withSystemScope(ctx), d, meta)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			return importState(withSystemScope(ctx), d, meta)
+		}
+	}
+
+	return r
+}
+
+// newSystemScopeConfig returns a copy of the base Config whose service
+// clients share the HTTP client of the base Config, but authenticate with
+// a system-scoped token.
+func (c *Config) newSystemScopeConfig(ao *gophercloud.AuthOptions) (*auth.Config, *gophercloud.AuthOptions, error) {
+	client, err := openstack.NewClient(ao.IdentityEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	client.HTTPClient = c.OsClient.HTTPClient
+	client.UserAgent = c.OsClient.UserAgent
+
+	config := c.Config
+	config.OsClient = client
+
+	systemAO := *ao
+	systemAO.Scope = &gophercloud.AuthScope{System: true}
+	systemAO.TenantID = ""
+	systemAO.TenantName = ""
+
+	return &config, &systemAO, nil
+}
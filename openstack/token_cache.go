@@ -0,0 +1,261 @@
+package openstack
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/mitchellh/go-homedir"
+)
+
+// tokenCacheExpiryMargin is the time before their expiry after which cached
+// tokens are not used anymore, so that they do not expire during a run.
+const tokenCacheExpiryMargin = 5 * time.Minute
+
+// tokenCacheHashKeyFile is the file in the token cache directory which holds
+// the random key the credentials are hashed with.
+const tokenCacheHashKeyFile = "hash.key"
+
+// tokenCache stores Keystone tokens in a directory, one file per set of
+// credentials. The files are readable by the owner only and are encrypted
+// if a key is given. The files are named by a keyed hash of the credentials,
+// so that the names can't be used to guess the secrets.
+type tokenCache struct {
+	dir     string
+	aead    cipher.AEAD
+	hashKey []byte
+}
+
+// cachedToken is a cached token along with the body of the token response,
+// which contains the service catalog.
+type cachedToken struct {
+	ID        string      `json:"id"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	Body      interface{} `json:"body"`
+}
+
+// defaultTokenCacheDir returns the token cache directory used if none is
+// configured.
+func defaultTokenCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "terraform-provider-openstack", "tokens"), nil
+}
+
+func newTokenCache(dir, key string) (*tokenCache, error) {
+	var err error
+	if dir == "" {
+		dir, err = defaultTokenCacheDir()
+	} else {
+		dir, err = homedir.Expand(dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error determining the token cache directory: %s", err)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("Error creating the token cache directory: %s", err)
+	}
+
+	hashKey, err := tokenCacheHashKey(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the token cache hash key: %s", err)
+	}
+
+	cache := &tokenCache{dir: dir, hashKey: hashKey}
+	if key != "" {
+		sum := sha256.Sum256([]byte(key))
+		block, err := aes.NewCipher(sum[:])
+		if err != nil {
+			return nil, err
+		}
+		cache.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cache, nil
+}
+
+// tokenCacheHashKey returns the random key of the token cache in dir,
+// creating it if it does not exist yet.
+func tokenCacheHashKey(dir string) ([]byte, error) {
+	path := filepath.Join(dir, tokenCacheHashKeyFile)
+	if key, err := ioutil.ReadFile(path); err == nil && len(key) > 0 {
+		return key, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	// The key is linked into place once it is complete, so that concurrent
+	// runs agree on the first key written.
+	f, err := ioutil.TempFile(dir, tokenCacheHashKeyFile+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(key); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Link(f.Name(), path); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+// key identifies the tokens issued for the given credentials by the auth
+// URL, the authentication method, the user, the scope and the secrets, so
+// that a token is not reused once its credentials changed. secrets holds the
+// secrets which are not part of the auth options. The credentials are hashed
+// with the key of the cache.
+func (c *tokenCache) key(authURL string, method []string, ao gophercloud.AuthOptions, secrets []string) string {
+	if c == nil {
+		return ""
+	}
+
+	scope := gophercloud.AuthScope{}
+	if ao.Scope != nil {
+		scope = *ao.Scope
+	}
+
+	key, _ := json.Marshal([]interface{}{
+		authURL,
+		method,
+		ao.UserID, ao.Username, ao.DomainID, ao.DomainName,
+		ao.ApplicationCredentialID, ao.ApplicationCredentialName,
+		ao.TenantID, ao.TenantName,
+		scope.ProjectID, scope.ProjectName, scope.DomainID, scope.DomainName, scope.System,
+		append([]string{ao.Password, ao.ApplicationCredentialSecret}, secrets...),
+	})
+	mac := hmac.New(sha256.New, c.hashKey)
+	mac.Write(key)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *tokenCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get returns the cached token for key if it is still valid long enough.
+func (c *tokenCache) get(key string) *cachedToken {
+	if c == nil {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[DEBUG] Unable to read cached OpenStack token: %s", err)
+		}
+		return nil
+	}
+
+	if c.aead != nil {
+		size := c.aead.NonceSize()
+		if len(data) < size {
+			log.Printf("[DEBUG] Invalid cached OpenStack token")
+			return nil
+		}
+		data, err = c.aead.Open(nil, data[:size], data[size:], nil)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to decrypt cached OpenStack token: %s", err)
+			return nil
+		}
+	}
+
+	var entry cachedToken
+	if err := json.Unmarshal(data, &entry); err != nil {
+		log.Printf("[DEBUG] Invalid cached OpenStack token: %s", err)
+		return nil
+	}
+
+	if time.Now().Add(tokenCacheExpiryMargin).After(entry.ExpiresAt) {
+		return nil
+	}
+
+	return &entry
+}
+
+// put stores the token of a successful token request under key.
+func (c *tokenCache) put(key string, result tokens.CreateResult) error {
+	if c == nil {
+		return nil
+	}
+
+	token, err := result.ExtractToken()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cachedToken{
+		ID:        token.ID,
+		ExpiresAt: token.ExpiresAt,
+		Body:      result.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.aead != nil {
+		nonce := make([]byte, c.aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return err
+		}
+		data = c.aead.Seal(nonce, nonce, data, nil)
+	}
+
+	// Write to a temporary file first, so that concurrent runs never read
+	// a partial token.
+	f, err := ioutil.TempFile(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), c.path(key))
+}
+
+// result returns the cached token as the result of a token request.
+func (t *cachedToken) result() tokens.CreateResult {
+	var result tokens.CreateResult
+	result.Body = t.Body
+	result.Header = http.Header{"X-Subject-Token": []string{t.ID}}
+
+	return result
+}
@@ -0,0 +1,184 @@
+package openstack
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTokenCacheResult(expiresAt time.Time) tokens.CreateResult {
+	var result tokens.CreateResult
+	result.Header = http.Header{"X-Subject-Token": []string{"token"}}
+	result.Body = map[string]interface{}{
+		"token": map[string]interface{}{
+			"expires_at": expiresAt.UTC().Format(gophercloud.RFC3339Milli),
+			"catalog":    []interface{}{},
+		},
+	}
+
+	return result
+}
+
+func TestTokenCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := newTokenCache(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, cache.get("key"))
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	assert.NoError(t, cache.put("key", testTokenCacheResult(expiresAt)))
+
+	info, err := os.Stat(filepath.Join(dir, "key.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entry := cache.get("key")
+	if assert.NotNil(t, entry) {
+		assert.True(t, expiresAt.Equal(entry.ExpiresAt))
+
+		id, err := entry.result().ExtractTokenID()
+		assert.NoError(t, err)
+		assert.Equal(t, "token", id)
+
+		_, err = entry.result().ExtractServiceCatalog()
+		assert.NoError(t, err)
+	}
+
+	// Tokens expiring soon are not used.
+	assert.NoError(t, cache.put("key", testTokenCacheResult(time.Now().Add(time.Minute))))
+	assert.Nil(t, cache.get("key"))
+}
+
+func TestTokenCacheEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, _ := newTokenCache(dir, "secret")
+	assert.NoError(t, cache.put("key", testTokenCacheResult(time.Now().Add(time.Hour))))
+	assert.NotNil(t, cache.get("key"))
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, "key.json"))
+	assert.NotContains(t, string(data), "expires_at")
+
+	other, _ := newTokenCache(dir, "other")
+	assert.Nil(t, other.get("key"))
+
+	plain, _ := newTokenCache(dir, "")
+	assert.Nil(t, plain.get("key"))
+}
+
+func TestTokenCacheKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := newTokenCache(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ao := gophercloud.AuthOptions{
+		Username:   "user",
+		Password:   "password",
+		DomainName: "Default",
+		TenantName: "project",
+	}
+	key := cache.key("https://keystone/v3/", []string{"password"}, ao, nil)
+	assert.Equal(t, key, cache.key("https://keystone/v3/", []string{"password"}, ao, nil))
+
+	// Tokens are not reused once the secrets changed.
+	changed := ao
+	changed.Password = "changed"
+	assert.NotEqual(t, key, cache.key("https://keystone/v3/", []string{"password"}, changed, nil))
+	assert.NotEqual(t, key, cache.key("https://keystone/v3/", []string{"password"}, ao, []string{"123456"}))
+
+	assert.NotEqual(t, key, cache.key("https://other/v3/", []string{"password"}, ao, nil))
+
+	changed = ao
+	changed.TenantName = "other"
+	assert.NotEqual(t, key, cache.key("https://keystone/v3/", []string{"password"}, changed, nil))
+
+	assert.NotEqual(t, key, cache.key("https://keystone/v3/", []string{"application_credential"}, ao, nil))
+
+	changed = ao
+	changed.Scope = &gophercloud.AuthScope{System: true}
+	assert.NotEqual(t, key, cache.key("https://keystone/v3/", []string{"password"}, changed, nil))
+
+	// The hash key is kept in the directory, other caches hash differently.
+	info, err := os.Stat(filepath.Join(dir, tokenCacheHashKeyFile))
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	same, _ := newTokenCache(dir, "")
+	assert.Equal(t, key, same.key("https://keystone/v3/", []string{"password"}, ao, nil))
+
+	otherDir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(otherDir)
+	other, _ := newTokenCache(otherDir, "")
+	assert.NotEqual(t, key, other.key("https://keystone/v3/", []string{"password"}, ao, nil))
+}
+
+func TestTokenCacheKeyFederated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := newTokenCache(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := func(c *Config) string {
+		return cache.key("https://keystone/v3/", c.federatedAuthMethod(), gophercloud.AuthOptions{}, c.tokenCacheSecrets())
+	}
+
+	config := func(modify func(*Config)) *Config {
+		c := &Config{
+			AuthType:         "v3oidcaccesstoken",
+			IdentityProvider: "idp",
+			Protocol:         "openid",
+			AccessToken:      "access-token-1",
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+	base := key(config(nil))
+
+	// Different users of the same identity provider get different tokens.
+	assert.NotEqual(t, base, key(config(func(c *Config) { c.AccessToken = "access-token-2" })))
+
+	assert.NotEqual(t, base, key(config(func(c *Config) {
+		c.DiscoveryEndpoint = "https://other-idp/.well-known/openid-configuration"
+	})))
+	assert.NotEqual(t, base, key(config(func(c *Config) { c.AccessTokenEndpoint = "https://other-idp/token" })))
+	assert.NotEqual(t, base, key(config(func(c *Config) { c.IdentityProviderURL = "https://other-idp/saml" })))
+}
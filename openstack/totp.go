@@ -0,0 +1,56 @@
+package openstack
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TOTP parameters used by Keystone, see RFC 6238.
+const (
+	totpStep   = 30 * time.Second
+	totpDigits = 6
+)
+
+// decodeTOTPSecret decodes a base32 TOTP secret. Spaces, lower case letters
+// and missing padding are accepted, as authenticator apps display secrets
+// that way.
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Replace(secret, " ", "", -1))
+	secret = strings.TrimRight(secret, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid TOTP secret: %s", err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("Invalid TOTP secret: empty")
+	}
+
+	return key, nil
+}
+
+// totpPasscode returns the TOTP passcode of a base32 secret at time t.
+func totpPasscode(secret string, t time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/int64(totpStep/time.Second)))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as in RFC 4226, section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, code%1000000), nil
+}
@@ -0,0 +1,30 @@
+package openstack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPPasscode(t *testing.T) {
+	// Test vectors of RFC 6238, truncated to six digits.
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	for unix, expected := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		passcode, err := totpPasscode(secret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, passcode)
+	}
+
+	passcode, err := totpPasscode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", passcode)
+
+	_, err = totpPasscode("not base32!", time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Service names used to select per-service request limits. They follow the
+// official OpenStack service types.
+const (
+	serviceBlockStorage     = "block-storage"
+	serviceCompute          = "compute"
+	serviceContainerInfra   = "container-infra"
+	serviceDatabase         = "database"
+	serviceDNS              = "dns"
+	serviceIdentity         = "identity"
+	serviceImage            = "image"
+	serviceKeyManager       = "key-manager"
+	serviceLoadBalancer     = "load-balancer"
+	serviceNetwork          = "network"
+	serviceObjectStore      = "object-store"
+	serviceOrchestration    = "orchestration"
+	serviceSharedFileSystem = "shared-file-system"
+)
+
+var serviceNames = []string{
+	serviceBlockStorage,
+	serviceCompute,
+	serviceContainerInfra,
+	serviceDatabase,
+	serviceDNS,
+	serviceIdentity,
+	serviceImage,
+	serviceKeyManager,
+	serviceLoadBalancer,
+	serviceNetwork,
+	serviceObjectStore,
+	serviceOrchestration,
+	serviceSharedFileSystem,
+}
+
+type serviceContextKey struct{}
+
+// withService records the service a request is sent to in its context.
+func withService(ctx context.Context, service string) context.Context {
+	return context.WithValue(ctx, serviceContextKey{}, service)
+}
+
+// serviceFromContext returns the service recorded by withService.
+func serviceFromContext(ctx context.Context) string {
+	service, _ := ctx.Value(serviceContextKey{}).(string)
+	return service
+}
+
+// RequestLimit limits the API requests sent by the provider. Zero values
+// mean no limit.
+type RequestLimit struct {
+	// RequestsPerSecond is the maximum rate at which requests are sent.
+	RequestsPerSecond float64
+
+	// MaxInFlight is the maximum number of requests waiting for a response
+	// at the same time.
+	MaxInFlight int
+}
+
+func (l RequestLimit) isSet() bool {
+	return l.RequestsPerSecond > 0 || l.MaxInFlight > 0
+}
+
+// requestLimiter enforces a RequestLimit. Requests are spaced evenly, so
+// that bursts are not sent to the API.
+type requestLimiter struct {
+	interval time.Duration
+	slots    chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRequestLimiter(limit RequestLimit) *requestLimiter {
+	l := &requestLimiter{}
+	if limit.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / limit.RequestsPerSecond)
+	}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return l
+}
+
+// acquireSlot blocks until fewer than MaxInFlight requests are in flight or
+// ctx is done. Each successful acquireSlot must be followed by a
+// releaseSlot.
+func (l *requestLimiter) acquireSlot(ctx context.Context) error {
+	if l.slots == nil {
+		return nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *requestLimiter) releaseSlot() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// wait blocks until the rate limit allows the next request or ctx is done.
+// The send time reserved by a cancelled wait is given back, so cancelled
+// requests do not delay the requests that follow.
+func (l *requestLimiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.next = l.next.Add(-l.interval)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// limitTransport is an http.RoundTripper which applies a global and
+// per-service request limits. A request counts as in flight until its
+// response headers have been received.
+type limitTransport struct {
+	rt       http.RoundTripper
+	global   *requestLimiter
+	services map[string]*requestLimiter
+}
+
+func newLimitTransport(rt http.RoundTripper, global RequestLimit, services map[string]RequestLimit) http.RoundTripper {
+	t := &limitTransport{
+		rt:       rt,
+		services: make(map[string]*requestLimiter),
+	}
+
+	if global.isSet() {
+		t.global = newRequestLimiter(global)
+	}
+	for service, limit := range services {
+		if limit.isSet() {
+			t.services[service] = newRequestLimiter(limit)
+		}
+	}
+
+	if t.global == nil && len(t.services) == 0 {
+		return rt
+	}
+
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var limiters []*requestLimiter
+	if l, ok := t.services[serviceFromContext(ctx)]; ok {
+		limiters = append(limiters, l)
+	}
+	if t.global != nil {
+		limiters = append(limiters, t.global)
+	}
+
+	// All slots are acquired before the rate is waited for, so that a
+	// request cancelled while it waits for a slot does not use up the rate
+	// of the requests that follow.
+	var acquired []*requestLimiter
+	defer func() {
+		for _, l := range acquired {
+			l.releaseSlot()
+		}
+	}()
+	for _, l := range limiters {
+		if err := l.acquireSlot(ctx); err != nil {
+			return nil, err
+		}
+		acquired = append(acquired, l)
+	}
+	for _, l := range limiters {
+		if err := l.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.rt.RoundTrip(req)
+}
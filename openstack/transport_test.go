@@ -0,0 +1,139 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLimitRequest(t *testing.T, ctx context.Context, rt http.RoundTripper, url string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func TestLimitTransportUnlimited(t *testing.T) {
+	rt := newLimitTransport(http.DefaultTransport, RequestLimit{}, map[string]RequestLimit{
+		serviceNetwork: {},
+	})
+	assert.Equal(t, http.DefaultTransport, rt)
+}
+
+func TestLimitTransportRequestsPerSecond(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	rt := newLimitTransport(http.DefaultTransport, RequestLimit{RequestsPerSecond: 20}, nil)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, testLimitRequest(t, context.Background(), rt, srv.URL))
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+}
+
+func TestLimitTransportMaxInFlight(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	rt := newLimitTransport(http.DefaultTransport, RequestLimit{MaxInFlight: 2}, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, testLimitRequest(t, context.Background(), rt, srv.URL))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, maxInFlight)
+}
+
+func TestLimitTransportService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	rt := newLimitTransport(http.DefaultTransport, RequestLimit{}, map[string]RequestLimit{
+		serviceNetwork: {RequestsPerSecond: 0.1},
+	})
+
+	// Requests to other services are not limited.
+	compute := withService(context.Background(), serviceCompute)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, testLimitRequest(t, compute, rt, srv.URL))
+	}
+
+	network := withService(context.Background(), serviceNetwork)
+	assert.NoError(t, testLimitRequest(t, network, rt, srv.URL))
+
+	// The next network request has to wait 10 seconds and is cancelled
+	// before.
+	ctx, cancel := context.WithTimeout(network, 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, testLimitRequest(t, ctx, rt, srv.URL))
+}
+
+func TestLimitTransportCancelledInFlight(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/block" {
+			<-release
+		}
+	}))
+	defer srv.Close()
+
+	rt := newLimitTransport(http.DefaultTransport, RequestLimit{MaxInFlight: 1}, map[string]RequestLimit{
+		serviceNetwork: {RequestsPerSecond: 10},
+	})
+	network := withService(context.Background(), serviceNetwork)
+
+	done := make(chan error)
+	go func() {
+		done <- testLimitRequest(t, network, rt, srv.URL+"/block")
+	}()
+	time.Sleep(150 * time.Millisecond)
+
+	// Requests cancelled while they wait for the in-flight request do not
+	// take from the rate of the following requests.
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(network, 10*time.Millisecond)
+		assert.Error(t, testLimitRequest(t, ctx, rt, srv.URL))
+		cancel()
+	}
+	close(release)
+	assert.NoError(t, <-done)
+
+	start := time.Now()
+	assert.NoError(t, testLimitRequest(t, network, rt, srv.URL))
+	assert.Less(t, int64(time.Since(start)), int64(100*time.Millisecond))
+}
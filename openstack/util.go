@@ -121,22 +121,16 @@ func expandVendorOptions(vendOptsRaw []interface{}) map[string]interface{} {
 func expandObjectReadTags(d *schema.ResourceData, tags []string) {
 	d.Set("all_tags", tags)
 
-	allTags := d.Get("all_tags").(*schema.Set)
-	desiredTags := d.Get("tags").(*schema.Set)
-	actualTags := allTags.Intersection(desiredTags)
-	if !actualTags.Equal(desiredTags) {
-		d.Set("tags", expandToStringSlice(actualTags.List()))
+	desiredTags := expandTags(d.Get("tags"))
+	var actualTags []string
+	for _, tag := range desiredTags {
+		if strSliceContains(tags, tag) {
+			actualTags = append(actualTags, tag)
+		}
+	}
+	if len(actualTags) != len(desiredTags) {
+		d.Set("tags", actualTags)
 	}
-}
-
-func expandObjectUpdateTags(d *schema.ResourceData) []string {
-	allTags := d.Get("all_tags").(*schema.Set)
-	oldTagsRaw, newTagsRaw := d.GetChange("tags")
-	oldTags, newTags := oldTagsRaw.(*schema.Set), newTagsRaw.(*schema.Set)
-
-	allTagsWithoutOld := allTags.Difference(oldTags)
-
-	return expandToStringSlice(allTagsWithoutOld.Union(newTags).List())
 }
 
 func expandObjectTags(d *schema.ResourceData) []string {
@@ -276,6 +270,18 @@ func validateJsonObject(v interface{}, k string) ([]string, []error) {
 	return nil, nil
 }
 
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	d, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration: %s", k, err)}
+	}
+	if d < 0 {
+		return nil, []error{fmt.Errorf("%q must not be negative", k)}
+	}
+
+	return nil, nil
+}
+
 func diffSuppressJsonObject(k, old, new string, d *schema.ResourceData) bool {
 	if strSliceContains([]string{"{}", ""}, old) &&
 		strSliceContains([]string{"{}", ""}, new) {